@@ -0,0 +1,155 @@
+package nexus
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Authenticator adds the credentials needed by a Nexus server to a request
+type Authenticator interface {
+	Authenticate(request *http.Request) error
+}
+
+// TLSAuthenticator is implemented by authenticators which need to configure the TLS connection
+type TLSAuthenticator interface {
+	ConfigureTLS(config *tls.Config) error
+}
+
+// CredentialsProvider returns the username and password to authenticate with.
+// It is called for every request so that rotated secrets are picked up
+type CredentialsProvider func(ctx context.Context) (username, password string, err error)
+
+// TokenProvider returns the token to authenticate with.
+// It is called for every request so that rotated secrets are picked up
+type TokenProvider func(ctx context.Context) (token string, err error)
+
+// CertificateProvider returns the client certificate to authenticate with.
+// It is called for every TLS handshake so that rotated certificates are picked up
+type CertificateProvider func() (*tls.Certificate, error)
+
+// BasicAuth authenticates with a username and password
+type BasicAuth struct {
+	Username, Password string
+
+	// Provider, if set, is used instead of Username and Password
+	Provider CredentialsProvider
+}
+
+// Authenticate sets the basic auth header of the request
+func (a BasicAuth) Authenticate(request *http.Request) error {
+	username, password := a.Username, a.Password
+	if a.Provider != nil {
+		var err error
+		if username, password, err = a.Provider(request.Context()); err != nil {
+			return fmt.Errorf("could not retrieve credentials: %w", err)
+		}
+	}
+
+	request.SetBasicAuth(username, password)
+	return nil
+}
+
+// UserTokenAuth authenticates with a Nexus user token
+type UserTokenAuth struct {
+	NameCode, PassCode string
+
+	// Provider, if set, is used instead of NameCode and PassCode
+	Provider CredentialsProvider
+}
+
+// Authenticate sets the user token as the basic auth header of the request
+func (a UserTokenAuth) Authenticate(request *http.Request) error {
+	nameCode, passCode := a.NameCode, a.PassCode
+	if a.Provider != nil {
+		var err error
+		if nameCode, passCode, err = a.Provider(request.Context()); err != nil {
+			return fmt.Errorf("could not retrieve user token: %w", err)
+		}
+	}
+
+	if nameCode == "" || passCode == "" {
+		return errors.New("user token requires both a name code and a pass code")
+	}
+
+	request.SetBasicAuth(nameCode, passCode)
+	return nil
+}
+
+// BearerAuth authenticates with a bearer token, such as one issued by an SSO provider
+type BearerAuth struct {
+	Token string
+
+	// Provider, if set, is used instead of Token
+	Provider TokenProvider
+}
+
+// Authenticate sets the bearer token header of the request
+func (a BearerAuth) Authenticate(request *http.Request) error {
+	token := a.Token
+	if a.Provider != nil {
+		var err error
+		if token, err = a.Provider(request.Context()); err != nil {
+			return fmt.Errorf("could not retrieve bearer token: %w", err)
+		}
+	}
+
+	if token == "" {
+		return errors.New("no bearer token available")
+	}
+
+	request.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// ClientCertAuth authenticates with a client certificate (mutual TLS)
+type ClientCertAuth struct {
+	// CertFile and KeyFile are the PEM encoded certificate and its key. They are read on every handshake
+	CertFile, KeyFile string
+
+	// Certificate, if set, is used instead of CertFile and KeyFile
+	Certificate *tls.Certificate
+
+	// Provider, if set, is used instead of any of the other fields
+	Provider CertificateProvider
+
+	// Authenticator, if set, adds credentials to the requests in addition to the certificate
+	Authenticator Authenticator
+}
+
+// Authenticate defers to the wrapped Authenticator, if any, as the certificate is presented during the TLS handshake
+func (a ClientCertAuth) Authenticate(request *http.Request) error {
+	if a.Authenticator != nil {
+		return a.Authenticator.Authenticate(request)
+	}
+	return nil
+}
+
+// ConfigureTLS sets up the TLS configuration to present the client certificate
+func (a ClientCertAuth) ConfigureTLS(config *tls.Config) error {
+	if a.Provider == nil && a.Certificate == nil && (a.CertFile == "" || a.KeyFile == "") {
+		return errors.New("client certificate authentication requires a certificate")
+	}
+
+	config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		return a.certificate()
+	}
+	return nil
+}
+
+func (a ClientCertAuth) certificate() (*tls.Certificate, error) {
+	switch {
+	case a.Provider != nil:
+		return a.Provider()
+	case a.Certificate != nil:
+		return a.Certificate, nil
+	default:
+		cert, err := tls.LoadX509KeyPair(a.CertFile, a.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		return &cert, nil
+	}
+}
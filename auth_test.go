@@ -0,0 +1,113 @@
+package nexus
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBasicAuth(t *testing.T) {
+	client := NewClient(ServerInfo{Host: "http://nexus.example", Username: "legacy", Password: "legacy"}, WithAuthenticator(BasicAuth{Username: "user", Password: "pass"}))
+
+	request, err := client.NewRequest(http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user, pass, ok := request.BasicAuth(); !ok || user != "user" || pass != "pass" {
+		t.Errorf("Did not find expected credentials: %s %s", user, pass)
+	}
+}
+
+func TestDefaultBasicAuth(t *testing.T) {
+	client := &DefaultClient{ServerInfo: ServerInfo{Host: "http://nexus.example", Username: "user", Password: "pass"}}
+
+	request, err := client.NewRequest(http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user, pass, ok := request.BasicAuth(); !ok || user != "user" || pass != "pass" {
+		t.Errorf("Did not find expected credentials: %s %s", user, pass)
+	}
+}
+
+func TestUserTokenAuth(t *testing.T) {
+	var rotations int
+	auth := UserTokenAuth{Provider: func(ctx context.Context) (string, string, error) {
+		rotations++
+		return fmt.Sprintf("name%d", rotations), fmt.Sprintf("pass%d", rotations), nil
+	}}
+
+	client := NewClient(ServerInfo{Host: "http://nexus.example"}, WithAuthenticator(auth))
+
+	for i := 1; i <= 2; i++ {
+		request, err := client.NewRequest(http.MethodGet, "test", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantName, wantPass := fmt.Sprintf("name%d", i), fmt.Sprintf("pass%d", i)
+		if name, pass, _ := request.BasicAuth(); name != wantName || pass != wantPass {
+			t.Errorf("Expected rotated token %s:%s but got %s:%s", wantName, wantPass, name, pass)
+		}
+	}
+
+	if err := (UserTokenAuth{NameCode: "name"}).Authenticate(new(http.Request)); err == nil {
+		t.Error("Expected an error for an incomplete user token")
+	}
+}
+
+func TestBearerAuth(t *testing.T) {
+	client := NewClient(ServerInfo{Host: "http://nexus.example"}, WithAuthenticator(BearerAuth{Token: "sso-token"}))
+
+	request, err := client.NewRequest(http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := request.Header.Get("Authorization"); got != "Bearer sso-token" {
+		t.Errorf("Did not find expected authorization header: %q", got)
+	}
+}
+
+func TestAuthProviderError(t *testing.T) {
+	client := NewClient(ServerInfo{Host: "http://nexus.example"},
+		WithAuthenticator(BearerAuth{Provider: func(ctx context.Context) (string, error) {
+			return "", errors.New("vault sealed")
+		}}),
+	)
+
+	if _, _, err := client.Get("test"); err == nil {
+		t.Error("Expected an error when credentials could not be retrieved")
+	}
+}
+
+func TestClientCertAuth(t *testing.T) {
+	mock := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	mock.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	mock.StartTLS()
+	defer mock.Close()
+
+	cert := mock.TLS.Certificates[0]
+
+	client := NewClient(ServerInfo{Host: mock.URL}, WithTransport(mock.Client().Transport))
+
+	if _, _, err := client.Get("test"); err == nil {
+		t.Error("Expected request without a client certificate to fail")
+	}
+
+	client = NewClient(ServerInfo{Host: mock.URL}, WithTransport(mock.Client().Transport), WithAuthenticator(ClientCertAuth{Certificate: &cert}))
+
+	if _, _, err := client.Get("test"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,338 @@
+package nexus
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheEntries is the number of responses kept by a Cache whose policy does not set MaxEntries
+const DefaultCacheEntries = 1000
+
+// CacheClass is a class of endpoints whose responses are cached for the same time
+type CacheClass struct {
+	Name string
+
+	// Endpoints are the endpoints of the class, relative to the host, such as api/v2/applications.
+	// An endpoint ending with / also matches every endpoint under it. The query of a request is not
+	// matched, but it is part of what identifies a cached response
+	Endpoints []string
+
+	// TTL is how long a response is used without asking the server again. Zero disables caching
+	TTL time.Duration
+
+	// InvalidatedBy are endpoints, besides those of the class, whose mutation invalidates the class.
+	// Every endpoint under them matches
+	InvalidatedBy []string
+}
+
+// CachePolicy describes which GET responses are cached, and for how long
+type CachePolicy struct {
+	// Classes are matched in order against the endpoint of each GET request. Responses of
+	// endpoints which match no class are not cached
+	Classes []CacheClass
+
+	// MaxEntries caps the number of cached responses, evicting the least recently used ones.
+	// Defaults to DefaultCacheEntries
+	MaxEntries int
+}
+
+func (p CachePolicy) class(path string) *CacheClass {
+	for i, c := range p.Classes {
+		for _, e := range c.Endpoints {
+			if (strings.HasSuffix(e, "/") && strings.HasPrefix(path, e)) || path == e {
+				return &p.Classes[i]
+			}
+		}
+	}
+	return nil
+}
+
+// CacheStats counts how the GET requests of the cached classes were served
+type CacheStats struct {
+	// Hits were served from the cache without a request
+	Hits int64
+	// Revalidations were served from the cache once the server answered that they were not modified
+	Revalidations int64
+	// Misses were served by the server
+	Misses int64
+}
+
+// Cache keeps the responses of GET requests for the TTL of their class. Once expired, a response with an
+// ETag or Last-Modified header is revalidated with a conditional request, so that the server only sends
+// it again if it changed. Mutating requests (POST, PUT, DELETE...) invalidate the responses of their
+// endpoint and of the classes they belong to.
+// A Cache can be shared by several clients; responses are kept apart by URL and credentials
+type Cache struct {
+	policy CachePolicy
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	fetches map[*cacheFetch]bool
+	stats   CacheStats
+}
+
+// cacheFetch is a request in flight whose response may be stored. It is marked invalidated when its endpoint
+// is invalidated before the response arrives, so that a response which may be stale is not stored
+type cacheFetch struct {
+	class       *CacheClass
+	path        string
+	invalidated bool
+}
+
+type cacheEntry struct {
+	class        *CacheClass
+	path         string
+	body         []byte
+	header       http.Header
+	etag         string
+	lastModified string
+	expires      time.Time
+	used         time.Time
+}
+
+// NewCache creates an empty Cache
+func NewCache(policy CachePolicy) *Cache {
+	if policy.MaxEntries <= 0 {
+		policy.MaxEntries = DefaultCacheEntries
+	}
+	return &Cache{policy: policy, entries: make(map[string]*cacheEntry), fetches: make(map[*cacheFetch]bool)}
+}
+
+// Policy returns the policy of the Cache
+func (c *Cache) Policy() CachePolicy {
+	if c == nil {
+		return CachePolicy{}
+	}
+	return c.policy
+}
+
+// Stats returns how the requests were served so far
+func (c *Cache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Invalidate discards the responses of the given endpoints and of every endpoint under them
+func (c *Cache) Invalidate(endpoints ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	matches := func(path string) bool {
+		for _, e := range endpoints {
+			if underEndpoint(path, e) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for key, entry := range c.entries {
+		if matches(entry.path) {
+			delete(c.entries, key)
+		}
+	}
+	for f := range c.fetches {
+		if matches(f.path) {
+			f.invalidated = true
+		}
+	}
+}
+
+// Clear discards every response
+func (c *Cache) Clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.entries = make(map[string]*cacheEntry)
+	for f := range c.fetches {
+		f.invalidated = true
+	}
+	c.mu.Unlock()
+}
+
+// invalidateMutation discards the responses which a mutation of the endpoint may have changed
+func (c *Cache) invalidateMutation(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if mutates(entry.class, entry.path, path) {
+			delete(c.entries, key)
+		}
+	}
+	for f := range c.fetches {
+		if mutates(f.class, f.path, path) {
+			f.invalidated = true
+		}
+	}
+}
+
+// mutates determines if a mutation of the endpoint may change the response of the path, of the given class
+func mutates(class *CacheClass, path, endpoint string) bool {
+	return underEndpoint(path, endpoint) || underEndpoint(endpoint, path) || invalidates(class, endpoint)
+}
+
+func invalidates(class *CacheClass, path string) bool {
+	for _, endpoints := range [][]string{class.Endpoints, class.InvalidatedBy} {
+		for _, e := range endpoints {
+			if underEndpoint(path, e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// underEndpoint returns whether the path is the endpoint or one of the endpoints under it
+func underEndpoint(path, endpoint string) bool {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	return path == endpoint || strings.HasPrefix(path, endpoint+"/")
+}
+
+func cacheKey(request *http.Request) string {
+	auth := sha256.Sum256([]byte(request.Header.Get("Authorization")))
+	return request.URL.String() + " " + hex.EncodeToString(auth[:])
+}
+
+func cacheable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusOK && !strings.Contains(resp.Header.Get("Cache-Control"), "no-store")
+}
+
+// do serves a GET request from the cache when possible, and invalidates the cache after any other request
+func (c *Cache) do(request *http.Request, path string, doFunc func(*http.Request) ([]byte, *http.Response, error)) ([]byte, *http.Response, error) {
+	if c == nil {
+		return doFunc(request)
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+	case http.MethodHead, http.MethodOptions:
+		return doFunc(request)
+	default:
+		// The mutation may have been applied even if its response was lost
+		defer c.invalidateMutation(path)
+		return doFunc(request)
+	}
+
+	class := c.policy.class(path)
+	if class == nil || class.TTL <= 0 {
+		return doFunc(request)
+	}
+
+	key := cacheKey(request)
+	now := time.Now()
+
+	c.mu.Lock()
+	entry := c.entries[key]
+	if entry != nil && now.Before(entry.expires) {
+		entry.used = now
+		c.stats.Hits++
+		c.mu.Unlock()
+		return entry.response(request)
+	}
+	fetch := &cacheFetch{class: class, path: path}
+	c.fetches[fetch] = true
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.fetches, fetch)
+		c.mu.Unlock()
+	}()
+
+	conditional := request
+	if entry != nil && (entry.etag != "" || entry.lastModified != "") {
+		conditional = request.Clone(request.Context())
+		if entry.etag != "" {
+			conditional.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			conditional.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	body, resp, err := doFunc(conditional)
+	if err != nil {
+		return body, resp, err
+	}
+
+	now = time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A response which the cache was invalidated for while it was awaited is returned but not stored,
+	// since it may predate the mutation
+	if resp.StatusCode == http.StatusNotModified && conditional != request {
+		c.stats.Revalidations++
+		if !fetch.invalidated && c.entries[key] == entry {
+			entry.expires, entry.used = now.Add(class.TTL), now
+		}
+		return entry.response(request)
+	}
+
+	c.stats.Misses++
+	if fetch.invalidated {
+		return body, resp, nil
+	}
+	if !cacheable(resp) {
+		delete(c.entries, key)
+		return body, resp, nil
+	}
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.policy.MaxEntries {
+		c.evict()
+	}
+	c.entries[key] = &cacheEntry{
+		class:        class,
+		path:         path,
+		body:         append([]byte(nil), body...),
+		header:       resp.Header.Clone(),
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		expires:      now.Add(class.TTL),
+		used:         now,
+	}
+
+	return body, resp, nil
+}
+
+// evict discards the least recently used response
+func (c *Cache) evict() {
+	var oldest string
+	for key, entry := range c.entries {
+		if oldest == "" || entry.used.Before(c.entries[oldest].used) {
+			oldest = key
+		}
+	}
+	delete(c.entries, oldest)
+}
+
+// response returns a copy of the cached response
+func (e *cacheEntry) response(request *http.Request) ([]byte, *http.Response, error) {
+	body := append([]byte(nil), e.body...)
+	return body, &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
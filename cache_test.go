@@ -0,0 +1,252 @@
+package nexus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// cacheServer serves a list of items whose ETag changes when an item is added
+func cacheServer(opts ...Option) (*DefaultClient, *httptest.Server, *int32) {
+	var requests int32
+	version := 1
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/items":
+			version++
+		case r.Method == http.MethodGet && r.URL.Path == "/api/items":
+			etag := `"v` + strconv.Itoa(version) + `"`
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			w.Write([]byte(strings.Repeat("item ", version)))
+		case r.URL.Path == "/api/secret":
+			w.Header().Set("Cache-Control", "no-store")
+			w.Write([]byte("secret"))
+		default:
+			w.Write([]byte("other"))
+		}
+	}))
+
+	client := NewClient(ServerInfo{Host: mock.URL}, opts...)
+	return client, mock, &requests
+}
+
+func TestCacheHit(t *testing.T) {
+	cache := NewCache(CachePolicy{Classes: []CacheClass{{Name: "items", Endpoints: []string{"api/items"}, TTL: time.Minute}}})
+	client, mock, requests := cacheServer(WithCache(cache))
+	defer mock.Close()
+
+	for i := 0; i < 3; i++ {
+		body, resp, err := client.Get("api/items")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != "item " || resp.StatusCode != http.StatusOK || resp.Header.Get("ETag") != `"v1"` {
+			t.Errorf("Unexpected response %d %v: %q", resp.StatusCode, resp.Header, body)
+		}
+		body[0] = 'X'
+	}
+
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Errorf("Expected a single request but got %d", n)
+	}
+	if stats := cache.Stats(); stats != (CacheStats{Hits: 2, Misses: 1}) {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	if _, _, err := client.Get("api/other"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Get("api/other"); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(requests); n != 3 {
+		t.Errorf("Expected the endpoint of no class not to be cached but got %d requests", n)
+	}
+}
+
+func TestCacheRevalidate(t *testing.T) {
+	cache := NewCache(CachePolicy{Classes: []CacheClass{{Name: "items", Endpoints: []string{"api/items"}, TTL: 10 * time.Millisecond}}})
+	recorder := NewRecorder()
+	client, mock, requests := cacheServer(WithCache(cache), WithTelemetry(recorder))
+	defer mock.Close()
+
+	if _, _, err := client.Get("api/items"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	body, resp, err := client.Get("api/items")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "item " || resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected the cached response to be revalidated but got %d: %q", resp.StatusCode, body)
+	}
+
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("Expected a conditional request but got %d requests", n)
+	}
+	if stats := cache.Stats(); stats.Revalidations != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if n := recorder.Counter(MetricErrors); n != 0 {
+		t.Errorf("Expected the not modified response not to be an error but got %d errors", n)
+	}
+
+	// The revalidated response is fresh again
+	if _, _, err = client.Get("api/items"); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("Expected the revalidated response to be used but got %d requests", n)
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	cache := NewCache(CachePolicy{Classes: []CacheClass{
+		{Name: "items", Endpoints: []string{"api/items"}, TTL: time.Minute, InvalidatedBy: []string{"api/scripts"}},
+		{Name: "others", Endpoints: []string{"api/others/"}, TTL: time.Minute},
+	}})
+	client, mock, requests := cacheServer(WithCache(cache))
+	defer mock.Close()
+
+	get := func(endpoint, want string) {
+		t.Helper()
+		body, _, err := client.Get(endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != want {
+			t.Errorf("Expected %q but got %q", want, body)
+		}
+	}
+
+	get("api/items", "item ")
+	get("api/others/a", "other")
+
+	if _, _, err := client.Post("api/items", nil); err != nil {
+		t.Fatal(err)
+	}
+	get("api/items", "item item ")
+	get("api/others/a", "other")
+
+	if _, _, err := client.Post("api/scripts/create/run", nil); err != nil {
+		t.Fatal(err)
+	}
+	get("api/items", "item item ")
+	get("api/others/a", "other")
+
+	if n := atomic.LoadInt32(requests); n != 6 {
+		t.Errorf("Expected the mutations to invalidate the items only but got %d requests", n)
+	}
+
+	cache.Invalidate("api/others")
+	get("api/others/a", "other")
+	cache.Clear()
+	get("api/items", "item item ")
+
+	if n := atomic.LoadInt32(requests); n != 8 {
+		t.Errorf("Expected the explicit invalidations to be applied but got %d requests", n)
+	}
+}
+
+func TestCacheNoStore(t *testing.T) {
+	client, mock, requests := cacheServer(WithCache(NewCache(CachePolicy{Classes: []CacheClass{{Name: "all", Endpoints: []string{"api/"}, TTL: time.Minute}}})))
+	defer mock.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Get("api/secret"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("Expected the no-store response not to be cached but got %d requests", n)
+	}
+}
+
+func TestCacheKeyedByCredentials(t *testing.T) {
+	cache := NewCache(CachePolicy{Classes: []CacheClass{{Name: "items", Endpoints: []string{"api/items"}, TTL: time.Minute}}})
+	client, mock, requests := cacheServer(WithCache(cache))
+	defer mock.Close()
+	other := NewClient(ServerInfo{Host: mock.URL, Username: "other"}, WithCache(cache))
+
+	for _, c := range []*DefaultClient{client, other, client, other} {
+		if _, _, err := c.Get("api/items"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("Expected a request per user but got %d", n)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	client, mock, requests := cacheServer(WithCache(NewCache(CachePolicy{Classes: []CacheClass{{Name: "all", Endpoints: []string{"api/"}, TTL: time.Minute}}, MaxEntries: 2})))
+	defer mock.Close()
+
+	for _, endpoint := range []string{"api/a", "api/b", "api/a", "api/c", "api/a", "api/b"} {
+		if _, _, err := client.Get(endpoint); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a and b are cached, a is used again so c evicts b, which is requested again
+	if n := atomic.LoadInt32(requests); n != 4 {
+		t.Errorf("Expected the least recently used response to be evicted but got %d requests", n)
+	}
+}
+
+func TestCacheInvalidatedDuringRequest(t *testing.T) {
+	cache := NewCache(CachePolicy{Classes: []CacheClass{{Name: "items", Endpoints: []string{"api/items"}, TTL: 10 * time.Millisecond}}})
+
+	// mutate adds an item once the response of the next GET was sent, as if another caller changed the items
+	// while the response was on its way
+	var (
+		client *DefaultClient
+		mutate bool
+	)
+	mutation := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(r)
+			if r.Method == http.MethodGet && mutate {
+				mutate = false
+				if _, _, err := client.Post("api/items", nil); err != nil {
+					t.Error(err)
+				}
+			}
+			return resp, err
+		})
+	}
+	client, mock, _ := cacheServer(WithCache(cache), WithMiddleware(mutation))
+	defer mock.Close()
+
+	get := func(want string) {
+		t.Helper()
+		body, _, err := client.Get("api/items")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != want {
+			t.Errorf("Expected %q but got %q", want, body)
+		}
+	}
+
+	mutate = true
+	get("item ")
+	get("item item ")
+
+	// The revalidation of the expired response is invalidated as well
+	time.Sleep(20 * time.Millisecond)
+	mutate = true
+	get("item item ")
+	get("item item item ")
+}
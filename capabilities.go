@@ -0,0 +1,297 @@
+package nexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Errors returned when working out what the server supports
+var (
+	ErrUnsupported    = errors.New("not supported by server")
+	ErrUnknownVersion = errors.New("could not determine server version")
+)
+
+// Version describes the version of a Nexus server, e.g. 3.29.2-02 or 1.100.0-01
+type Version struct {
+	Major, Minor, Patch, Build int
+}
+
+// ParseVersion parses a version such as 3.29.2-02, ignoring anything following it such as the edition
+func ParseVersion(version string) (Version, error) {
+	var v Version
+
+	fields := strings.Fields(version)
+	if len(fields) == 0 {
+		return Version{}, fmt.Errorf("could not parse empty version")
+	}
+
+	release := fields[0]
+	if i := strings.Index(release, "-"); i >= 0 {
+		build, err := strconv.Atoi(release[i+1:])
+		if err != nil {
+			return Version{}, fmt.Errorf("could not parse build of version %q: %w", version, err)
+		}
+		v.Build, release = build, release[:i]
+	}
+
+	parts := strings.Split(release, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("could not parse version %q", version)
+	}
+
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return Version{}, fmt.Errorf("could not parse version %q: %w", version, err)
+		}
+		*numbers[i] = n
+	}
+
+	return v, nil
+}
+
+// MustParseVersion is like ParseVersion but panics if the version cannot be parsed
+func MustParseVersion(version string) Version {
+	v, err := ParseVersion(version)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Compare returns -1, 0 or 1 if the version is older than, the same as or newer than the other
+func (v Version) Compare(other Version) int {
+	a := []int{v.Major, v.Minor, v.Patch, v.Build}
+	b := []int{other.Major, other.Minor, other.Patch, other.Build}
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast determines if the version is the same as, or newer than, the other
+func (v Version) AtLeast(other Version) bool {
+	return v.Compare(other) >= 0
+}
+
+// IsZero determines if the version is unset
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Build > 0 {
+		s += fmt.Sprintf("-%02d", v.Build)
+	}
+	return s
+}
+
+// Feature names a capability of a server and the version which introduced it
+type Feature struct {
+	Name  string
+	Since Version
+
+	// Probe, if set, is used to find out if the server has the feature when its version cannot be determined
+	Probe func(ctx context.Context, client Client) (bool, error)
+}
+
+// UnsupportedError is returned when the server is too old for the feature being used
+type UnsupportedError struct {
+	Feature Feature
+	Version Version
+}
+
+func (e *UnsupportedError) Error() string {
+	msg := fmt.Sprintf("%s requires server >= %s", e.Feature.Name, e.Feature.Since)
+	if !e.Version.IsZero() {
+		msg += fmt.Sprintf(" but found %s", e.Version)
+	}
+	return msg
+}
+
+// Is allows matching the error against ErrUnsupported
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// VersionDetector finds out the version of the server the client connects to.
+// It returns a zero Version, and no error, if the server responded but did not reveal its version
+type VersionDetector func(ctx context.Context, client Client) (Version, error)
+
+// Capabilities detects the version of a server once and determines which features it supports.
+// The results are cached, so a Capabilities is meant to live as long as the client.
+// Concurrent callers share a single detection, and probe for a feature once
+type Capabilities struct {
+	client Client
+	detect VersionDetector
+
+	mu        sync.Mutex
+	version   Version
+	detected  bool
+	detecting *flight
+	probed    map[string]bool
+	probing   map[string]*flight
+}
+
+// flight is a detection or probe in progress, whose result is shared by the callers which wait for it
+type flight struct {
+	done      chan struct{}
+	supported bool
+	err       error
+}
+
+// wait waits for the flight to land, or for the context to be done
+func (f *flight) wait(ctx context.Context) error {
+	select {
+	case <-f.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewCapabilities creates a Capabilities which detects the version of the server of the client
+func NewCapabilities(client Client, detect VersionDetector) *Capabilities {
+	return &Capabilities{client: client, detect: detect, probed: make(map[string]bool), probing: make(map[string]*flight)}
+}
+
+// SetVersion overrides the detected version, e.g. when it is already known
+func (c *Capabilities) SetVersion(version Version) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version, c.detected = version, true
+	c.probed = make(map[string]bool)
+}
+
+// Version returns the version of the server, detecting it on first use.
+// Returns ErrUnknownVersion if the server does not reveal its version
+func (c *Capabilities) Version(ctx context.Context) (Version, error) {
+	c.mu.Lock()
+	if c.detected {
+		defer c.mu.Unlock()
+		return c.knownVersion()
+	}
+
+	f, leader := c.detecting, false
+	if f == nil {
+		f, leader = &flight{done: make(chan struct{})}, true
+		c.detecting = f
+	}
+	c.mu.Unlock()
+
+	if leader {
+		c.detectVersion(ctx, f)
+	}
+
+	if err := f.wait(ctx); err != nil {
+		return Version{}, fmt.Errorf("could not detect server version: %w", err)
+	}
+	if f.err != nil {
+		return Version{}, fmt.Errorf("could not detect server version: %w", f.err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.knownVersion()
+}
+
+// detectVersion detects the version without holding the lock, and lands the flight of the callers waiting for it.
+// Failures are not cached, so that the next call tries again
+func (c *Capabilities) detectVersion(ctx context.Context, f *flight) {
+	version, err := c.detect(ctx, c.client)
+
+	c.mu.Lock()
+	if err == nil && !c.detected {
+		c.version, c.detected = version, true
+	}
+	c.detecting = nil
+	c.mu.Unlock()
+
+	f.err = err
+	close(f.done)
+}
+
+// knownVersion returns the detected version, and must be called with the lock held
+func (c *Capabilities) knownVersion() (Version, error) {
+	if c.version.IsZero() {
+		return Version{}, ErrUnknownVersion
+	}
+	return c.version, nil
+}
+
+// Supports determines if the server has the feature. When the version of the server is not known,
+// the feature is probed for if it can be, otherwise ErrUnknownVersion is returned
+func (c *Capabilities) Supports(ctx context.Context, feature Feature) (bool, error) {
+	version, err := c.Version(ctx)
+	if err == nil {
+		return version.AtLeast(feature.Since), nil
+	}
+	if feature.Probe == nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	if supported, ok := c.probed[feature.Name]; ok {
+		c.mu.Unlock()
+		return supported, nil
+	}
+
+	f, leader := c.probing[feature.Name], false
+	if f == nil {
+		f, leader = &flight{done: make(chan struct{})}, true
+		c.probing[feature.Name] = f
+	}
+	c.mu.Unlock()
+
+	if leader {
+		c.probe(ctx, feature, f)
+	}
+
+	if err = f.wait(ctx); err != nil {
+		return false, fmt.Errorf("could not probe for %s: %w", feature.Name, err)
+	}
+	if f.err != nil {
+		return false, fmt.Errorf("could not probe for %s: %w", feature.Name, f.err)
+	}
+
+	return f.supported, nil
+}
+
+// probe probes for the feature without holding the lock, and lands the flight of the callers waiting for it
+func (c *Capabilities) probe(ctx context.Context, feature Feature, f *flight) {
+	supported, err := feature.Probe(ctx, c.client)
+
+	c.mu.Lock()
+	if err == nil {
+		c.probed[feature.Name] = supported
+	}
+	delete(c.probing, feature.Name)
+	c.mu.Unlock()
+
+	f.supported, f.err = supported, err
+	close(f.done)
+}
+
+// Require returns an *UnsupportedError if the server does not have the feature.
+// Features are assumed to be available when that cannot be determined, leaving it to the server to refuse
+func (c *Capabilities) Require(ctx context.Context, feature Feature) error {
+	supported, err := c.Supports(ctx, feature)
+	if err != nil || supported {
+		return nil
+	}
+
+	version, _ := c.Version(ctx)
+	return &UnsupportedError{Feature: feature, Version: version}
+}
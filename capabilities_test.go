@@ -0,0 +1,226 @@
+package nexus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Version
+		err   bool
+	}{
+		{"3.29.2-02", Version{3, 29, 2, 2}, false},
+		{"1.100.0-01", Version{1, 100, 0, 1}, false},
+		{"3.29.2-02 (PRO)", Version{3, 29, 2, 2}, false},
+		{"1.70", Version{1, 70, 0, 0}, false},
+		{"", Version{}, true},
+		{"1.x.0", Version{}, true},
+		{"1.2.3.4", Version{}, true},
+		{"3.29.2-beta", Version{}, true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseVersion(test.input)
+		if (err != nil) != test.err {
+			t.Errorf("%q: unexpected error: %v", test.input, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q: got %v, want %v", test.input, got, test.want)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.70.0", "1.70.0", 0},
+		{"1.69.0-02", "1.70.0", -1},
+		{"1.100.0", "1.70.0", 1},
+		{"3.29.2-02", "3.29.2-01", 1},
+	}
+
+	for _, test := range tests {
+		if got := MustParseVersion(test.a).Compare(MustParseVersion(test.b)); got != test.want {
+			t.Errorf("%s vs %s: got %d, want %d", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestCapabilitiesDetectOnce(t *testing.T) {
+	var detections int
+	caps := NewCapabilities(nil, func(ctx context.Context, client Client) (Version, error) {
+		detections++
+		return MustParseVersion("1.69.0-02"), nil
+	})
+
+	old := Feature{Name: "old", Since: Version{Major: 1, Minor: 50}}
+	recent := Feature{Name: "recent", Since: Version{Major: 1, Minor: 70}}
+
+	for i := 0; i < 3; i++ {
+		if ok, err := caps.Supports(context.Background(), old); err != nil || !ok {
+			t.Errorf("Expected old feature to be supported: %v", err)
+		}
+		if ok, err := caps.Supports(context.Background(), recent); err != nil || ok {
+			t.Errorf("Did not expect recent feature to be supported: %v", err)
+		}
+	}
+
+	if detections != 1 {
+		t.Errorf("Expected version to be detected once but was detected %d times", detections)
+	}
+
+	err := caps.Require(context.Background(), recent)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("Expected unsupported error but got: %v", err)
+	}
+	if want := "recent requires server >= 1.70.0 but found 1.69.0-02"; err.Error() != want {
+		t.Errorf("got %q, want %q", err.Error(), want)
+	}
+}
+
+func TestCapabilitiesUnknownVersion(t *testing.T) {
+	caps := NewCapabilities(nil, func(ctx context.Context, client Client) (Version, error) {
+		return Version{}, nil
+	})
+
+	var probes int
+	probed := Feature{
+		Name:  "probed",
+		Since: Version{Major: 1, Minor: 70},
+		Probe: func(ctx context.Context, client Client) (bool, error) {
+			probes++
+			return false, nil
+		},
+	}
+
+	if _, err := caps.Version(context.Background()); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("Expected unknown version but got: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if ok, err := caps.Supports(context.Background(), probed); err != nil || ok {
+			t.Errorf("Expected probe to find feature missing: %v", err)
+		}
+	}
+	if probes != 1 {
+		t.Errorf("Expected feature to be probed once but was probed %d times", probes)
+	}
+
+	if err := caps.Require(context.Background(), Feature{Name: "unprobed"}); err != nil {
+		t.Errorf("Expected feature to be assumed supported but got: %v", err)
+	}
+
+	caps.SetVersion(MustParseVersion("1.70.0"))
+	if ok, err := caps.Supports(context.Background(), probed); err != nil || !ok {
+		t.Errorf("Expected feature to be supported by set version: %v", err)
+	}
+}
+
+func TestCapabilitiesDetectionFailure(t *testing.T) {
+	fail := errors.New("connection refused")
+
+	var detections int
+	caps := NewCapabilities(nil, func(ctx context.Context, client Client) (Version, error) {
+		detections++
+		return Version{}, fail
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := caps.Version(context.Background()); !errors.Is(err, fail) {
+			t.Errorf("Expected detection failure but got: %v", err)
+		}
+	}
+
+	if detections != 2 {
+		t.Errorf("Expected failed detection to not be cached")
+	}
+}
+
+func TestCapabilitiesSharedDetection(t *testing.T) {
+	var detections int32
+	started, release := make(chan struct{}), make(chan struct{})
+	caps := NewCapabilities(nil, func(ctx context.Context, client Client) (Version, error) {
+		if atomic.AddInt32(&detections, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return MustParseVersion("1.69.0-02"), nil
+	})
+
+	feature := Feature{Name: "old", Since: Version{Major: 1, Minor: 50}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := caps.Supports(context.Background(), feature); err != nil || !ok {
+				t.Errorf("Expected the feature to be supported: %v", err)
+			}
+		}()
+	}
+	<-started
+
+	// The lock is not held during the detection, so other callers can give up waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := caps.Version(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the canceled caller to stop waiting but got: %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&detections); n != 1 {
+		t.Errorf("Expected the callers to share one detection but the version was detected %d times", n)
+	}
+}
+
+func TestCapabilitiesSharedProbe(t *testing.T) {
+	caps := NewCapabilities(nil, func(ctx context.Context, client Client) (Version, error) {
+		return Version{}, nil
+	})
+
+	var probes int32
+	started, release := make(chan struct{}), make(chan struct{})
+	probed := Feature{
+		Name: "probed",
+		Probe: func(ctx context.Context, client Client) (bool, error) {
+			if atomic.AddInt32(&probes, 1) == 1 {
+				close(started)
+			}
+			<-release
+			return true, nil
+		},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := caps.Supports(context.Background(), probed); err != nil || !ok {
+				t.Errorf("Expected the probe to find the feature: %v", err)
+			}
+		}()
+	}
+	<-started
+
+	// The lock is not held during the probe, so the version can still be set
+	caps.SetVersion(MustParseVersion("1.70.0"))
+
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&probes); n != 1 {
+		t.Errorf("Expected the callers to share one probe but the feature was probed %d times", n)
+	}
+}
@@ -0,0 +1,333 @@
+package nexus
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// MatchMode determines how a request is matched to a recorded interaction
+type MatchMode int
+
+// The ways of matching requests to recorded interactions
+const (
+	// MatchStrict expects requests in the recorded order, with the same method, path, query and body
+	MatchStrict MatchMode = iota
+	// MatchLenient matches a request to any interaction with the same method, path and query parameters,
+	// in any order and regardless of the body. Interactions can be replayed more than once
+	MatchLenient
+)
+
+// ErrNoInteraction is returned when a replayed request does not match any recorded interaction
+var ErrNoInteraction = errors.New("no matching interaction in cassette")
+
+// RecordedRequest describes a recorded request. Credentials are scrubbed when recorded
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	Base64 bool        `json:"base64,omitempty"`
+}
+
+// RecordedResponse describes a recorded response. Binary bodies are base64 encoded
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	Base64     bool        `json:"base64,omitempty"`
+}
+
+// Interaction is a recorded request and the response it received
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Cassette holds recorded HTTP interactions and is stored as a JSON file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+
+	path   string
+	mu     sync.Mutex
+	played []bool
+}
+
+// NewCassette creates an empty cassette which will be saved to the given path
+func NewCassette(path string) *Cassette {
+	return &Cassette{path: path}
+}
+
+// LoadCassette reads the cassette at the given path
+func LoadCassette(path string) (*Cassette, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read cassette: %w", err)
+	}
+
+	c := &Cassette{path: path}
+	if err = json.Unmarshal(buf, c); err != nil {
+		return nil, fmt.Errorf("could not parse cassette %s: %w", path, err)
+	}
+	c.played = make([]bool, len(c.Interactions))
+
+	return c, nil
+}
+
+// Save writes the cassette to its path
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.save()
+}
+
+func (c *Cassette) save() error {
+	buf, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode cassette: %w", err)
+	}
+
+	if err = ioutil.WriteFile(c.path, buf, 0644); err != nil {
+		return fmt.Errorf("could not write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// Record creates a Middleware which sends requests on and records the interactions, with credentials scrubbed.
+// The cassette is saved after every interaction. Bodies are held in memory, so avoid recording large downloads
+func (c *Cassette) Record() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			reqBody, err := readBody(&request.Body)
+			if err != nil {
+				return nil, fmt.Errorf("could not record request: %w", err)
+			}
+
+			resp, err := next.RoundTrip(request)
+			if err != nil {
+				return resp, err
+			}
+
+			respBody, err := readBody(&resp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("could not record response: %w", err)
+			}
+
+			interaction := Interaction{
+				Request: RecordedRequest{
+					Method: request.Method,
+					URL:    scrubURL(request.URL),
+					Header: scrubHeader(request.Header),
+				},
+				Response: RecordedResponse{
+					StatusCode: resp.StatusCode,
+					Status:     resp.Status,
+					Header:     scrubHeader(resp.Header),
+				},
+			}
+			interaction.Request.Body, interaction.Request.Base64 = encodeBody(scrubRequestBody(request, reqBody))
+			interaction.Response.Body, interaction.Response.Base64 = encodeBody(scrubBody(respBody))
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			c.Interactions = append(c.Interactions, interaction)
+			c.played = append(c.played, true)
+			if err = c.save(); err != nil {
+				return nil, err
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+// Replay creates a Middleware which serves requests from the recorded interactions without using the network.
+// Requests which do not match an interaction fail with ErrNoInteraction
+func (c *Cassette) Replay(match MatchMode) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			body, err := readBody(&request.Body)
+			if err != nil {
+				return nil, fmt.Errorf("could not replay request: %w", err)
+			}
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			i := c.find(request, body, match)
+			if i < 0 {
+				return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, request.Method, request.URL.RequestURI())
+			}
+			c.played[i] = true
+
+			recorded := c.Interactions[i].Response
+			respBody, err := decodeBody(recorded.Body, recorded.Base64)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode recorded response: %w", err)
+			}
+
+			header := recorded.Header
+			if header == nil {
+				header = make(http.Header)
+			}
+
+			return &http.Response{
+				Status:        recorded.Status,
+				StatusCode:    recorded.StatusCode,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        header.Clone(),
+				Body:          ioutil.NopCloser(bytes.NewReader(respBody)),
+				ContentLength: int64(len(respBody)),
+				Request:       request,
+			}, nil
+		})
+	}
+}
+
+// find returns the index of the interaction which matches the request or -1
+func (c *Cassette) find(request *http.Request, body []byte, match MatchMode) int {
+	if match == MatchStrict {
+		for i, played := range c.played {
+			if !played {
+				if c.matchesStrictly(c.Interactions[i].Request, request, body) {
+					return i
+				}
+				return -1
+			}
+		}
+		return -1
+	}
+
+	found := -1
+	for i := range c.Interactions {
+		if !matchesLeniently(c.Interactions[i].Request, request) {
+			continue
+		}
+		if !c.played[i] {
+			return i
+		}
+		found = i
+	}
+	return found
+}
+
+func (c *Cassette) matchesStrictly(recorded RecordedRequest, request *http.Request, body []byte) bool {
+	u, err := url.Parse(recorded.URL)
+	if err != nil || recorded.Method != request.Method || u.RequestURI() != request.URL.RequestURI() {
+		return false
+	}
+
+	recordedBody, err := decodeBody(recorded.Body, recorded.Base64)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(recordedBody, scrubRequestBody(request, body))
+}
+
+func matchesLeniently(recorded RecordedRequest, request *http.Request) bool {
+	u, err := url.Parse(recorded.URL)
+	if err != nil || recorded.Method != request.Method || u.Path != request.URL.Path {
+		return false
+	}
+
+	want, got := u.Query(), request.URL.Query()
+	if len(want) != len(got) {
+		return false
+	}
+	for k, v := range want {
+		if !sameValues(v, got[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameValues determines if both lists hold the same values, in any order
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a, b = append([]string(nil), a...), append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	return reflect.DeepEqual(a, b)
+}
+
+// readBody reads the body and replaces it with an unread copy
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	buf, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(buf))
+
+	return buf, nil
+}
+
+func encodeBody(body []byte) (string, bool) {
+	if utf8.Valid(body) {
+		return string(body), false
+	}
+	return base64.StdEncoding.EncodeToString(body), true
+}
+
+func decodeBody(body string, encoded bool) ([]byte, error) {
+	if encoded {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
+
+func scrubRequestBody(request *http.Request, body []byte) []byte {
+	if len(body) > 0 && isCredentialBody(request) {
+		return []byte(redacted)
+	}
+	return scrubBody(body)
+}
+
+func scrubBody(body []byte) []byte {
+	if !utf8.Valid(body) {
+		return body
+	}
+	return redactFields.ReplaceAll(body, []byte(`$1"`+redacted+`"`))
+}
+
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, h := range sensitiveHeaders {
+		if _, ok := scrubbed[h]; ok {
+			scrubbed[h] = []string{redacted}
+		}
+	}
+	return scrubbed
+}
+
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	scrubbed.User = nil
+	return scrubbed.String()
+}
@@ -0,0 +1,219 @@
+package nexus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func cassettePath(t *testing.T) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Join(dir, "cassette.json"), func() { os.RemoveAll(dir) }
+}
+
+func recordCassette(t *testing.T, path string, handler http.HandlerFunc, calls func(client *DefaultClient)) {
+	t.Helper()
+
+	mock := httptest.NewServer(handler)
+	defer mock.Close()
+
+	cassette := NewCassette(path)
+	calls(NewClient(ServerInfo{Host: mock.URL, Username: "admin", Password: "admin123"}, WithMiddleware(cassette.Record())))
+}
+
+func replayClient(t *testing.T, path string, match MatchMode) *DefaultClient {
+	t.Helper()
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewClient(ServerInfo{Host: "http://localhost:0", Username: "admin", Password: "admin123"}, WithMiddleware(cassette.Replay(match)))
+}
+
+func echoHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Set-Cookie", "NXSESSIONID=secret-session")
+	fmt.Fprintf(w, "%s %s", r.Method, r.URL.RequestURI())
+}
+
+func TestCassetteRecordScrubsCredentials(t *testing.T) {
+	path, cleanup := cassettePath(t)
+	defer cleanup()
+
+	recordCassette(t, path, echoHandler, func(client *DefaultClient) {
+		if _, _, err := client.Post("test", strings.NewReader(`{"password":"secret-password","secretAccessKey":"secret-key"}`)); err != nil {
+			t.Fatal(err)
+		}
+
+		request, err := client.NewRequest(http.MethodPut, "users/admin/change-password", strings.NewReader("secret-plain"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		request.Header.Set("Content-Type", "text/plain")
+		if _, _, err := client.Do(request); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"YWRtaW46YWRtaW4xMjM=", "admin123", "secret-password", "secret-key", "secret-plain", "secret-session"} {
+		if bytes.Contains(buf, []byte(secret)) {
+			t.Errorf("Cassette contains credentials %q: %s", secret, buf)
+		}
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cassette.Interactions) != 2 {
+		t.Fatalf("Expected 2 interactions but recorded %d", len(cassette.Interactions))
+	}
+}
+
+func TestCassetteReplayStrict(t *testing.T) {
+	path, cleanup := cassettePath(t)
+	defer cleanup()
+
+	recordCassette(t, path, echoHandler, func(client *DefaultClient) {
+		for _, endpoint := range []string{"first", "second?a=1&b=2"} {
+			if _, _, err := client.Get(endpoint); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	client := replayClient(t, path, MatchStrict)
+
+	body, _, err := client.Get("first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "GET /first" {
+		t.Errorf("Unexpected replayed body: %q", body)
+	}
+
+	if _, _, err = client.Get("second?b=2&a=1"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Expected reordered query to not match but got: %v", err)
+	}
+
+	if _, _, err = client.Get("second?a=1&b=2"); err != nil {
+		t.Error(err)
+	}
+
+	if _, _, err = client.Get("first"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Expected replayed interaction to not be served again but got: %v", err)
+	}
+}
+
+func TestCassetteReplayStrictOrder(t *testing.T) {
+	path, cleanup := cassettePath(t)
+	defer cleanup()
+
+	recordCassette(t, path, echoHandler, func(client *DefaultClient) {
+		for _, endpoint := range []string{"first", "second"} {
+			if _, _, err := client.Get(endpoint); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	client := replayClient(t, path, MatchStrict)
+
+	if _, _, err := client.Get("second"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Expected out of order request to fail but got: %v", err)
+	}
+}
+
+func TestCassetteReplayLenient(t *testing.T) {
+	path, cleanup := cassettePath(t)
+	defer cleanup()
+
+	recordCassette(t, path, echoHandler, func(client *DefaultClient) {
+		for _, endpoint := range []string{"first", "second?a=1&b=2"} {
+			if _, _, err := client.Get(endpoint); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	client := replayClient(t, path, MatchLenient)
+
+	for _, endpoint := range []string{"second?b=2&a=1", "first", "first"} {
+		if _, _, err := client.Get(endpoint); err != nil {
+			t.Errorf("%s: %v", endpoint, err)
+		}
+	}
+
+	if _, _, err := client.Get("second?a=1"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("Expected different query to not match but got: %v", err)
+	}
+}
+
+func TestCassetteReplayErrorResponse(t *testing.T) {
+	path, cleanup := cassettePath(t)
+	defer cleanup()
+
+	recordCassette(t, path, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusNotFound)
+	}, func(client *DefaultClient) {
+		if _, _, err := client.Get("missing"); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+
+	client := replayClient(t, path, MatchStrict)
+
+	_, resp, err := client.Get("missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected replayed response to be not found but got: %v", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Unexpected replayed response: %v", resp)
+	}
+}
+
+func TestCassetteBinaryBody(t *testing.T) {
+	binary := []byte{0x50, 0x4b, 0x03, 0x04, 0xff, 0xfe, 0x00}
+
+	path, cleanup := cassettePath(t)
+	defer cleanup()
+
+	recordCassette(t, path, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(binary)
+	}, func(client *DefaultClient) {
+		if _, _, err := client.Get("download"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	client := replayClient(t, path, MatchStrict)
+
+	body, _, err := client.Get("download")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(body, binary) {
+		t.Errorf("Binary body not replayed as recorded: %v", body)
+	}
+}
@@ -0,0 +1,498 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"strings"
+
+	nexusiq "github.com/sonatype-nexus-community/gonexus/iq"
+)
+
+func iqCommands() []command {
+	return []command{
+		{name: "apps", args: "list [-org name] | get <id> | create <name> <id> <organization> | delete <id>", help: "list, show, create or delete applications", iq: iqApps},
+		{name: "orgs", args: "list | get <name> | create <name>", help: "list, show or create organizations", iq: iqOrgs},
+		{name: "evaluate", args: "-app <id> [-fail-threat level] <component>...", help: "evaluate components, given as package URLs or format:coordinates", iq: iqEvaluate},
+		{name: "reports", args: "list [-app id | -org name] | get <app id> <stage>", help: "list reports, or show the policy report of an application", iq: iqReports},
+		{name: "policies", args: "[name]", help: "list the policies, or show the named one", iq: iqPolicies},
+		{name: "violations", args: "[policy name...]", help: "list the policy violations, optionally only those of the named policies", iq: iqViolations},
+		{name: "labels", args: "list | create [-description text] [-color color] <label> | delete <label> | apply <component> <label> | unapply <component> <label>, with -org name or -app id", help: "manage component labels", iq: iqLabels},
+		{name: "remediation", args: "-app id | -org name [-stage stage] <component>", help: "show the remediation of a component", iq: iqRemediation},
+		{name: "roles", args: "[name]", help: "list the roles, or show the named one", iq: iqRoles},
+		{name: "users", args: "get <username> | set [-first name] [-last name] [-email address] [-password password] <username> | delete <username>", help: "show, create or update, or delete users", iq: iqUsers},
+		{name: "retention", args: "get <organization> | set <organization> <file>", help: "show or set the data retention policies of an organization", iq: iqRetention},
+	}
+}
+
+// view pairs a result, which is written as is as JSON, with the rows which show it in tables and CSV
+type view struct {
+	data interface{}
+	rows interface{}
+}
+
+func (v view) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.data)
+}
+
+func parseComponent(s string) (nexusiq.Component, error) {
+	var (
+		c   *nexusiq.Component
+		err error
+	)
+	if strings.HasPrefix(s, "pkg:") {
+		c, err = nexusiq.NewComponentFromPURL(s)
+	} else {
+		c, err = nexusiq.NewComponentFromString(s)
+	}
+	if err != nil {
+		return nexusiq.Component{}, usagef("could not parse component %q: %v", s, err)
+	}
+	return *c, nil
+}
+
+func componentName(c nexusiq.Component) string {
+	switch {
+	case c.PackageURL != "":
+		return c.PackageURL
+	case c.ComponentID != nil:
+		return c.ComponentID.String()
+	default:
+		return c.Hash
+	}
+}
+
+// applicationID returns the internal ID of the application with the given public ID
+func applicationID(ctx context.Context, iq nexusiq.IQ, publicID string) (string, error) {
+	app, err := nexusiq.GetApplicationByPublicIDContext(ctx, iq, publicID)
+	if err != nil {
+		return "", err
+	}
+	return app.ID, nil
+}
+
+func iqApps(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	act, args := action(args, "list")
+
+	switch act {
+	case "list":
+		flags := flag.NewFlagSet("list", flag.ContinueOnError)
+		org := flags.String("org", "", "only list the applications of the named organization")
+		if err := parseFlags(flags, args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() > 0 {
+			return nil, usagef("unexpected arguments")
+		}
+		if *org != "" {
+			return nexusiq.GetApplicationsByOrganizationContext(ctx, iq, *org)
+		}
+		return nexusiq.GetAllApplicationsContext(ctx, iq)
+	case "get":
+		if len(args) != 1 {
+			return nil, usagef("expected an application ID")
+		}
+		return nexusiq.GetApplicationByPublicIDContext(ctx, iq, args[0])
+	case "create":
+		if len(args) != 3 {
+			return nil, usagef("expected a name, an ID and an organization name")
+		}
+		org, err := nexusiq.GetOrganizationByNameContext(ctx, iq, args[2])
+		if err != nil {
+			return nil, err
+		}
+		if _, err = nexusiq.CreateApplicationContext(ctx, iq, args[0], args[1], org.ID); err != nil {
+			return nil, err
+		}
+		return nexusiq.GetApplicationByPublicIDContext(ctx, iq, args[1])
+	case "delete":
+		if len(args) != 1 {
+			return nil, usagef("expected an application ID")
+		}
+		id, err := applicationID(ctx, iq, args[0])
+		if err != nil {
+			return nil, err
+		}
+		return nil, nexusiq.DeleteApplicationContext(ctx, iq, id)
+	default:
+		return nil, usagef("unknown action %q", act)
+	}
+}
+
+func iqOrgs(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	act, args := action(args, "list")
+
+	switch {
+	case act == "list" && len(args) == 0:
+		return nexusiq.GetAllOrganizationsContext(ctx, iq)
+	case act == "get" && len(args) == 1:
+		return nexusiq.GetOrganizationByNameContext(ctx, iq, args[0])
+	case act == "create" && len(args) == 1:
+		if _, err := nexusiq.CreateOrganizationContext(ctx, iq, args[0]); err != nil {
+			return nil, err
+		}
+		return nexusiq.GetOrganizationByNameContext(ctx, iq, args[0])
+	default:
+		return nil, usagef("unknown action or wrong number of arguments")
+	}
+}
+
+type evaluationRow struct {
+	Component   string `json:"component"`
+	MatchState  string `json:"matchState"`
+	Violations  int    `json:"violations"`
+	ThreatLevel int    `json:"threatLevel"`
+	Policy      string `json:"policy"`
+}
+
+func iqEvaluate(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	flags := flag.NewFlagSet("evaluate", flag.ContinueOnError)
+	app := flags.String("app", "", "ID of the application to evaluate the components against")
+	failThreat := flags.Int("fail-threat", 8, "exit with an error if a violation has at least this threat level, 0 to never fail")
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+	if *app == "" || flags.NArg() == 0 {
+		return nil, usagef("expected an application and components")
+	}
+
+	components := make([]nexusiq.Component, 0, flags.NArg())
+	for _, arg := range flags.Args() {
+		c, err := parseComponent(arg)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, c)
+	}
+
+	id, err := applicationID(ctx, iq, *app)
+	if err != nil {
+		return nil, err
+	}
+
+	eval, err := nexusiq.EvaluateComponentsContext(ctx, iq, components, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed int
+	rows := make([]evaluationRow, 0, len(eval.Results))
+	for i := range eval.Results {
+		result := &eval.Results[i]
+		row := evaluationRow{
+			Component:  componentName(result.Component),
+			MatchState: result.MatchState,
+			Violations: len(result.PolicyData.PolicyViolations),
+		}
+		if p := result.HighestThreatPolicy(); p != nil {
+			row.ThreatLevel, row.Policy = p.ThreatLevel, p.PolicyName
+			if *failThreat > 0 && p.ThreatLevel >= *failThreat {
+				failed++
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	result := view{data: eval, rows: rows}
+	if failed > 0 {
+		return result, violationsError{failed}
+	}
+
+	return result, nil
+}
+
+type reportViolationRow struct {
+	Component     string `json:"component"`
+	Policy        string `json:"policy"`
+	ThreatLevel   int64  `json:"threatLevel"`
+	Waived        bool   `json:"waived"`
+	Grandfathered bool   `json:"grandfathered"`
+}
+
+func iqReports(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	act, args := action(args, "list")
+
+	switch act {
+	case "list":
+		flags := flag.NewFlagSet("list", flag.ContinueOnError)
+		app := flags.String("app", "", "only list the reports of the application with the given ID")
+		org := flags.String("org", "", "only list the reports of the named organization")
+		if err := parseFlags(flags, args); err != nil {
+			return nil, err
+		}
+		switch {
+		case flags.NArg() > 0 || (*app != "" && *org != ""):
+			return nil, usagef("unexpected arguments")
+		case *app != "":
+			return nexusiq.GetReportInfosByAppIDContext(ctx, iq, *app)
+		case *org != "":
+			return nexusiq.GetReportInfosByOrganizationContext(ctx, iq, *org)
+		default:
+			return nexusiq.GetAllReportInfosContext(ctx, iq)
+		}
+	case "get":
+		if len(args) != 2 {
+			return nil, usagef("expected an application ID and a stage")
+		}
+		report, err := nexusiq.GetPolicyReportByAppIDContext(ctx, iq, args[0], args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		rows := make([]reportViolationRow, 0)
+		for _, c := range report.Components {
+			for _, v := range c.Violations {
+				rows = append(rows, reportViolationRow{
+					Component:     componentName(c.Component),
+					Policy:        v.PolicyName,
+					ThreatLevel:   v.PolicyThreatLevel,
+					Waived:        v.Waived,
+					Grandfathered: v.Grandfathered,
+				})
+			}
+		}
+		return view{data: report, rows: rows}, nil
+	default:
+		return nil, usagef("unknown action %q", act)
+	}
+}
+
+func iqPolicies(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	switch len(args) {
+	case 0:
+		return nexusiq.GetPoliciesContext(ctx, iq)
+	case 1:
+		return nexusiq.GetPolicyInfoByNameContext(ctx, iq, args[0])
+	default:
+		return nil, usagef("expected at most one policy name")
+	}
+}
+
+type violationRow struct {
+	Application string `json:"application"`
+	Policy      string `json:"policy"`
+	ThreatLevel int    `json:"threatLevel"`
+	Stage       string `json:"stage"`
+}
+
+func iqViolations(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	var (
+		violations []nexusiq.ApplicationViolation
+		err        error
+	)
+	if len(args) == 0 {
+		violations, err = nexusiq.GetAllPolicyViolationsContext(ctx, iq)
+	} else {
+		violations, err = nexusiq.GetPolicyViolationsByNameContext(ctx, iq, args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]violationRow, 0)
+	for _, av := range violations {
+		for _, v := range av.PolicyViolations {
+			rows = append(rows, violationRow{
+				Application: av.Application.PublicID,
+				Policy:      v.PolicyName,
+				ThreatLevel: v.ThreatLevel,
+				Stage:       v.StageID,
+			})
+		}
+	}
+
+	return view{data: violations, rows: rows}, nil
+}
+
+func iqLabels(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	act, args := action(args, "list")
+
+	flags := flag.NewFlagSet("labels", flag.ContinueOnError)
+	org := flags.String("org", "", "name of the organization which owns the labels")
+	app := flags.String("app", "", "ID of the application which owns, or has applied, the labels")
+	description := flags.String("description", "", "description of the label")
+	color := flags.String("color", "light-blue", "color of the label")
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+	args = flags.Args()
+
+	if (*org == "") == (*app == "") {
+		return nil, usagef("expected either -org or -app")
+	}
+
+	switch {
+	case act == "list" && len(args) == 0:
+		if *org != "" {
+			return nexusiq.GetComponentLabelsByOrganizationContext(ctx, iq, *org)
+		}
+		return nexusiq.GetComponentLabelsByAppIDContext(ctx, iq, *app)
+	case act == "create" && len(args) == 1:
+		if *org != "" {
+			return nexusiq.CreateComponentLabelForOrganizationContext(ctx, iq, *org, args[0], *description, *color)
+		}
+		return nexusiq.CreateComponentLabelForApplicationContext(ctx, iq, *app, args[0], *description, *color)
+	case act == "delete" && len(args) == 1:
+		if *org != "" {
+			return nil, nexusiq.DeleteComponentLabelForOrganizationContext(ctx, iq, *org, args[0])
+		}
+		return nil, nexusiq.DeleteComponentLabelForApplicationContext(ctx, iq, *app, args[0])
+	case (act == "apply" || act == "unapply") && len(args) == 2:
+		if *app == "" {
+			return nil, usagef("labels are applied to the components of an application")
+		}
+		c, err := parseComponent(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if act == "apply" {
+			return nil, nexusiq.ComponentLabelApplyContext(ctx, iq, c, *app, args[1])
+		}
+		return nil, nexusiq.ComponentLabelUnapplyContext(ctx, iq, c, *app, args[1])
+	default:
+		return nil, usagef("unknown action or wrong number of arguments")
+	}
+}
+
+type remediationRow struct {
+	Type      string `json:"type"`
+	Component string `json:"component"`
+}
+
+func iqRemediation(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	flags := flag.NewFlagSet("remediation", flag.ContinueOnError)
+	app := flags.String("app", "", "ID of the application to remediate the component in")
+	org := flags.String("org", "", "name of the organization to remediate the component in")
+	stage := flags.String("stage", nexusiq.StageBuild, "stage whose policies the remediation is for")
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() != 1 || (*org == "") == (*app == "") {
+		return nil, usagef("expected a component and either -org or -app")
+	}
+
+	c, err := parseComponent(flags.Arg(0))
+	if err != nil {
+		return nil, err
+	}
+
+	var remediation nexusiq.Remediation
+	if *app != "" {
+		var id string
+		if id, err = applicationID(ctx, iq, *app); err != nil {
+			return nil, err
+		}
+		remediation, err = nexusiq.GetRemediationByAppContext(ctx, iq, c, *stage, id)
+	} else {
+		remediation, err = nexusiq.GetRemediationByOrgContext(ctx, iq, c, *stage, *org)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]remediationRow, 0, len(remediation.VersionChanges))
+	for _, change := range remediation.VersionChanges {
+		rows = append(rows, remediationRow{Type: change.Type, Component: componentName(change.Data.Component)})
+	}
+
+	return view{data: remediation, rows: rows}, nil
+}
+
+func iqRoles(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	switch len(args) {
+	case 0:
+		return nexusiq.RolesContext(ctx, iq)
+	case 1:
+		return nexusiq.RoleByNameContext(ctx, iq, args[0])
+	default:
+		return nil, usagef("expected at most one role name")
+	}
+}
+
+func iqUsers(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	act, args := action(args, "")
+
+	var user nexusiq.User
+	if act == "set" {
+		flags := flag.NewFlagSet("set", flag.ContinueOnError)
+		flags.StringVar(&user.FirstName, "first", "", "first name of the user")
+		flags.StringVar(&user.LastName, "last", "", "last name of the user")
+		flags.StringVar(&user.Email, "email", "", "email address of the user")
+		flags.StringVar(&user.Password, "password", "", "password of the user")
+		if err := parseFlags(flags, args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+	}
+
+	if len(args) != 1 {
+		return nil, usagef("expected an action and a username")
+	}
+
+	switch act {
+	case "get":
+		return nexusiq.GetUserContext(ctx, iq, args[0])
+	case "set":
+		user.Username = args[0]
+		if err := nexusiq.SetUserContext(ctx, iq, user); err != nil {
+			return nil, err
+		}
+		return nexusiq.GetUserContext(ctx, iq, args[0])
+	case "delete":
+		return nil, nexusiq.DeleteUserContext(ctx, iq, args[0])
+	default:
+		return nil, usagef("unknown action %q", act)
+	}
+}
+
+type retentionRow struct {
+	Stage         string `json:"stage"`
+	InheritPolicy bool   `json:"inheritPolicy"`
+	EnablePurging bool   `json:"enablePurging"`
+	MaxAge        string `json:"maxAge"`
+}
+
+func iqRetention(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error) {
+	act, args := action(args, "")
+
+	switch {
+	case act == "get" && len(args) == 1:
+		policies, err := nexusiq.GetRetentionPoliciesContext(ctx, iq, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		var rows []retentionRow
+		for stage, p := range policies.ApplicationReports.Stages {
+			rows = append(rows, retentionRow{string(stage), p.InheritPolicy, p.EnablePurging, p.MaxAge})
+		}
+		sortRetention(rows)
+		p := policies.SuccessMetrics
+		rows = append(rows, retentionRow{"success-metrics", p.InheritPolicy, p.EnablePurging, p.MaxAge})
+
+		return view{data: policies, rows: rows}, nil
+	case act == "set" && len(args) == 2:
+		buf, err := ioutil.ReadFile(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		var policies nexusiq.DataRetentionPolicies
+		if err = json.Unmarshal(buf, &policies); err != nil {
+			return nil, usagef("could not parse retention policies: %v", err)
+		}
+
+		return nil, nexusiq.SetRetentionPoliciesContext(ctx, iq, args[0], policies)
+	default:
+		return nil, usagef("unknown action or wrong number of arguments")
+	}
+}
+
+func sortRetention(rows []retentionRow) {
+	for i := 1; i < len(rows); i++ {
+		for j := i; j > 0 && rows[j].Stage < rows[j-1].Stage; j-- {
+			rows[j], rows[j-1] = rows[j-1], rows[j]
+		}
+	}
+}
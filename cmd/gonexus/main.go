@@ -0,0 +1,318 @@
+// Command gonexus gives command-line access to Nexus Repository Manager and Nexus IQ Server.
+//
+// Usage:
+//
+//	gonexus [flags] <command> [arguments]
+//
+// The servers and credentials are read from a profile in the configuration file, see nexus.Config, and can be
+// overridden with the environment or with flags.
+// The results of commands are written as a table, CSV or JSON.
+//
+// The exit code is 0 on success, 1 if a request failed, 2 on incorrect usage, 3 if something was not found,
+// 4 if the credentials were refused and 5 if an evaluation found policy violations.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	nexusiq "github.com/sonatype-nexus-community/gonexus/iq"
+	nexusrm "github.com/sonatype-nexus-community/gonexus/rm"
+)
+
+// Exit codes
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitNotFound
+	exitUnauthorized
+	exitViolations
+)
+
+// usageError is returned when a command is called incorrectly
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string { return e.msg }
+
+func usagef(format string, a ...interface{}) error {
+	return usageError{fmt.Sprintf(format, a...)}
+}
+
+// violationsError is returned when an evaluation found policy violations
+type violationsError struct {
+	count int
+}
+
+func (e violationsError) Error() string {
+	return fmt.Sprintf("found %d components with policy violations", e.count)
+}
+
+// command describes a subcommand which acts on a Repository Manager or IQ Server
+type command struct {
+	name, args, help string
+
+	rm func(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error)
+	iq func(ctx context.Context, e *env, iq nexusiq.IQ, args []string) (interface{}, error)
+}
+
+// env holds the state shared by the commands
+type env struct {
+	stdout, stderr io.Writer
+	output         string
+	debug          bool
+	dryRun         bool
+	server         nexus.ServerConfig
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gonexus", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { usage(flags, stderr) }
+
+	var (
+		configPath = flags.String("config", nexus.DefaultConfigPath(), "path of the YAML or JSON configuration file")
+		profile    = flags.String("profile", "", "name of the profile to use, instead of the default one")
+		host       = flags.String("host", "", "URL of the server, overriding the profile")
+		username   = flags.String("username", "", "username, overriding the profile")
+		password   = flags.String("password", "", "password, overriding the profile")
+		output     = flags.String("output", formatTable, "output format: table, csv or json")
+		timeout    = flags.Duration("timeout", 0, "maximum time to run the command for, e.g. 30s")
+		debug      = flags.Bool("debug", false, "log the requests and responses, with credentials redacted")
+		dryRun     = flags.Bool("dry-run", false, "only send requests which read, and print the changes which would have been made")
+	)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	cmd, ok := findCommand(flags.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "gonexus: unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return exitUsage
+	}
+
+	switch *output {
+	case formatTable, formatCSV, formatJSON:
+	default:
+		fmt.Fprintf(stderr, "gonexus: unknown output format %q\n", *output)
+		return exitUsage
+	}
+
+	e := &env{stdout: stdout, stderr: stderr, output: *output, debug: *debug, dryRun: *dryRun}
+
+	p, err := nexus.LoadProfile(*configPath, *profile)
+	if err != nil {
+		fmt.Fprintf(stderr, "gonexus: %v\n", err)
+		return exitUsage
+	}
+
+	if cmd.rm != nil {
+		e.server = p.RM
+	} else {
+		e.server = p.IQ
+	}
+	override(&e.server, *host, *username, *password)
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	return e.exec(ctx, cmd, flags.Args()[1:])
+}
+
+func (e *env) exec(ctx context.Context, cmd command, args []string) int {
+	result, err := e.call(ctx, cmd, args)
+	if result != nil {
+		if werr := write(e.stdout, e.output, result); werr != nil && err == nil {
+			err = fmt.Errorf("could not write output: %w", werr)
+		}
+	}
+	if err == nil {
+		return exitOK
+	}
+
+	fmt.Fprintf(e.stderr, "gonexus %s: %v\n", cmd.name, err)
+
+	var violations violationsError
+	switch {
+	case errors.As(err, &usageError{}):
+		fmt.Fprintf(e.stderr, "usage: gonexus %s %s\n", cmd.name, cmd.args)
+		return exitUsage
+	case errors.As(err, &violations):
+		return exitViolations
+	case errors.Is(err, nexus.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, nexus.ErrUnauthorized), errors.Is(err, nexus.ErrForbidden):
+		return exitUnauthorized
+	default:
+		return exitError
+	}
+}
+
+func (e *env) call(ctx context.Context, cmd command, args []string) (interface{}, error) {
+	if e.server.Host == "" {
+		return nil, usagef("no server configured; set the host in the profile or with -host")
+	}
+
+	if cmd.rm != nil {
+		rm, err := nexusrm.NewFromConfig(e.server, e.options()...)
+		if err != nil {
+			return nil, err
+		}
+		if e.dryRun {
+			dryRun := nexus.NewDryRun(rm)
+			defer e.printPlan(dryRun)
+			return cmd.rm(ctx, e, dryRun, args)
+		}
+		return cmd.rm(ctx, e, rm, args)
+	}
+
+	iq, err := nexusiq.NewFromConfig(e.server, e.options()...)
+	if err != nil {
+		return nil, err
+	}
+	if e.dryRun {
+		dryRun := nexus.NewDryRun(iq, nexusiq.ReadOnlyEndpoints()...)
+		defer e.printPlan(dryRun)
+		return cmd.iq(ctx, e, dryRun, args)
+	}
+	return cmd.iq(ctx, e, iq, args)
+}
+
+// options configures the clients from the flags
+func (e *env) options() []nexus.Option {
+	return []nexus.Option{nexus.WithDebug(e.debug), nexus.WithUserAgent("gonexus")}
+}
+
+// printPlan describes the requests which were not sent because of -dry-run
+func (e *env) printPlan(dryRun *nexus.DryRun) {
+	plan := dryRun.Plan()
+	if len(plan.Requests) == 0 {
+		fmt.Fprintln(e.stderr, "gonexus: dry run, no changes would be made")
+		return
+	}
+	fmt.Fprintf(e.stderr, "gonexus: dry run, the following requests were not sent:\n%s", plan)
+}
+
+// override replaces the settings of the profile with those given as flags
+func override(s *nexus.ServerConfig, host, username, password string) {
+	if host != "" {
+		s.Host = host
+	}
+	if username != "" {
+		s.Auth.Username = username
+	}
+	if password != "" {
+		s.Auth.Password = password
+	}
+}
+
+func commands() []command {
+	return append(rmCommands(), iqCommands()...)
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func usage(flags *flag.FlagSet, w io.Writer) {
+	fmt.Fprintln(w, "Usage: gonexus [flags] <command> [arguments]")
+
+	list := func(title string, cmds []command) {
+		fmt.Fprintf(w, "\n%s commands:\n", title)
+		sort.Slice(cmds, func(i, j int) bool { return cmds[i].name < cmds[j].name })
+		for _, cmd := range cmds {
+			fmt.Fprintf(w, "  %-12s %s\n", cmd.name, cmd.help)
+		}
+	}
+	list("Repository Manager", rmCommands())
+	list("IQ Server", iqCommands())
+
+	fmt.Fprintln(w, "\nFlags:")
+	flags.PrintDefaults()
+}
+
+// parseFlags parses the flags of a command, which are given after its name
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	flags.SetOutput(ioutil.Discard)
+	if err := flags.Parse(args); err != nil {
+		return usagef("%v", err)
+	}
+	return nil
+}
+
+// action splits the arguments of commands with several actions, using the default if none is given
+func action(args []string, def string) (string, []string) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	return args[0], args[1:]
+}
+
+// criteria parses arguments such as repository=maven-releases
+func criteria(args []string) (map[string]string, error) {
+	c := make(map[string]string)
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, usagef("expected search criteria such as name=value but got %q", arg)
+		}
+		c[kv[0]] = kv[1]
+	}
+	return c, nil
+}
+
+// create opens the named file, or returns stdout if no name is given
+func (e *env) create(path string) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return e.stdout, func() error { return nil }, nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return f, f.Close, nil
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sonatype-nexus-community/gonexus/iq/nexusiqtest"
+	"github.com/sonatype-nexus-community/gonexus/rm/nexusrmtest"
+)
+
+// gonexus runs the command with the given arguments and returns its exit code and output
+func gonexus(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append([]string{"-config", ""}, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func newFakeRM(t *testing.T) *nexusrmtest.Server {
+	server := nexusrmtest.NewServer()
+	server.Username, server.Password = "dummy_user", "dummy_pass"
+	server.AddComponent(nexusrmtest.Component{
+		Repository: "maven-releases",
+		Format:     "maven2",
+		Group:      "org.example",
+		Name:       "widget",
+		Version:    "1.0.0",
+		Assets:     []nexusrmtest.Asset{{Path: "org/example/widget/1.0.0/widget-1.0.0.jar", Content: []byte("jar")}},
+	})
+	return server
+}
+
+func newFakeIQ(t *testing.T) *nexusiqtest.Server {
+	server := nexusiqtest.NewServer()
+	server.Username, server.Password = "dummy_user", "dummy_pass"
+	server.Seed(nexusiqtest.Fixtures{
+		Organizations: []nexusiqtest.Organization{{ID: "org", Name: "Engineering"}},
+		Applications:  []nexusiqtest.Application{{ID: "app-id", PublicID: "app", Name: "App", OrganizationID: "org"}},
+	})
+	return server
+}
+
+func TestUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"no command", nil, exitUsage},
+		{"unknown command", []string{"nope"}, exitUsage},
+		{"unknown flag", []string{"-nope", "repositories"}, exitUsage},
+		{"unknown output", []string{"-output", "xml", "-host", "http://localhost", "repositories"}, exitUsage},
+		{"no host", []string{"repositories"}, exitUsage},
+		{"help", []string{"-h"}, exitOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, _, stderr := gonexus(test.args...)
+			if code != test.want {
+				t.Errorf("Expected exit code %d but got %d: %s", test.want, code, stderr)
+			}
+		})
+	}
+}
+
+func TestRMCommands(t *testing.T) {
+	server := newFakeRM(t)
+	defer server.Close()
+
+	rmArgs := func(args ...string) []string {
+		return append([]string{"-host", server.URL, "-username", server.Username, "-password", server.Password}, args...)
+	}
+
+	t.Run("table", func(t *testing.T) {
+		code, stdout, stderr := gonexus(rmArgs("components", "list", "maven-releases")...)
+		if code != exitOK {
+			t.Fatalf("Expected exit code %d but got %d: %s", exitOK, code, stderr)
+		}
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("Expected a header and one row but got:\n%s", stdout)
+		}
+		if !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "widget") {
+			t.Errorf("Unexpected table:\n%s", stdout)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		code, stdout, stderr := gonexus(rmArgs("-output", "csv", "search", "name=widget")...)
+		if code != exitOK {
+			t.Fatalf("Expected exit code %d but got %d: %s", exitOK, code, stderr)
+		}
+		if !strings.HasPrefix(stdout, "id,repository,format,group,name,version") || !strings.Contains(stdout, ",widget,1.0.0") {
+			t.Errorf("Unexpected CSV:\n%s", stdout)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		code, stdout, stderr := gonexus(rmArgs("-output", "json", "components", "list", "maven-releases")...)
+		if code != exitOK {
+			t.Fatalf("Expected exit code %d but got %d: %s", exitOK, code, stderr)
+		}
+
+		var components []struct {
+			Name   string `json:"name"`
+			Assets []struct {
+				Path string `json:"path"`
+			} `json:"assets"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &components); err != nil {
+			t.Fatal(err)
+		}
+		if len(components) != 1 || components[0].Name != "widget" || len(components[0].Assets) != 1 {
+			t.Errorf("Unexpected components: %+v", components)
+		}
+	})
+
+	t.Run("download", func(t *testing.T) {
+		id := server.Components()[0].Assets[0].ID
+		code, stdout, stderr := gonexus(rmArgs("assets", "download", "-o", "-", id)...)
+		if code != exitOK {
+			t.Fatalf("Expected exit code %d but got %d: %s", exitOK, code, stderr)
+		}
+		if stdout != "jar" {
+			t.Errorf("Expected the asset content but got %q", stdout)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		code, _, stderr := gonexus(rmArgs("components", "get", "nope")...)
+		if code != exitNotFound {
+			t.Errorf("Expected exit code %d but got %d: %s", exitNotFound, code, stderr)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		code, _, stderr := gonexus("-host", server.URL, "-username", "nope", "-password", "nope", "repositories")
+		if code != exitUnauthorized {
+			t.Errorf("Expected exit code %d but got %d: %s", exitUnauthorized, code, stderr)
+		}
+	})
+
+	t.Run("bad arguments", func(t *testing.T) {
+		code, _, stderr := gonexus(rmArgs("components", "list")...)
+		if code != exitUsage {
+			t.Errorf("Expected exit code %d but got %d: %s", exitUsage, code, stderr)
+		}
+		if !strings.Contains(stderr, "usage: gonexus components") {
+			t.Errorf("Expected the usage of the command but got: %s", stderr)
+		}
+	})
+}
+
+func TestIQCommands(t *testing.T) {
+	server := newFakeIQ(t)
+	defer server.Close()
+
+	iqArgs := func(args ...string) []string {
+		return append([]string{"-host", server.URL, "-username", server.Username, "-password", server.Password}, args...)
+	}
+
+	t.Run("apps", func(t *testing.T) {
+		code, stdout, stderr := gonexus(iqArgs("apps", "create", "Other", "other", "Engineering")...)
+		if code != exitOK {
+			t.Fatalf("Expected exit code %d but got %d: %s", exitOK, code, stderr)
+		}
+		if !strings.Contains(stdout, "other") {
+			t.Errorf("Expected the created application but got:\n%s", stdout)
+		}
+
+		if len(server.Applications()) != 2 {
+			t.Errorf("Expected the application to be created but found %+v", server.Applications())
+		}
+
+		code, _, stderr = gonexus(iqArgs("apps", "delete", "other")...)
+		if code != exitOK {
+			t.Fatalf("Expected exit code %d but got %d: %s", exitOK, code, stderr)
+		}
+		if len(server.Applications()) != 1 {
+			t.Errorf("Expected the application to be deleted but found %+v", server.Applications())
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		code, _, stderr := gonexus(iqArgs("-dry-run", "apps", "delete", "app")...)
+		if code != exitOK {
+			t.Fatalf("Expected exit code %d but got %d: %s", exitOK, code, stderr)
+		}
+		if len(server.Applications()) != 1 {
+			t.Errorf("Expected the application not to be deleted but found %+v", server.Applications())
+		}
+		if !strings.Contains(stderr, "1. DELETE api/v2/applications/app-id") {
+			t.Errorf("Expected the deletion to be planned but got:\n%s", stderr)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		code, _, stderr := gonexus(iqArgs("apps", "get", "nope")...)
+		if code != exitNotFound {
+			t.Errorf("Expected exit code %d but got %d: %s", exitNotFound, code, stderr)
+		}
+	})
+
+	t.Run("evaluate", func(t *testing.T) {
+		server.Evaluator = func(c nexusiqtest.Component) nexusiqtest.EvaluationResult {
+			return nexusiqtest.EvaluationResult{
+				Component:  c,
+				MatchState: "exact",
+				PolicyData: json.RawMessage(`{"policyViolations":[{"policyName":"Security-High","threatLevel":9}]}`),
+			}
+		}
+		defer func() { server.Evaluator = nil }()
+
+		code, stdout, stderr := gonexus(iqArgs("evaluate", "-app", "app", "pkg:maven/org.example/widget@1.0.0?type=jar")...)
+		if code != exitViolations {
+			t.Errorf("Expected exit code %d but got %d: %s", exitViolations, code, stderr)
+		}
+		if !strings.Contains(stdout, "Security-High") {
+			t.Errorf("Expected the violation in the output but got:\n%s", stdout)
+		}
+
+		code, _, stderr = gonexus(iqArgs("evaluate", "-app", "app", "-fail-threat", "0", "pkg:maven/org.example/widget@1.0.0?type=jar")...)
+		if code != exitOK {
+			t.Errorf("Expected exit code %d but got %d: %s", exitOK, code, stderr)
+		}
+	})
+}
+
+func TestProfiles(t *testing.T) {
+	rmServer := newFakeRM(t)
+	defer rmServer.Close()
+
+	dir, err := ioutil.TempDir("", "gonexus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cfg := fmt.Sprintf(`
+defaultProfile: dev
+profiles:
+  dev:
+    rm:
+      host: %[1]s
+      auth: {username: %[2]s, password: %[3]s}
+  prod:
+    rm:
+      host: %[1]s
+      auth:
+        username: nope
+        password: nope
+`, rmServer.URL, rmServer.Username, rmServer.Password)
+	path := filepath.Join(dir, "config.yaml")
+	if err = ioutil.WriteFile(path, []byte(cfg), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	profile := func(args ...string) int {
+		stdout.Reset()
+		stderr.Reset()
+		return run(context.Background(), append([]string{"-config", path}, args...), &stdout, &stderr)
+	}
+
+	if code := profile("repositories"); code != exitOK {
+		t.Errorf("Expected the default profile to succeed but got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "maven-releases") {
+		t.Errorf("Expected the repositories but got:\n%s", stdout.String())
+	}
+
+	if code := profile("-profile", "prod", "repositories"); code != exitUnauthorized {
+		t.Errorf("Expected the prod profile to be refused but got %d: %s", code, stderr.String())
+	}
+
+	if code := profile("-profile", "prod", "-username", rmServer.Username, "-password", rmServer.Password, "repositories"); code != exitOK {
+		t.Errorf("Expected the flags to override the profile but got %d: %s", code, stderr.String())
+	}
+
+	os.Setenv("GONEXUS_RM_PASSWORD", rmServer.Password)
+	code := profile("-profile", "prod", "-username", rmServer.Username, "repositories")
+	os.Unsetenv("GONEXUS_RM_PASSWORD")
+	if code != exitOK {
+		t.Errorf("Expected the environment to override the profile but got %d: %s", code, stderr.String())
+	}
+
+	if code := profile("-profile", "nope", "repositories"); code != exitUsage {
+		t.Errorf("Expected an unknown profile to be a usage error but got %d: %s", code, stderr.String())
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+)
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// write writes the result of a command in the given format.
+// Tables and CSV hold the scalar fields of structs, one row per item; JSON holds the complete result
+func write(w io.Writer, format string, result interface{}) error {
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	if v, ok := result.(view); ok {
+		result = v.rows
+	}
+
+	if s, ok := result.(string); ok {
+		_, err := fmt.Fprintln(w, s)
+		return err
+	}
+
+	if !indirect(reflect.ValueOf(result)).IsValid() {
+		return nil
+	}
+
+	headers, rows := tabulate(reflect.ValueOf(result))
+
+	if format == formatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	upper := make([]string, len(headers))
+	for i, h := range headers {
+		upper[i] = strings.ToUpper(h)
+	}
+	fmt.Fprintln(tw, strings.Join(upper, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// tabulate turns a struct, a slice or a map into rows of columns
+func tabulate(v reflect.Value) (headers []string, rows [][]string) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		headers = columns(v.Type().Elem())
+		for i := 0; i < v.Len(); i++ {
+			rows = append(rows, row(v.Index(i)))
+		}
+	case reflect.Map:
+		headers = append([]string{"key"}, columns(v.Type().Elem())...)
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			rows = append(rows, append([]string{fmt.Sprint(k)}, row(v.MapIndex(k))...))
+		}
+	default:
+		headers = columns(v.Type())
+		rows = [][]string{row(v)}
+	}
+
+	return headers, rows
+}
+
+// columns names the scalar fields of a struct by their JSON names. Other types have a single column
+func columns(t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if isScalar(t) {
+		return []string{"value"}
+	}
+
+	var names []string
+	for _, f := range fields(t) {
+		names = append(names, fieldName(f))
+	}
+	return names
+}
+
+func row(v reflect.Value) []string {
+	v = indirect(v)
+	if v.IsValid() && isScalar(v.Type()) {
+		return []string{format(v)}
+	}
+
+	var values []string
+	for _, f := range fields(v.Type()) {
+		if v.IsValid() {
+			values = append(values, format(v.FieldByIndex(f.Index)))
+		} else {
+			values = append(values, "")
+		}
+	}
+	return values
+}
+
+// fields returns the exported scalar fields of the struct
+func fields(t reflect.Type) []reflect.StructField {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var scalars []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || fieldName(f) == "-" {
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if isScalar(ft) {
+			scalars = append(scalars, f)
+		}
+	}
+	return scalars
+}
+
+func fieldName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+func isScalar(t reflect.Type) bool {
+	if t.Implements(stringerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func format(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+	return v
+}
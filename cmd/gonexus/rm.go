@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	nexusrm "github.com/sonatype-nexus-community/gonexus/rm"
+)
+
+func rmCommands() []command {
+	return []command{
+		{name: "repositories", args: "[name]", help: "list the repositories, or show the named one", rm: rmRepositories},
+		{name: "components", args: "list <repository> | get <id> | delete <id>", help: "list, show or delete components", rm: rmComponents},
+		{name: "assets", args: "list <repository> | get <id> | delete <id> | download [-o file] <id>", help: "list, show, delete or download assets", rm: rmAssets},
+		{name: "search", args: "[-assets] <criteria>=<value>...", help: "search for components or assets, e.g. repository=maven-releases", rm: rmSearch},
+		{name: "tags", args: "list | get <name> | add <name>", help: "list, show or create tags", rm: rmTags},
+		{name: "staging", args: "delete <criteria>=<value>...", help: "delete staged components which match the criteria", rm: rmStaging},
+		{name: "scripts", args: "list | get <name> | upload <file> | update <file> | run <name> [arguments] | delete <name>", help: "manage and run scripts", rm: rmScripts},
+		{name: "read-only", args: "status | enable | release [-force]", help: "show or change the read-only state", rm: rmReadOnly},
+		{name: "db-check", args: "[database]", help: "check the databases for corruption", rm: rmDatabaseCheck},
+		{name: "support-zip", args: "[-o file]", help: "download a support zip", rm: rmSupportZip},
+	}
+}
+
+func rmRepositories(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	switch len(args) {
+	case 0:
+		return nexusrm.GetRepositoriesContext(ctx, rm)
+	case 1:
+		return nexusrm.GetRepositoryByNameContext(ctx, rm, args[0])
+	default:
+		return nil, usagef("expected at most one repository name")
+	}
+}
+
+func rmComponents(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	act, args := action(args, "")
+	if len(args) != 1 {
+		return nil, usagef("expected an action and a single argument")
+	}
+
+	switch act {
+	case "list":
+		return nexusrm.GetComponentsContext(ctx, rm, args[0])
+	case "get":
+		return nexusrm.GetComponentByIDContext(ctx, rm, args[0])
+	case "delete":
+		return nil, nexusrm.DeleteComponentByIDContext(ctx, rm, args[0])
+	default:
+		return nil, usagef("unknown action %q", act)
+	}
+}
+
+func rmAssets(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	act, args := action(args, "")
+
+	var output string
+	if act == "download" {
+		flags := flag.NewFlagSet("download", flag.ContinueOnError)
+		flags.StringVar(&output, "o", "", "file to write the asset to, defaults to its name")
+		if err := parseFlags(flags, args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+	}
+
+	if len(args) != 1 {
+		return nil, usagef("expected an action and a single argument")
+	}
+
+	switch act {
+	case "list":
+		return nexusrm.GetAssetsContext(ctx, rm, args[0])
+	case "get":
+		return nexusrm.GetAssetByIDContext(ctx, rm, args[0])
+	case "delete":
+		return nil, nexusrm.DeleteAssetByIDContext(ctx, rm, args[0])
+	case "download":
+		asset, err := nexusrm.GetAssetByIDContext(ctx, rm, args[0])
+		if err != nil {
+			return nil, err
+		}
+		if output == "" {
+			output = filepath.Base(asset.Path)
+		}
+
+		w, closer, err := e.create(output)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = nexusrm.DownloadAssetContext(ctx, rm, asset, w, nexusrm.DownloadOptions{}); err != nil {
+			closer()
+			return nil, err
+		}
+		return nil, closer()
+	default:
+		return nil, usagef("unknown action %q", act)
+	}
+}
+
+func rmSearch(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	assets := flags.Bool("assets", false, "search for assets instead of components")
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+
+	c, err := criteria(flags.Args())
+	if err != nil {
+		return nil, err
+	}
+	if len(c) == 0 {
+		return nil, usagef("expected search criteria")
+	}
+
+	query := nexusrm.NewSearchQueryBuilder()
+	for k, v := range c {
+		query.Criteria(k, v)
+	}
+
+	if *assets {
+		return nexusrm.SearchAssetsContext(ctx, rm, query)
+	}
+	return nexusrm.SearchComponentsContext(ctx, rm, query)
+}
+
+func rmTags(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	act, args := action(args, "list")
+
+	switch {
+	case act == "list" && len(args) == 0:
+		return nexusrm.TagsListContext(ctx, rm)
+	case act == "get" && len(args) == 1:
+		return nexusrm.GetTagContext(ctx, rm, args[0])
+	case act == "add" && len(args) == 1:
+		return nexusrm.AddTagContext(ctx, rm, args[0], nil)
+	default:
+		return nil, usagef("unknown action or wrong number of arguments")
+	}
+}
+
+func rmStaging(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	act, args := action(args, "")
+	if act != "delete" {
+		return nil, usagef("unknown action %q", act)
+	}
+
+	c, err := criteria(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(c) == 0 {
+		return nil, usagef("expected criteria to select the components with")
+	}
+
+	query := nexusrm.NewQueryBuilder()
+	for k, v := range c {
+		query.Criteria(k, v)
+	}
+
+	return nil, nexusrm.StagingDeleteContext(ctx, rm, *query)
+}
+
+func rmScripts(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	act, args := action(args, "list")
+
+	readScript := func(path string) (nexusrm.Script, error) {
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nexusrm.Script{}, err
+		}
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		return nexusrm.Script{Name: name, Content: string(buf), Type: "groovy"}, nil
+	}
+
+	switch {
+	case act == "list" && len(args) == 0:
+		return nexusrm.ScriptListContext(ctx, rm)
+	case act == "get" && len(args) == 1:
+		return nexusrm.ScriptGetContext(ctx, rm, args[0])
+	case (act == "upload" || act == "update") && len(args) == 1:
+		script, err := readScript(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if act == "upload" {
+			return nil, nexusrm.ScriptUploadContext(ctx, rm, script)
+		}
+		return nil, nexusrm.ScriptUpdateContext(ctx, rm, script)
+	case act == "run" && (len(args) == 1 || len(args) == 2):
+		var arguments []byte
+		if len(args) == 2 {
+			arguments = []byte(args[1])
+		}
+		return nexusrm.ScriptRunContext(ctx, rm, args[0], arguments)
+	case act == "delete" && len(args) == 1:
+		return nil, nexusrm.ScriptDeleteContext(ctx, rm, args[0])
+	default:
+		return nil, usagef("unknown action or wrong number of arguments")
+	}
+}
+
+func rmReadOnly(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	act, args := action(args, "status")
+
+	flags := flag.NewFlagSet("read-only", flag.ContinueOnError)
+	force := flags.Bool("force", false, "release a read-only state which was initiated by the system")
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 || (*force && act != "release") {
+		return nil, usagef("unexpected arguments")
+	}
+
+	switch act {
+	case "status":
+		return nexusrm.GetReadOnlyStateContext(ctx, rm)
+	case "enable":
+		return nexusrm.ReadOnlyEnableContext(ctx, rm)
+	case "release":
+		return nexusrm.ReadOnlyReleaseContext(ctx, rm, *force)
+	default:
+		return nil, usagef("unknown action %q", act)
+	}
+}
+
+func rmDatabaseCheck(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	var (
+		states map[string]nexusrm.DatabaseState
+		err    error
+	)
+
+	switch len(args) {
+	case 0:
+		states, err = nexusrm.CheckAllDatabasesContext(ctx, rm)
+	case 1:
+		var state nexusrm.DatabaseState
+		state, err = nexusrm.CheckDatabaseContext(ctx, rm, args[0])
+		states = map[string]nexusrm.DatabaseState{args[0]: state}
+	default:
+		return nil, usagef("expected at most one database name")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for name, state := range states {
+		if state.PageCorruption || state.IndexErrors > 0 {
+			return states, fmt.Errorf("database %s is corrupt", name)
+		}
+	}
+
+	return states, nil
+}
+
+func rmSupportZip(ctx context.Context, e *env, rm nexusrm.RM, args []string) (interface{}, error) {
+	flags := flag.NewFlagSet("support-zip", flag.ContinueOnError)
+	output := flags.String("o", "", "file to write the zip to, defaults to the name given by the server")
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, usagef("unexpected arguments")
+	}
+
+	if *output != "" {
+		w, closer, err := e.create(*output)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = nexusrm.StreamSupportZipContext(ctx, rm, nexusrm.NewSupportZipOptions(), w, nil); err != nil {
+			closer()
+			return nil, err
+		}
+		return nil, closer()
+	}
+
+	zip, name, err := nexusrm.GetSupportZipContext(ctx, rm, nexusrm.NewSupportZipOptions())
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = "support.zip"
+	}
+
+	name = filepath.Base(name)
+	if err = ioutil.WriteFile(name, zip, 0644); err != nil {
+		return nil, err
+	}
+
+	return name, nil
+}
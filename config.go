@@ -0,0 +1,421 @@
+package nexus
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by the configuration loader.
+// The settings of the selected profile can be overridden with GONEXUS_RM_<SETTING> and GONEXUS_IQ_<SETTING>,
+// e.g. GONEXUS_IQ_HOST or GONEXUS_RM_PASSWORD, where the settings are those listed in envSettings
+const (
+	// EnvConfig is the path of the configuration file
+	EnvConfig = "GONEXUS_CONFIG"
+	// EnvProfile is the name of the profile used when none is given
+	EnvProfile = "GONEXUS_PROFILE"
+
+	envPrefix = "GONEXUS_"
+)
+
+// DefaultProfileName is the profile used when no profile is selected and the file does not name a default
+const DefaultProfileName = "default"
+
+// Authentication methods of a ServerConfig
+const (
+	AuthBasic       = "basic"
+	AuthUserToken   = "usertoken"
+	AuthBearer      = "bearer"
+	AuthCertificate = "certificate"
+)
+
+var (
+	// ErrUnknownProfile is returned when the requested profile is not in the configuration
+	ErrUnknownProfile = errors.New("unknown profile")
+
+	// ErrNoHost is returned when creating a client from a configuration without a host
+	ErrNoHost = errors.New("no host configured")
+)
+
+// Duration is a time.Duration which is configured as a string such as "30s", or as a number of seconds
+type Duration time.Duration
+
+// UnmarshalText parses the duration
+func (d *Duration) UnmarshalText(text []byte) error {
+	s := string(text)
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		*d = Duration(secs * float64(time.Second))
+		return nil
+	}
+
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// UnmarshalJSON parses the duration from a string or number
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return d.UnmarshalText(data)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalText formats the duration, e.g. as "1m30s"
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// AuthConfig describes how to authenticate with a server. Which fields are used depends on the Method:
+//   - basic, the default: Username and Password
+//   - usertoken: NameCode and PassCode
+//   - bearer: Token
+//   - certificate: CertFile and KeyFile, plus Username and Password if set
+type AuthConfig struct {
+	Method   string `json:"method,omitempty" yaml:"method,omitempty"`
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+	NameCode string `json:"nameCode,omitempty" yaml:"nameCode,omitempty"`
+	PassCode string `json:"passCode,omitempty" yaml:"passCode,omitempty"`
+	Token    string `json:"token,omitempty" yaml:"token,omitempty"`
+	CertFile string `json:"certFile,omitempty" yaml:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty" yaml:"keyFile,omitempty"`
+}
+
+// RetryConfig describes the RetryPolicy of a client. Unset fields keep the values of DefaultRetryPolicy
+type RetryConfig struct {
+	MaxAttempts        int      `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+	InitialBackoff     Duration `json:"initialBackoff,omitempty" yaml:"initialBackoff,omitempty"`
+	MaxBackoff         Duration `json:"maxBackoff,omitempty" yaml:"maxBackoff,omitempty"`
+	Multiplier         float64  `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+	Jitter             float64  `json:"jitter,omitempty" yaml:"jitter,omitempty"`
+	RetryStatuses      []int    `json:"retryStatuses,omitempty" yaml:"retryStatuses,omitempty"`
+	RetryNonIdempotent bool     `json:"retryNonIdempotent,omitempty" yaml:"retryNonIdempotent,omitempty"`
+}
+
+// Policy returns the retry policy described by the configuration
+func (c RetryConfig) Policy() RetryPolicy {
+	p := DefaultRetryPolicy()
+	if c.MaxAttempts != 0 {
+		p.MaxAttempts = c.MaxAttempts
+	}
+	if c.InitialBackoff != 0 {
+		p.InitialBackoff = time.Duration(c.InitialBackoff)
+	}
+	if c.MaxBackoff != 0 {
+		p.MaxBackoff = time.Duration(c.MaxBackoff)
+	}
+	if c.Multiplier != 0 {
+		p.Multiplier = c.Multiplier
+	}
+	if c.Jitter != 0 {
+		p.Jitter = c.Jitter
+	}
+	if len(c.RetryStatuses) > 0 {
+		p.RetryStatuses = c.RetryStatuses
+	}
+	p.RetryNonIdempotent = c.RetryNonIdempotent
+	return p
+}
+
+// ServerConfig describes how to connect to a Repository Manager or IQ Server instance
+type ServerConfig struct {
+	Host string     `json:"host" yaml:"host"`
+	Auth AuthConfig `json:"auth" yaml:"auth"`
+
+	// CAFile is a PEM file of certificates trusted in addition to the system ones
+	CAFile string `json:"caFile,omitempty" yaml:"caFile,omitempty"`
+
+	// Timeout limits each attempt of a request, see WithTimeout
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// Retry, if set, replaces the retry policy of the client
+	Retry *RetryConfig `json:"retry,omitempty" yaml:"retry,omitempty"`
+
+	// RateLimit, if set, limits the rate and concurrency of the requests of the client
+	RateLimit *RateLimit `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
+}
+
+// Authenticator returns the authenticator for the configured method.
+// Basic auth returns nil, as clients use the username and password they were created with
+func (s ServerConfig) Authenticator() (Authenticator, error) {
+	a := s.Auth
+	switch strings.ToLower(a.Method) {
+	case "", AuthBasic:
+		return nil, nil
+	case AuthUserToken:
+		if a.NameCode == "" || a.PassCode == "" {
+			return nil, errors.New("user token authentication requires a name code and a pass code")
+		}
+		return UserTokenAuth{NameCode: a.NameCode, PassCode: a.PassCode}, nil
+	case AuthBearer:
+		if a.Token == "" {
+			return nil, errors.New("bearer authentication requires a token")
+		}
+		return BearerAuth{Token: a.Token}, nil
+	case AuthCertificate:
+		if a.CertFile == "" || a.KeyFile == "" {
+			return nil, errors.New("certificate authentication requires a certificate and a key file")
+		}
+		auth := ClientCertAuth{CertFile: a.CertFile, KeyFile: a.KeyFile}
+		if a.Username != "" {
+			auth.Authenticator = BasicAuth{Username: a.Username, Password: a.Password}
+		}
+		return auth, nil
+	default:
+		return nil, fmt.Errorf("unknown authentication method %q", a.Method)
+	}
+}
+
+// Options returns the options which configure a client with the authentication, CA file, timeout, retry policy and rate limit
+func (s ServerConfig) Options() ([]Option, error) {
+	auth, err := s.Authenticator()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []Option
+	if auth != nil {
+		opts = append(opts, WithAuthenticator(auth))
+	}
+	if s.CAFile != "" {
+		opts = append(opts, WithCertFile(s.CAFile))
+	}
+	if s.Timeout > 0 {
+		opts = append(opts, WithTimeout(time.Duration(s.Timeout)))
+	}
+	if s.Retry != nil {
+		opts = append(opts, WithRetryPolicy(s.Retry.Policy()))
+	}
+	if s.RateLimit != nil {
+		opts = append(opts, WithLimiter(NewLimiter(*s.RateLimit)))
+	}
+	return opts, nil
+}
+
+// Profile describes the servers of an environment, such as dev or prod
+type Profile struct {
+	RM ServerConfig `json:"rm" yaml:"rm"`
+	IQ ServerConfig `json:"iq" yaml:"iq"`
+}
+
+// Config holds named profiles, in YAML or JSON, e.g.
+//
+//	defaultProfile: dev
+//	profiles:
+//	  dev:
+//	    rm:
+//	      host: http://localhost:8081
+//	      auth: {username: admin, password: admin123}
+//	  prod:
+//	    iq:
+//	      host: https://iq.example.com
+//	      auth:
+//	        method: usertoken
+//	        nameCode: abc
+//	        passCode: xyz
+//	      caFile: /etc/ssl/corp.pem
+//	      timeout: 1m
+//	      retry:
+//	        maxAttempts: 6
+type Config struct {
+	DefaultProfile string             `json:"defaultProfile,omitempty" yaml:"defaultProfile,omitempty"`
+	Profiles       map[string]Profile `json:"profiles" yaml:"profiles"`
+}
+
+// DefaultConfigPath returns the path named by GONEXUS_CONFIG or, if it exists, the first of
+// config.yaml, config.yml and config.json in ~/.gonexus. Otherwise it returns an empty string
+func DefaultConfigPath() string {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	for _, name := range []string{"config.yaml", "config.yml", "config.json"} {
+		path := filepath.Join(home, ".gonexus", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	return ""
+}
+
+// LoadConfig reads the configuration file at path, which is YAML if its extension is .yaml or .yml
+// and JSON otherwise. An empty path gives an empty configuration, so that the environment alone can be used
+func LoadConfig(path string) (Config, error) {
+	if path == "" {
+		return Config{}, nil
+	}
+
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not read configuration: %w", err)
+	}
+
+	parse := ParseConfig
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		parse = ParseYAMLConfig
+	}
+
+	cfg, err := parse(buf)
+	if err != nil {
+		return Config{}, fmt.Errorf("could not parse configuration %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// ParseConfig parses a JSON configuration. Unknown settings are an error, so that typos are not ignored
+func ParseConfig(data []byte) (Config, error) {
+	var cfg Config
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&cfg)
+
+	return cfg, err
+}
+
+// ParseYAMLConfig parses a YAML configuration. Unknown settings are an error, so that typos are not ignored
+func ParseYAMLConfig(data []byte) (Config, error) {
+	var cfg Config
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && err != io.EOF {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// Profile returns the named profile with the environment overrides applied.
+// Without a name, the profile named by GONEXUS_PROFILE, the default profile of the file or the
+// "default" profile is used, in that order. Only an explicitly selected profile has to exist
+func (c Config) Profile(name string) (Profile, error) {
+	explicit := true
+	switch {
+	case name != "":
+	case os.Getenv(EnvProfile) != "":
+		name = os.Getenv(EnvProfile)
+	case c.DefaultProfile != "":
+		name = c.DefaultProfile
+	default:
+		name, explicit = DefaultProfileName, false
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok && explicit {
+		names := make([]string, 0, len(c.Profiles))
+		for n := range c.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return p, fmt.Errorf("%w %q, expected one of: %s", ErrUnknownProfile, name, strings.Join(names, ", "))
+	}
+
+	if err := p.RM.override(envPrefix + "RM_"); err != nil {
+		return p, err
+	}
+	if err := p.IQ.override(envPrefix + "IQ_"); err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+// LoadProfile reads the configuration file at path and returns the named profile, see Config.Profile
+func LoadProfile(path, name string) (Profile, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	return cfg.Profile(name)
+}
+
+// envSettings maps the suffixes of the environment variables to the string settings they override
+func (s *ServerConfig) envSettings() map[string]*string {
+	return map[string]*string{
+		"HOST":        &s.Host,
+		"AUTH_METHOD": &s.Auth.Method,
+		"USERNAME":    &s.Auth.Username,
+		"PASSWORD":    &s.Auth.Password,
+		"NAME_CODE":   &s.Auth.NameCode,
+		"PASS_CODE":   &s.Auth.PassCode,
+		"TOKEN":       &s.Auth.Token,
+		"CERT_FILE":   &s.Auth.CertFile,
+		"KEY_FILE":    &s.Auth.KeyFile,
+		"CA_FILE":     &s.CAFile,
+	}
+}
+
+// override applies the environment variables with the given prefix
+func (s *ServerConfig) override(prefix string) error {
+	for suffix, setting := range s.envSettings() {
+		if v, ok := os.LookupEnv(prefix + suffix); ok {
+			*setting = v
+		}
+	}
+
+	if v, ok := os.LookupEnv(prefix + "TIMEOUT"); ok {
+		if err := s.Timeout.UnmarshalText([]byte(v)); err != nil {
+			return fmt.Errorf("%sTIMEOUT: %w", prefix, err)
+		}
+	}
+
+	if v, ok := os.LookupEnv(prefix + "RETRY_MAX_ATTEMPTS"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%sRETRY_MAX_ATTEMPTS: invalid number %q", prefix, v)
+		}
+		if s.Retry == nil {
+			s.Retry = new(RetryConfig)
+		}
+		s.Retry.MaxAttempts = n
+	}
+
+	if v, ok := os.LookupEnv(prefix + "REQUESTS_PER_SECOND"); ok {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("%sREQUESTS_PER_SECOND: invalid number %q", prefix, v)
+		}
+		if s.RateLimit == nil {
+			s.RateLimit = new(RateLimit)
+		}
+		s.RateLimit.RequestsPerSecond = rate
+	}
+
+	if v, ok := os.LookupEnv(prefix + "MAX_IN_FLIGHT"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%sMAX_IN_FLIGHT: invalid number %q", prefix, v)
+		}
+		if s.RateLimit == nil {
+			s.RateLimit = new(RateLimit)
+		}
+		s.RateLimit.MaxInFlight = n
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+package nexus
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testConfigYAML = `
+# Servers used by the tools
+defaultProfile: dev
+profiles:
+  dev:
+    rm:
+      host: http://localhost:8081
+      auth: {username: admin, password: "admin#123"}
+  prod:
+    rm:
+      host: https://rm.example.com
+      auth:
+        method: bearer
+        token: abc
+      timeout: 1m30s
+    iq:
+      host: https://iq.example.com
+      auth:
+        method: usertoken
+        nameCode: name
+        passCode: 'pass'
+      caFile: /etc/ssl/corp.pem
+      timeout: 45
+      retry:
+        maxAttempts: 6
+        initialBackoff: 1s
+        retryStatuses: [429, 503]
+`
+
+const testConfigJSON = `{
+  "defaultProfile": "dev",
+  "profiles": {
+    "dev": {
+      "rm": {"host": "http://localhost:8081", "auth": {"username": "admin", "password": "admin#123"}}
+    },
+    "prod": {
+      "rm": {"host": "https://rm.example.com", "auth": {"method": "bearer", "token": "abc"}, "timeout": "1m30s"},
+      "iq": {
+        "host": "https://iq.example.com",
+        "auth": {"method": "usertoken", "nameCode": "name", "passCode": "pass"},
+        "caFile": "/etc/ssl/corp.pem",
+        "timeout": 45,
+        "retry": {"maxAttempts": 6, "initialBackoff": "1s", "retryStatuses": [429, 503]}
+      }
+    }
+  }
+}`
+
+func testConfig() Config {
+	return Config{
+		DefaultProfile: "dev",
+		Profiles: map[string]Profile{
+			"dev": {
+				RM: ServerConfig{Host: "http://localhost:8081", Auth: AuthConfig{Username: "admin", Password: "admin#123"}},
+			},
+			"prod": {
+				RM: ServerConfig{
+					Host:    "https://rm.example.com",
+					Auth:    AuthConfig{Method: AuthBearer, Token: "abc"},
+					Timeout: Duration(90 * time.Second),
+				},
+				IQ: ServerConfig{
+					Host:    "https://iq.example.com",
+					Auth:    AuthConfig{Method: AuthUserToken, NameCode: "name", PassCode: "pass"},
+					CAFile:  "/etc/ssl/corp.pem",
+					Timeout: Duration(45 * time.Second),
+					Retry:   &RetryConfig{MaxAttempts: 6, InitialBackoff: Duration(time.Second), RetryStatuses: []int{429, 503}},
+				},
+			},
+		},
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := map[string]struct {
+		parse func([]byte) (Config, error)
+		data  string
+	}{
+		"yaml": {ParseYAMLConfig, testConfigYAML},
+		"json": {ParseConfig, testConfigJSON},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := test.parse([]byte(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(cfg, testConfig()) {
+				t.Errorf("Unexpected configuration\ngot:  %+v\nwant: %+v", cfg, testConfig())
+			}
+		})
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":      `{"profiles": {"dev": {"rm": {"hots": "http://localhost"}}}}`,
+		"bad duration":       `{"profiles": {"dev": {"rm": {"timeout": "soon"}}}}`,
+		"bad number":         `{"profiles": {"dev": {"rm": {"retry": {"maxAttempts": "many"}}}}}`,
+		"scalar for mapping": `{"profiles": "dev"}`,
+		"yaml":               "profiles:\n  dev:\n    rm:\n      host: http://localhost\n",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseConfig([]byte(data)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestParseYAMLConfigErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":      "profiles:\n  dev:\n    rm:\n      hots: http://localhost\n",
+		"bad duration":       "profiles:\n  dev:\n    rm:\n      timeout: soon\n",
+		"bad number":         "profiles:\n  dev:\n    rm:\n      retry: {maxAttempts: many}\n",
+		"scalar for mapping": "profiles: dev\n",
+		"bad indentation":    "profiles:\n  dev:\n    rm:\n  host: http://localhost\n   auth: {}\n",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseYAMLConfig([]byte(data)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestConfigProfile(t *testing.T) {
+	cfg := testConfig()
+
+	p, err := cfg.Profile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.RM.Host != "http://localhost:8081" {
+		t.Errorf("Expected the default profile but got %+v", p)
+	}
+
+	p, err = cfg.Profile("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.IQ.Host != "https://iq.example.com" {
+		t.Errorf("Expected the prod profile but got %+v", p)
+	}
+
+	os.Setenv(EnvProfile, "prod")
+	p, err = cfg.Profile("")
+	os.Unsetenv(EnvProfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.IQ.Host != "https://iq.example.com" {
+		t.Errorf("Expected the profile named by the environment but got %+v", p)
+	}
+
+	if _, err = cfg.Profile("nope"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("Expected ErrUnknownProfile but got %v", err)
+	}
+
+	if _, err = (Config{}).Profile(""); err != nil {
+		t.Errorf("Expected a missing default profile not to be an error but got %v", err)
+	}
+}
+
+func TestConfigEnvOverrides(t *testing.T) {
+	env := map[string]string{
+		"GONEXUS_IQ_HOST":               "https://other.example.com",
+		"GONEXUS_IQ_PASS_CODE":          "rotated",
+		"GONEXUS_IQ_TIMEOUT":            "2m",
+		"GONEXUS_IQ_RETRY_MAX_ATTEMPTS": "2",
+		"GONEXUS_RM_RETRY_MAX_ATTEMPTS": "3",
+		"GONEXUS_RM_MAX_IN_FLIGHT":      "4",
+	}
+	for k, v := range env {
+		os.Setenv(k, v)
+	}
+	defer func() {
+		for k := range env {
+			os.Unsetenv(k)
+		}
+	}()
+
+	p, err := testConfig().Profile("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := testConfig().Profiles["prod"].IQ
+	want.Host = "https://other.example.com"
+	want.Auth.PassCode = "rotated"
+	want.Timeout = Duration(2 * time.Minute)
+	want.Retry.MaxAttempts = 2
+	if !reflect.DeepEqual(p.IQ, want) {
+		t.Errorf("Unexpected IQ configuration\ngot:  %+v\nwant: %+v", p.IQ, want)
+	}
+
+	if p.RM.Retry == nil || p.RM.Retry.MaxAttempts != 3 {
+		t.Errorf("Expected a retry configuration to be created by the environment but got %+v", p.RM.Retry)
+	}
+	if p.RM.RateLimit == nil || p.RM.RateLimit.MaxInFlight != 4 {
+		t.Errorf("Expected a rate limit to be created by the environment but got %+v", p.RM.RateLimit)
+	}
+
+	os.Setenv("GONEXUS_RM_TIMEOUT", "soon")
+	defer os.Unsetenv("GONEXUS_RM_TIMEOUT")
+	if _, err = testConfig().Profile("prod"); err == nil {
+		t.Error("Expected an invalid override to be an error")
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gonexus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, data := range map[string]string{"config.yaml": testConfigYAML, "config.yml": testConfigYAML, "config.json": testConfigJSON} {
+		path := filepath.Join(dir, name)
+		if err = ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		p, err := LoadProfile(path, "prod")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(p, testConfig().Profiles["prod"]) {
+			t.Errorf("%s: unexpected profile: %+v", name, p)
+		}
+	}
+
+	// The format is chosen by the extension, not guessed from the content
+	path := filepath.Join(dir, "yaml.json")
+	if err = ioutil.WriteFile(path, []byte(testConfigYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = LoadProfile(path, ""); err == nil {
+		t.Error("Expected YAML in a .json file to be an error")
+	}
+
+	if _, err = LoadProfile(filepath.Join(dir, "missing.yaml"), ""); err == nil {
+		t.Error("Expected a missing file to be an error")
+	}
+
+	os.Setenv("GONEXUS_RM_HOST", "http://env.example.com")
+	p, err := LoadProfile("", "")
+	os.Unsetenv("GONEXUS_RM_HOST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.RM.Host != "http://env.example.com" {
+		t.Errorf("Expected the environment alone to be used but got %+v", p)
+	}
+}
+
+func TestDefaultConfigPath(t *testing.T) {
+	home, err := ioutil.TempDir("", "gonexus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	defer os.Setenv(EnvConfig, os.Getenv(EnvConfig))
+	os.Setenv("HOME", home)
+	os.Unsetenv(EnvConfig)
+
+	if path := DefaultConfigPath(); path != "" {
+		t.Errorf("Expected no configuration file but got %q", path)
+	}
+
+	if err = os.Mkdir(filepath.Join(home, ".gonexus"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"config.json", "config.yml", "config.yaml"} {
+		path := filepath.Join(home, ".gonexus", name)
+		if err = ioutil.WriteFile(path, []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if got := DefaultConfigPath(); got != path {
+			t.Errorf("Expected %s to be found but got %q", name, got)
+		}
+	}
+
+	os.Setenv(EnvConfig, "/etc/gonexus.yaml")
+	if got := DefaultConfigPath(); got != "/etc/gonexus.yaml" {
+		t.Errorf("Expected the file named by the environment but got %q", got)
+	}
+}
+
+func TestServerConfigOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		auth AuthConfig
+		want Authenticator
+	}{
+		{"basic", AuthConfig{Username: "admin", Password: "admin123"}, nil},
+		{"usertoken", AuthConfig{Method: AuthUserToken, NameCode: "n", PassCode: "p"}, UserTokenAuth{NameCode: "n", PassCode: "p"}},
+		{"bearer", AuthConfig{Method: "Bearer", Token: "abc"}, BearerAuth{Token: "abc"}},
+		{
+			"certificate",
+			AuthConfig{Method: AuthCertificate, CertFile: "c.pem", KeyFile: "k.pem", Username: "u", Password: "p"},
+			ClientCertAuth{CertFile: "c.pem", KeyFile: "k.pem", Authenticator: BasicAuth{Username: "u", Password: "p"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := ServerConfig{
+				Host:    "http://localhost",
+				Auth:    test.auth,
+				CAFile:  "ca.pem",
+				Timeout: Duration(time.Minute),
+				Retry:   &RetryConfig{MaxAttempts: 7},
+			}
+
+			opts, err := cfg.Options()
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := NewClient(ServerInfo{}, opts...)
+
+			if !reflect.DeepEqual(client.auth, test.want) {
+				t.Errorf("Expected authenticator %#v but got %#v", test.want, client.auth)
+			}
+			if client.CertFile != "ca.pem" || client.timeout != time.Minute {
+				t.Errorf("Expected the CA file and timeout to be applied but got %q and %s", client.CertFile, client.timeout)
+			}
+			if client.retryPolicy.MaxAttempts != 7 || client.retryPolicy.MaxBackoff != DefaultRetryPolicy().MaxBackoff {
+				t.Errorf("Expected the retry configuration to be applied over the default policy but got %+v", client.retryPolicy)
+			}
+		})
+	}
+
+	for _, auth := range []AuthConfig{{Method: "kerberos"}, {Method: AuthBearer}, {Method: AuthUserToken, NameCode: "n"}, {Method: AuthCertificate}} {
+		if _, err := (ServerConfig{Auth: auth}).Options(); err == nil {
+			t.Errorf("Expected an error for %+v", auth)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package nexus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrRedacted is returned when replaying a request whose credentials were redacted from a saved plan
+var ErrRedacted = errors.New("credentials of the request were redacted")
+
+// PlannedRequest is a mutating request captured by a DryRun instead of being sent.
+// Credentials in its body, such as passwords and secret keys, are redacted
+type PlannedRequest struct {
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+
+	// Payload is the body of the request when it is JSON, so that the plan shows it decoded
+	Payload json.RawMessage `json:"payload,omitempty"`
+	// Data is the body of the request when it is not JSON, such as an uploaded file
+	Data []byte `json:"data,omitempty"`
+
+	ContentType string `json:"contentType,omitempty"`
+
+	// Redacted is set when credentials were redacted from the body
+	Redacted bool `json:"redacted,omitempty"`
+
+	// original is the body before its credentials were redacted, which is only kept in memory
+	original []byte
+}
+
+func (r PlannedRequest) body() (io.Reader, error) {
+	switch {
+	case r.original != nil:
+		return bytes.NewReader(r.original), nil
+	case r.Redacted:
+		return nil, ErrRedacted
+	case len(r.Payload) > 0:
+		return bytes.NewReader(r.Payload), nil
+	case len(r.Data) > 0:
+		return bytes.NewReader(r.Data), nil
+	default:
+		return nil, nil
+	}
+}
+
+// Plan lists the mutating requests captured by a DryRun, in the order they were made.
+// It can be saved as JSON for review, and replayed once approved
+type Plan struct {
+	Requests []PlannedRequest `json:"requests"`
+}
+
+// String describes the plan with one line per request, followed by its indented payload
+func (p Plan) String() string {
+	var buf bytes.Buffer
+	for i, r := range p.Requests {
+		fmt.Fprintf(&buf, "%d. %s %s\n", i+1, r.Method, r.Endpoint)
+		switch {
+		case len(r.Payload) > 0:
+			var indented bytes.Buffer
+			if err := json.Indent(&indented, r.Payload, "   ", "  "); err == nil {
+				fmt.Fprintf(&buf, "   %s\n", indented.String())
+			}
+		case len(r.Data) > 0:
+			fmt.Fprintf(&buf, "   (%d bytes of %s)\n", len(r.Data), r.ContentType)
+		}
+	}
+	return buf.String()
+}
+
+// ReplayContext sends the requests of the plan in order with the given client, stopping at the first
+// which fails. It returns the number of requests which were sent successfully.
+// Requests whose credentials were redacted can only be replayed from the plan returned by the DryRun,
+// not from a saved copy, and otherwise fail with ErrRedacted
+func (p Plan) ReplayContext(ctx context.Context, client Client) (int, error) {
+	for i, r := range p.Requests {
+		body, err := r.body()
+		if err != nil {
+			return i, fmt.Errorf("could not replay request %d (%s %s): %w", i+1, r.Method, r.Endpoint, err)
+		}
+
+		request, err := NewRequestWithContext(ctx, client, r.Method, r.Endpoint, body)
+		if err != nil {
+			return i, fmt.Errorf("could not replay request %d (%s %s): %w", i+1, r.Method, r.Endpoint, err)
+		}
+		if r.ContentType != "" {
+			request.Header.Set("Content-Type", r.ContentType)
+		}
+
+		if _, _, err = client.Do(request); err != nil {
+			return i, fmt.Errorf("could not replay request %d (%s %s): %w", i+1, r.Method, r.Endpoint, err)
+		}
+	}
+	return len(p.Requests), nil
+}
+
+// Replay sends the requests of the plan in order with the given client
+func (p Plan) Replay(client Client) (int, error) {
+	return p.ReplayContext(context.Background(), client)
+}
+
+// DryRun wraps a Client so that GET requests are sent as usual, but POST, PUT, DELETE and other
+// mutating requests are added to a Plan and answered with a synthetic success.
+// Since it is a Client, it can be given to any helper, e.g. to see what a cleanup would delete.
+// Requests made after a captured one see the server as it was, not as the plan would leave it
+type DryRun struct {
+	Client
+
+	readOnly  []string
+	responses []cannedResponse
+	mu        sync.Mutex
+	plan      Plan
+}
+
+// cannedResponse answers the captured requests of a method to an endpoint template
+type cannedResponse struct {
+	method, endpoint string
+	status           int
+	body             []byte
+}
+
+// NewDryRun creates a DryRun which reads through the given client. The read-only endpoints, templates
+// such as api/v2/evaluation/applications/%s, are requested with POST but do not change the server,
+// so they are sent as well.
+//
+// Captured requests are answered with an empty 204 No Content, since what the server would have returned,
+// such as the identifier of a created object, is not known. Helpers which decode the response therefore fail
+// rather than go on with made up values, unless a response is supplied with Respond
+func NewDryRun(client Client, readOnly ...string) *DryRun {
+	return &DryRun{Client: client, readOnly: readOnly}
+}
+
+// Respond sets the response to the captured requests of the method to the endpoint, a template such as
+// api/v2/applications/%s, e.g. the object a creation would return. The first matching response is used
+func (d *DryRun) Respond(method, endpoint string, status int, body []byte) *DryRun {
+	d.mu.Lock()
+	d.responses = append(d.responses, cannedResponse{method: method, endpoint: endpoint, status: status, body: body})
+	d.mu.Unlock()
+	return d
+}
+
+// response returns the response supplied for the request, or an empty 204 No Content
+func (d *DryRun) response(request *http.Request) (int, []byte) {
+	path := relativePath(d.Info().Host, request.URL)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, r := range d.responses {
+		if r.method == request.Method && matchesTemplate(path, r.endpoint) {
+			return r.status, append([]byte(nil), r.body...)
+		}
+	}
+	return http.StatusNoContent, nil
+}
+
+// Unwrap returns the wrapped client
+func (d *DryRun) Unwrap() Client {
+	return d.Client
+}
+
+// Plan returns the requests captured so far
+func (d *DryRun) Plan() Plan {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return Plan{Requests: append([]PlannedRequest(nil), d.plan.Requests...)}
+}
+
+// Reset discards the requests captured so far
+func (d *DryRun) Reset() {
+	d.mu.Lock()
+	d.plan.Requests = nil
+	d.mu.Unlock()
+}
+
+// sends determines if the request does not change the server, so that it is sent rather than captured
+func (d *DryRun) sends(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	path := relativePath(d.Info().Host, request.URL)
+	for _, template := range d.readOnly {
+		if matchesTemplate(path, template) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesTemplate determines if the path is one of the endpoint template, whose %s segments match any segment
+func matchesTemplate(path, template string) bool {
+	if i := strings.IndexByte(template, '?'); i >= 0 {
+		template = template[:i]
+	}
+
+	segments, templates := strings.Split(path, "/"), strings.Split(template, "/")
+	if len(segments) != len(templates) {
+		return false
+	}
+	for i, t := range templates {
+		if t != segments[i] && !strings.Contains(t, "%") {
+			return false
+		}
+	}
+	return true
+}
+
+// Do sends read-only requests with the wrapped client and captures the others
+func (d *DryRun) Do(request *http.Request) ([]byte, *http.Response, error) {
+	if d.sends(request) {
+		return d.Client.Do(request)
+	}
+	return d.capture(request)
+}
+
+// Stream sends read-only requests with the wrapped client and captures the others
+func (d *DryRun) Stream(request *http.Request) (io.ReadCloser, *http.Response, error) {
+	if d.sends(request) {
+		return Stream(d.Client, request)
+	}
+
+	body, resp, err := d.capture(request)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(body)), resp, nil
+}
+
+// capture adds the request to the plan, with its credentials redacted, and answers it
+func (d *DryRun) capture(request *http.Request) ([]byte, *http.Response, error) {
+	planned := PlannedRequest{
+		Method:      request.Method,
+		Endpoint:    strings.TrimPrefix(strings.TrimPrefix(request.URL.String(), strings.TrimSuffix(d.Info().Host, "/")), "/"),
+		ContentType: request.Header.Get("Content-Type"),
+	}
+
+	if request.Body != nil {
+		data, err := ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not read payload of %s %s: %w", planned.Method, planned.Endpoint, err)
+		}
+
+		if scrubbed := scrubRequestBody(request, data); !bytes.Equal(scrubbed, data) {
+			planned.original, planned.Redacted = data, true
+			data = scrubbed
+		}
+
+		if json.Valid(data) {
+			planned.Payload = data
+		} else {
+			planned.Data = data
+		}
+	}
+	if planned.ContentType == "application/json" && len(planned.Payload) > 0 {
+		planned.ContentType = ""
+	}
+
+	d.mu.Lock()
+	d.plan.Requests = append(d.plan.Requests, planned)
+	d.mu.Unlock()
+
+	status, body := d.response(request)
+	header := make(http.Header)
+	if len(body) > 0 {
+		header.Set("Content-Type", "application/json")
+	}
+
+	return body, &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
+
+// NewRequestWithContext creates a request of the wrapped client bound to the given context
+func (d *DryRun) NewRequestWithContext(ctx context.Context, method, endpoint string, payload io.Reader) (*http.Request, error) {
+	return NewRequestWithContext(ctx, d.Client, method, endpoint, payload)
+}
+
+// Post captures an HTTP POST against the indicated endpoint
+func (d *DryRun) Post(endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	return d.PostContext(context.Background(), endpoint, payload)
+}
+
+// PostContext captures an HTTP POST against the indicated endpoint
+func (d *DryRun) PostContext(ctx context.Context, endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	return PostContext(ctx, d, endpoint, payload)
+}
+
+// Put captures an HTTP PUT against the indicated endpoint
+func (d *DryRun) Put(endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	return d.PutContext(context.Background(), endpoint, payload)
+}
+
+// PutContext captures an HTTP PUT against the indicated endpoint
+func (d *DryRun) PutContext(ctx context.Context, endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	return PutContext(ctx, d, endpoint, payload)
+}
+
+// Del captures an HTTP DELETE against the indicated endpoint
+func (d *DryRun) Del(endpoint string) (*http.Response, error) {
+	return d.DelContext(context.Background(), endpoint)
+}
+
+// DelContext captures an HTTP DELETE against the indicated endpoint
+func (d *DryRun) DelContext(ctx context.Context, endpoint string) (*http.Response, error) {
+	return DelContext(ctx, d, endpoint)
+}
@@ -0,0 +1,265 @@
+package nexus
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingServer records the requests it receives
+func recordingServer() (*httptest.Server, func() []string) {
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		mu.Lock()
+		received = append(received, strings.TrimSpace(r.Method+" "+r.URL.RequestURI()+" "+string(body)))
+		mu.Unlock()
+
+		w.Write([]byte(`{"read": true}`))
+	}))
+
+	return mock, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), received...)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	mock, received := recordingServer()
+	defer mock.Close()
+
+	dryRun := NewDryRun(&DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}})
+
+	body, _, err := dryRun.Get("api/items")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"read": true}` {
+		t.Errorf("Expected the GET to be sent but got %q", body)
+	}
+
+	body, resp, err := dryRun.Post("api/items", bytes.NewBufferString(`{"name":"item"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusNoContent || len(body) != 0 {
+		t.Errorf("Expected a synthetic no content but got %d: %q", resp.StatusCode, body)
+	}
+
+	if _, _, err = dryRun.Put("api/items/1?force=true", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := dryRun.NewRequest(http.MethodPost, "api/upload", bytes.NewBufferString("raw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "text/plain")
+	stream, _, err := dryRun.Stream(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Close()
+
+	if resp, err = dryRun.Del("api/items/1"); err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected a synthetic no content but got %v, %v", resp, err)
+	}
+
+	if got := received(); !reflect.DeepEqual(got, []string{"GET /api/items"}) {
+		t.Errorf("Expected only the GET to be sent but the server received %v", got)
+	}
+
+	want := Plan{Requests: []PlannedRequest{
+		{Method: http.MethodPost, Endpoint: "api/items", Payload: json.RawMessage(`{"name":"item"}`)},
+		{Method: http.MethodPut, Endpoint: "api/items/1?force=true"},
+		{Method: http.MethodPost, Endpoint: "api/upload", Data: []byte("raw"), ContentType: "text/plain"},
+		{Method: http.MethodDelete, Endpoint: "api/items/1"},
+	}}
+	if plan := dryRun.Plan(); !reflect.DeepEqual(plan, want) {
+		t.Errorf("Unexpected plan\ngot:  %+v\nwant: %+v", plan, want)
+	}
+
+	const wantString = `1. POST api/items
+   {
+     "name": "item"
+   }
+2. PUT api/items/1?force=true
+3. POST api/upload
+   (3 bytes of text/plain)
+4. DELETE api/items/1
+`
+	if s := dryRun.Plan().String(); s != wantString {
+		t.Errorf("Unexpected description of the plan:\n%s", s)
+	}
+
+	dryRun.Reset()
+	if n := len(dryRun.Plan().Requests); n != 0 {
+		t.Errorf("Expected the plan to be empty after a reset but it has %d requests", n)
+	}
+}
+
+func TestPlanReplay(t *testing.T) {
+	mock, received := recordingServer()
+	defer mock.Close()
+
+	client := &DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}}
+	dryRun := NewDryRun(client)
+	dryRun.Post("api/items", bytes.NewBufferString(`{"name":"item"}`))
+	dryRun.Del("api/items/1")
+
+	// The plan survives being saved for review
+	saved, err := json.Marshal(dryRun.Plan())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var plan Plan
+	if err = json.Unmarshal(saved, &plan); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := plan.Replay(client)
+	if err != nil || n != 2 {
+		t.Fatalf("Expected the 2 requests to be replayed but got %d, %v", n, err)
+	}
+
+	want := []string{`POST /api/items {"name":"item"}`, "DELETE /api/items/1"}
+	if got := received(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the plan to be sent but the server received %v", got)
+	}
+}
+
+func TestPlanReplayStops(t *testing.T) {
+	var calls int
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer mock.Close()
+
+	plan := Plan{Requests: []PlannedRequest{{Method: "DELETE", Endpoint: "a"}, {Method: "DELETE", Endpoint: "b"}, {Method: "DELETE", Endpoint: "c"}}}
+	n, err := plan.Replay(&DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}})
+	if err == nil || n != 1 || calls != 2 {
+		t.Errorf("Expected the replay to stop at the failed request but got %d, %v after %d calls", n, err, calls)
+	}
+}
+
+func TestDryRunReadOnlyEndpoints(t *testing.T) {
+	mock, received := recordingServer()
+	defer mock.Close()
+
+	dryRun := NewDryRun(&DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}}, "api/reports", "api/evaluate/%s")
+
+	for _, endpoint := range []string{"api/reports", "api/evaluate/app", "api/evaluate/app/more", "api/reports/1"} {
+		if _, _, err := dryRun.Post(endpoint, bytes.NewBufferString(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{"POST /api/reports {}", "POST /api/evaluate/app {}"}
+	if got := received(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the read-only endpoints to be sent but the server received %v", got)
+	}
+	if n := len(dryRun.Plan().Requests); n != 2 {
+		t.Errorf("Expected the other endpoints to be planned but the plan has %d requests", n)
+	}
+}
+
+func TestDryRunRedactsCredentials(t *testing.T) {
+	mock, received := recordingServer()
+	defer mock.Close()
+
+	client := &DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}}
+	dryRun := NewDryRun(client)
+
+	if _, _, err := dryRun.Post("service/rest/v1/security/users", bytes.NewBufferString(`{"userId":"jdoe","password":"secret-password"}`)); err != nil {
+		t.Fatal(err)
+	}
+	dryRun.Post("service/rest/v1/blobstores/s3", bytes.NewBufferString(`{"bucketSecurity":{"accessKeyId":"secret-id","secretAccessKey":"secret-key"}}`))
+
+	request, err := dryRun.NewRequest(http.MethodPut, "service/rest/v1/security/users/jdoe/change-password", bytes.NewBufferString("secret-plain"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "text/plain")
+	if _, _, err = dryRun.Do(request); err != nil {
+		t.Fatal(err)
+	}
+
+	plan := dryRun.Plan()
+	saved, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{plan.String(), string(saved)} {
+		for _, secret := range []string{"secret-password", "secret-id", "secret-key", "secret-plain"} {
+			if strings.Contains(s, secret) {
+				t.Errorf("Plan contains credentials %q: %s", secret, s)
+			}
+		}
+	}
+
+	if n, err := plan.Replay(client); err != nil || n != 3 {
+		t.Fatalf("Expected the plan to be replayed with its credentials but got %d, %v", n, err)
+	}
+	if got := received(); len(got) != 3 || got[2] != "PUT /service/rest/v1/security/users/jdoe/change-password secret-plain" {
+		t.Errorf("Expected the credentials to be sent but the server received %v", got)
+	}
+
+	var loaded Plan
+	if err = json.Unmarshal(saved, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := loaded.Replay(client); !errors.Is(err, ErrRedacted) || n != 0 {
+		t.Errorf("Expected the saved plan not to be replayed without its credentials but got %d, %v", n, err)
+	}
+}
+
+func TestDryRunRespond(t *testing.T) {
+	mock, received := recordingServer()
+	defer mock.Close()
+
+	dryRun := NewDryRun(&DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}}).
+		Respond(http.MethodPost, "api/items", http.StatusOK, []byte(`{"id":"planned"}`)).
+		Respond(http.MethodPut, "api/items/%s", http.StatusAccepted, nil)
+
+	body, resp, err := dryRun.Post("api/items", bytes.NewBufferString(`{"name":"item"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || string(body) != `{"id":"planned"}` || resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected the supplied response but got %d %v: %q", resp.StatusCode, resp.Header, body)
+	}
+
+	if _, resp, err = dryRun.Put("api/items/1", nil); err != nil || resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected the supplied status but got %v, %v", resp, err)
+	}
+
+	// Only the method and endpoint of a supplied response match it
+	for _, endpoint := range []string{"api/items/1", "api/others"} {
+		body, resp, err = dryRun.Post(endpoint, nil)
+		if err != nil || resp.StatusCode != http.StatusNoContent || len(body) != 0 {
+			t.Errorf("%s: expected a synthetic no content but got %v, %q, %v", endpoint, resp, body, err)
+		}
+	}
+
+	if got := received(); len(got) != 0 {
+		t.Errorf("Expected no request to be sent but the server received %v", got)
+	}
+	if n := len(dryRun.Plan().Requests); n != 4 {
+		t.Errorf("Expected the 4 requests to be planned but the plan has %d", n)
+	}
+}
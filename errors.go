@@ -0,0 +1,125 @@
+package nexus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors which an *Error matches, via errors.Is, based on its status code
+var (
+	ErrBadRequest   = errors.New("bad request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrServer       = errors.New("server error")
+)
+
+const (
+	// maxErrorBodySize limits how much of an error response is kept
+	maxErrorBodySize = 64 * 1024
+
+	// maxErrorMessageLength is the longest plain text body which is used as the server message
+	maxErrorMessageLength = 512
+)
+
+// Error is returned when the server responds to a request with an unsuccessful status
+type Error struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Status     string
+	Body       []byte
+
+	// Message is the error message returned by the server, if one could be found in the body
+	Message string
+}
+
+func newError(request *http.Request, resp *http.Response, body []byte) *Error {
+	e := &Error{
+		Method:     request.Method,
+		Endpoint:   request.URL.RequestURI(),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+		Message:    parseErrorMessage(body),
+	}
+	if e.Status == "" {
+		e.Status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return e
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s %s: %s", e.Method, e.Endpoint, e.Status)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is allows matching the error against the sentinel of its status code, e.g. errors.Is(err, nexus.ErrNotFound)
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// parseErrorMessage extracts the message from the error formats used by RM and IQ:
+// a JSON object, a list of validation errors or plain text
+func parseErrorMessage(body []byte) string {
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" {
+		return ""
+	}
+
+	type jsonError struct {
+		Message      string `json:"message"`
+		ErrorMessage string `json:"errorMessage"`
+	}
+	messageOf := func(e jsonError) string {
+		if e.Message != "" {
+			return e.Message
+		}
+		return e.ErrorMessage
+	}
+
+	var single jsonError
+	if err := json.Unmarshal(body, &single); err == nil {
+		return messageOf(single)
+	}
+
+	var list []jsonError
+	if err := json.Unmarshal(body, &list); err == nil {
+		msgs := make([]string, 0, len(list))
+		for _, e := range list {
+			if m := messageOf(e); m != "" {
+				msgs = append(msgs, m)
+			}
+		}
+		return strings.Join(msgs, "; ")
+	}
+
+	// Skip HTML error pages and anything else which would not make for a readable message
+	if strings.HasPrefix(trimmed, "<") || len(trimmed) > maxErrorMessageLength {
+		return ""
+	}
+
+	return trimmed
+}
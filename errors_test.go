@@ -0,0 +1,87 @@
+package nexus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorFromResponse(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"component not found"}`)
+	}))
+	defer mock.Close()
+
+	client := &DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}}
+
+	_, _, err := client.Get("service/rest/v1/components/foo?repository=bar")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	wrapped := fmt.Errorf("component not retrieved: %w", err)
+
+	var nerr *Error
+	if !errors.As(wrapped, &nerr) {
+		t.Fatalf("Expected a *nexus.Error but got %T", err)
+	}
+
+	if nerr.Method != http.MethodGet || nerr.Endpoint != "/service/rest/v1/components/foo?repository=bar" {
+		t.Errorf("Unexpected request details: %s %s", nerr.Method, nerr.Endpoint)
+	}
+
+	if nerr.StatusCode != http.StatusNotFound || nerr.Message != "component not found" {
+		t.Errorf("Unexpected response details: %d %q", nerr.StatusCode, nerr.Message)
+	}
+
+	if !errors.Is(wrapped, ErrNotFound) {
+		t.Error("Expected error to match ErrNotFound")
+	}
+
+	if errors.Is(wrapped, ErrUnauthorized) || errors.Is(wrapped, ErrConflict) {
+		t.Error("Error matched an unrelated sentinel")
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	tests := []struct {
+		status   int
+		sentinel error
+	}{
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusBadGateway, ErrServer},
+	}
+
+	for _, test := range tests {
+		if err := (&Error{StatusCode: test.status}); !errors.Is(err, test.sentinel) {
+			t.Errorf("Status %d did not match %v", test.status, test.sentinel)
+		}
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	tests := []struct {
+		body, want string
+	}{
+		{"", ""},
+		{`{"message":"oops"}`, "oops"},
+		{`{"errorMessage":"bad application"}`, "bad application"},
+		{`[{"id":"name","message":"is required"},{"id":"format","message":"is invalid"}]`, "is required; is invalid"},
+		{"Application not found\n", "Application not found"},
+		{"<html><body>Not Found</body></html>", ""},
+	}
+
+	for _, test := range tests {
+		if got := parseErrorMessage([]byte(test.body)); got != test.want {
+			t.Errorf("Body %q: expected %q but got %q", test.body, test.want, got)
+		}
+	}
+}
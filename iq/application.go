@@ -2,9 +2,11 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -44,13 +46,13 @@ type Application struct {
 	} `json:"applicationTags,omitempty"`
 }
 
-// GetApplicationByPublicID returns details on the named IQ application
-func GetApplicationByPublicID(iq IQ, applicationPublicID string) (*Application, error) {
+// GetApplicationByPublicIDContext returns details on the named IQ application
+func GetApplicationByPublicIDContext(ctx context.Context, iq IQ, applicationPublicID string) (*Application, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("application '%s' not found: %v", applicationPublicID, err)
+		return fmt.Errorf("application '%s' not found: %w", applicationPublicID, err)
 	}
 	endpoint := fmt.Sprintf(restApplicationByPublic, applicationPublicID)
-	body, _, err := iq.Get(endpoint)
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
 	if err != nil {
 		return nil, doError(err)
 	}
@@ -61,20 +63,25 @@ func GetApplicationByPublicID(iq IQ, applicationPublicID string) (*Application,
 	}
 
 	if len(resp.Applications) == 0 {
-		return nil, fmt.Errorf("application %s not found", applicationPublicID)
+		return nil, fmt.Errorf("application %s %w", applicationPublicID, nexus.ErrNotFound)
 	}
 
 	return &resp.Applications[0], nil
 }
 
-// CreateApplication creates an application in IQ with the given name and identifier
-func CreateApplication(iq IQ, name, id, organizationID string) (string, error) {
+// GetApplicationByPublicID returns details on the named IQ application
+func GetApplicationByPublicID(iq IQ, applicationPublicID string) (*Application, error) {
+	return GetApplicationByPublicIDContext(context.Background(), iq, applicationPublicID)
+}
+
+// CreateApplicationContext creates an application in IQ with the given name and identifier
+func CreateApplicationContext(ctx context.Context, iq IQ, name, id, organizationID string) (string, error) {
 	if name == "" || id == "" || organizationID == "" {
 		return "", fmt.Errorf("cannot create application with empty values")
 	}
 
 	doError := func(err error) (string, error) {
-		return "", fmt.Errorf("application '%s' not created: %v", name, err)
+		return "", fmt.Errorf("application '%s' not created: %w", name, err)
 	}
 
 	request, err := json.Marshal(iqNewAppRequest{Name: name, PublicID: id, OrganizationID: organizationID})
@@ -82,7 +89,7 @@ func CreateApplication(iq IQ, name, id, organizationID string) (string, error) {
 		return doError(err)
 	}
 
-	body, _, err := iq.Post(restApplication, bytes.NewBuffer(request))
+	body, _, err := nexus.PostContext(ctx, iq, restApplication, bytes.NewBuffer(request))
 	if err != nil {
 		return doError(err)
 	}
@@ -95,39 +102,54 @@ func CreateApplication(iq IQ, name, id, organizationID string) (string, error) {
 	return resp.ID, nil
 }
 
-// DeleteApplication deletes an application in IQ with the given id
-func DeleteApplication(iq IQ, applicationID string) error {
-	if resp, err := iq.Del(fmt.Sprintf("%s/%s", restApplication, applicationID)); err != nil && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("application '%s' not deleted: %v", applicationID, err)
+// CreateApplication creates an application in IQ with the given name and identifier
+func CreateApplication(iq IQ, name, id, organizationID string) (string, error) {
+	return CreateApplicationContext(context.Background(), iq, name, id, organizationID)
+}
+
+// DeleteApplicationContext deletes an application in IQ with the given id
+func DeleteApplicationContext(ctx context.Context, iq IQ, applicationID string) error {
+	if _, err := nexus.DelContext(ctx, iq, fmt.Sprintf("%s/%s", restApplication, applicationID)); err != nil {
+		return fmt.Errorf("application '%s' not deleted: %w", applicationID, err)
 	}
 	return nil
 }
 
-// GetAllApplications returns a slice of all of the applications in an IQ instance
-func GetAllApplications(iq IQ) ([]Application, error) {
-	body, _, err := iq.Get(restApplication)
+// DeleteApplication deletes an application in IQ with the given id
+func DeleteApplication(iq IQ, applicationID string) error {
+	return DeleteApplicationContext(context.Background(), iq, applicationID)
+}
+
+// GetAllApplicationsContext returns a slice of all of the applications in an IQ instance
+func GetAllApplicationsContext(ctx context.Context, iq IQ) ([]Application, error) {
+	body, _, err := nexus.GetContext(ctx, iq, restApplication)
 	if err != nil {
-		return nil, fmt.Errorf("applications not found: %v", err)
+		return nil, fmt.Errorf("applications not found: %w", err)
 	}
 
 	var resp allAppsResponse
 	if err = json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("applications not found: %v", err)
+		return nil, fmt.Errorf("applications not found: %w", err)
 	}
 
 	return resp.Applications, nil
 }
 
-// GetApplicationsByOrganization returns all applications under a given organization
-func GetApplicationsByOrganization(iq IQ, organizationName string) ([]Application, error) {
-	org, err := GetOrganizationByName(iq, organizationName)
+// GetAllApplications returns a slice of all of the applications in an IQ instance
+func GetAllApplications(iq IQ) ([]Application, error) {
+	return GetAllApplicationsContext(context.Background(), iq)
+}
+
+// GetApplicationsByOrganizationContext returns all applications under a given organization
+func GetApplicationsByOrganizationContext(ctx context.Context, iq IQ, organizationName string) ([]Application, error) {
+	org, err := GetOrganizationByNameContext(ctx, iq, organizationName)
 	if err != nil {
-		return nil, fmt.Errorf("organization not found: %v", err)
+		return nil, fmt.Errorf("organization not found: %w", err)
 	}
 
-	apps, err := GetAllApplications(iq)
+	apps, err := GetAllApplicationsContext(ctx, iq)
 	if err != nil {
-		return nil, fmt.Errorf("could not get applications list: %v", err)
+		return nil, fmt.Errorf("could not get applications list: %w", err)
 	}
 
 	orgApps := make([]Application, 0)
@@ -139,3 +161,8 @@ func GetApplicationsByOrganization(iq IQ, organizationName string) ([]Applicatio
 
 	return orgApps, nil
 }
+
+// GetApplicationsByOrganization returns all applications under a given organization
+func GetApplicationsByOrganization(iq IQ, organizationName string) ([]Application, error) {
+	return GetApplicationsByOrganizationContext(context.Background(), iq, organizationName)
+}
@@ -2,13 +2,17 @@ package nexusiq
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 var dummyApps = []Application{
@@ -94,7 +98,7 @@ func applicationTestFunc(t *testing.T, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func applicationTestIQ(t *testing.T) (iq IQ, mock *httptest.Server) {
+func applicationTestIQ(t *testing.T, opts ...nexus.Option) (iq IQ, mock *httptest.Server) {
 	return newTestIQ(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.URL.Path[1:] == restOrganization:
@@ -102,7 +106,7 @@ func applicationTestIQ(t *testing.T) (iq IQ, mock *httptest.Server) {
 		default:
 			applicationTestFunc(t, w, r)
 		}
-	})
+	}, opts...)
 }
 
 func TestGetAllApplications(t *testing.T) {
@@ -292,3 +296,55 @@ func ExampleCreateApplication() {
 	}
 	fmt.Printf("Application ID: %s\n", appID)
 }
+
+func TestGetApplicationByPublicIDNotFound(t *testing.T) {
+	iq, mock := applicationTestIQ(t)
+	defer mock.Close()
+
+	_, err := GetApplicationByPublicID(iq, "doesNotExist")
+	if !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected a not found error but got: %v", err)
+	}
+}
+
+func TestApplicationsCached(t *testing.T) {
+	var requests int32
+	iq, mock := applicationTestIQ(t,
+		nexus.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return nexus.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				atomic.AddInt32(&requests, 1)
+				return next.RoundTrip(r)
+			})
+		}),
+		nexus.WithCache(nexus.NewCache(DefaultCachePolicy())),
+	)
+	defer mock.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := GetApplicationByPublicID(iq, dummyApps[0].PublicID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := GetAllApplications(iq); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("Expected the applications to be requested once but got %d requests", n)
+	}
+
+	if _, err := CreateApplication(iq, "cached", "cachedPublicId", "organization"); err != nil {
+		t.Fatal(err)
+	}
+	defer DeleteApplication(iq, "cachedPublicId")
+
+	apps, err := GetAllApplications(iq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != len(dummyApps) {
+		t.Errorf("Expected the new application to be listed once the cache was invalidated but got %v", apps)
+	}
+	if n := atomic.LoadInt32(&requests); n != 4 {
+		t.Errorf("Expected the creation to invalidate the applications but got %d requests", n)
+	}
+}
@@ -0,0 +1,107 @@
+package nexusiq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const restProductVersion = "rest/product/version"
+
+// Features of IQ which are only available from a given release
+var (
+	FeatureRoleMemberships = nexus.Feature{
+		Name:  "roleMemberships v70",
+		Since: nexus.Version{Major: 1, Minor: 70},
+		Probe: probe(fmt.Sprintf(restRoleMembersOrgGet, RootOrganization)),
+	}
+	FeatureRoles = nexus.Feature{
+		Name:  "roles v70",
+		Since: nexus.Version{Major: 1, Minor: 70},
+		Probe: probe(restRoles),
+	}
+)
+
+type productVersion struct {
+	Version string `json:"version"`
+}
+
+// probe creates a Feature Probe which finds the feature by the existence of the endpoint
+func probe(endpoint string) func(context.Context, nexus.Client) (bool, error) {
+	return func(ctx context.Context, client nexus.Client) (bool, error) {
+		request, err := nexus.NewRequestWithContext(ctx, client, http.MethodHead, endpoint, nil)
+		if err != nil {
+			return false, err
+		}
+
+		_, resp, err := client.Do(request)
+		if resp == nil {
+			return false, err
+		}
+
+		return resp.StatusCode != http.StatusNotFound, nil
+	}
+}
+
+func detectVersion(ctx context.Context, client nexus.Client) (nexus.Version, error) {
+	body, resp, err := nexus.GetContext(ctx, client, restProductVersion)
+	if resp == nil {
+		return nexus.Version{}, err
+	}
+	if err != nil {
+		return nexus.Version{}, nil
+	}
+
+	var product productVersion
+	if err = json.Unmarshal(body, &product); err != nil {
+		return nexus.Version{}, nil
+	}
+
+	version, err := nexus.ParseVersion(product.Version)
+	if err != nil {
+		return nexus.Version{}, nil
+	}
+
+	return version, nil
+}
+
+// Capabilities returns the capabilities of the IQ server. Those of clients created with New are cached,
+// so the version of the server is only detected once, including through wrappers such as nexus.DryRun
+func Capabilities(iq IQ) *nexus.Capabilities {
+	if c, ok := iq.(*iqClient); ok {
+		return c.capabilities
+	}
+	if c, ok := iq.(interface{ Unwrap() nexus.Client }); ok {
+		return Capabilities(c.Unwrap())
+	}
+	return nexus.NewCapabilities(iq, detectVersion)
+}
+
+// ServerVersionContext returns the version of the IQ server
+func ServerVersionContext(ctx context.Context, iq IQ) (nexus.Version, error) {
+	return Capabilities(iq).Version(ctx)
+}
+
+// ServerVersion returns the version of the IQ server
+func ServerVersion(iq IQ) (nexus.Version, error) {
+	return ServerVersionContext(context.Background(), iq)
+}
+
+// SupportsContext determines if the IQ server has the given feature
+func SupportsContext(ctx context.Context, iq IQ, feature nexus.Feature) (bool, error) {
+	return Capabilities(iq).Supports(ctx, feature)
+}
+
+// Supports determines if the IQ server has the given feature
+func Supports(iq IQ, feature nexus.Feature) (bool, error) {
+	return SupportsContext(context.Background(), iq, feature)
+}
+
+// supports determines if the server has the feature, assuming it does when that cannot be determined
+func supports(ctx context.Context, iq IQ, feature nexus.Feature) bool {
+	supported, err := SupportsContext(ctx, iq, feature)
+	return supported || err != nil
+}
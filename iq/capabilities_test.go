@@ -0,0 +1,78 @@
+package nexusiq
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+func capabilitiesTestIQ(t *testing.T, version string, requests map[string]int) (IQ, *httptest.Server) {
+	return newTestIQ(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests[r.Method+" "+r.URL.Path[1:]]++
+		switch {
+		case r.URL.Path[1:] == restProductVersion:
+			fmt.Fprintf(w, `{"name":"sonatype-clm-server","version":%q}`, version)
+		case r.URL.Path[1:] == restRoleMembersGlobalGet:
+			fmt.Fprint(w, `{"memberMappings":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestServerVersion(t *testing.T) {
+	requests := make(map[string]int)
+	iq, mock := capabilitiesTestIQ(t, "1.100.0-01", requests)
+	defer mock.Close()
+
+	for i := 0; i < 3; i++ {
+		version, err := ServerVersion(iq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := nexus.MustParseVersion("1.100.0-01"); version != want {
+			t.Errorf("got %v, want %v", version, want)
+		}
+	}
+
+	if n := requests["GET "+restProductVersion]; n != 1 {
+		t.Errorf("Expected version to be requested once but was requested %d times", n)
+	}
+}
+
+func TestSupportsByVersion(t *testing.T) {
+	requests := make(map[string]int)
+	iq, mock := capabilitiesTestIQ(t, "1.100.0-01", requests)
+	defer mock.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := GlobalAuthorizations(iq); err != nil {
+			t.Error(err)
+		}
+	}
+
+	for request := range requests {
+		if request[:4] == "HEAD" {
+			t.Errorf("Did not expect a probe when the version is known: %s", request)
+		}
+	}
+}
+
+func TestUnsupportedFeature(t *testing.T) {
+	iq, mock := capabilitiesTestIQ(t, "1.69.0-02", make(map[string]int))
+	defer mock.Close()
+
+	_, err := GlobalAuthorizations(iq)
+	if !errors.Is(err, nexus.ErrUnsupported) {
+		t.Fatalf("Expected unsupported error but got: %v", err)
+	}
+
+	var unsupported *nexus.UnsupportedError
+	if !errors.As(err, &unsupported) || unsupported.Feature.Name != FeatureRoleMemberships.Name {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
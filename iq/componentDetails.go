@@ -2,8 +2,11 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restComponentDetails = "api/v2/components/details"
@@ -42,17 +45,22 @@ type ComponentDetail struct {
 	} `json:"securityData"`
 }
 
-// GetComponent returns information on a named component
-func GetComponent(iq IQ, component Component) (ComponentDetail, error) {
-	deets, err := GetComponents(iq, []Component{component})
+// GetComponentContext returns information on a named component
+func GetComponentContext(ctx context.Context, iq IQ, component Component) (ComponentDetail, error) {
+	deets, err := GetComponentsContext(ctx, iq, []Component{component})
 	if deets == nil || len(deets) == 0 {
 		return ComponentDetail{}, err
 	}
 	return deets[0], err
 }
 
-// GetComponents returns information on the named components
-func GetComponents(iq IQ, components []Component) ([]ComponentDetail, error) {
+// GetComponent returns information on a named component
+func GetComponent(iq IQ, component Component) (ComponentDetail, error) {
+	return GetComponentContext(context.Background(), iq, component)
+}
+
+// GetComponentsContext returns information on the named components
+func GetComponentsContext(ctx context.Context, iq IQ, components []Component) ([]ComponentDetail, error) {
 	reqComponents := detailsRequest{Components: make([]componentRequested, len(components))}
 	for i, c := range components {
 		reqComponents.Components[i] = componentRequestedFromComponent(c)
@@ -60,29 +68,34 @@ func GetComponents(iq IQ, components []Component) ([]ComponentDetail, error) {
 
 	req, err := json.MarshalIndent(reqComponents, "", " ")
 	if err != nil {
-		return nil, fmt.Errorf("could not generate request: %v", err)
+		return nil, fmt.Errorf("could not generate request: %w", err)
 	}
 
-	body, _, err := iq.Post(restComponentDetails, bytes.NewBuffer(req))
+	body, _, err := nexus.PostContext(ctx, iq, restComponentDetails, bytes.NewBuffer(req))
 	if err != nil {
-		return nil, fmt.Errorf("could not find component details: %v", err)
+		return nil, fmt.Errorf("could not find component details: %w", err)
 	}
 
 	var resp detailsResponse
 	if err = json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("could not process component details: %v", err)
+		return nil, fmt.Errorf("could not process component details: %w", err)
 	}
 
 	return resp.ComponentDetails, nil
 }
 
-// GetComponentsByApplication returns an array with all components along with their
-func GetComponentsByApplication(iq IQ, appPublicID string) ([]ComponentDetail, error) {
+// GetComponents returns information on the named components
+func GetComponents(iq IQ, components []Component) ([]ComponentDetail, error) {
+	return GetComponentsContext(context.Background(), iq, components)
+}
+
+// GetComponentsByApplicationContext returns an array with all components along with their
+func GetComponentsByApplicationContext(ctx context.Context, iq IQ, appPublicID string) ([]ComponentDetail, error) {
 	componentHashes := make(map[string]struct{})
 	components := make([]Component, 0)
 	stages := []Stage{StageBuild, StageStageRelease, StageRelease, StageOperate}
 	for _, stage := range stages {
-		if report, err := GetRawReportByAppID(iq, appPublicID, string(stage)); err == nil {
+		if report, err := GetRawReportByAppIDContext(ctx, iq, appPublicID, string(stage)); err == nil {
 			for _, c := range report.Components {
 				if _, ok := componentHashes[c.Hash]; !ok {
 					componentHashes[c.Hash] = struct{}{}
@@ -92,12 +105,26 @@ func GetComponentsByApplication(iq IQ, appPublicID string) ([]ComponentDetail, e
 		}
 	}
 
-	return GetComponents(iq, components)
+	return GetComponentsContext(ctx, iq, components)
 }
 
-// GetAllComponents returns an array with all components along with their
-func GetAllComponents(iq IQ) ([]ComponentDetail, error) {
-	apps, err := GetAllApplications(iq)
+// GetComponentsByApplication returns an array with all components along with their
+func GetComponentsByApplication(iq IQ, appPublicID string) ([]ComponentDetail, error) {
+	return GetComponentsByApplicationContext(context.Background(), iq, appPublicID)
+}
+
+// GetAllComponentsContext returns an array with all components along with their
+func GetAllComponentsContext(ctx context.Context, iq IQ) ([]ComponentDetail, error) {
+	apps, err := GetAllApplicationsContext(ctx, iq)
+	if err != nil {
+		return nil, err
+	}
+
+	appComponents := make([][]ComponentDetail, len(apps))
+	err = nexus.FanOut(ctx, iq, len(apps), 1, func(ctx context.Context, i int) (err error) {
+		appComponents[i], err = GetComponentsByApplicationContext(ctx, iq, apps[i].PublicID)
+		return
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -105,14 +132,8 @@ func GetAllComponents(iq IQ) ([]ComponentDetail, error) {
 	componentHashes := make(map[string]struct{})
 	components := make([]ComponentDetail, 0)
 
-	for _, app := range apps {
-		appComponents, err := GetComponentsByApplication(iq, app.PublicID)
-		// TODO: catcher
-		if err != nil {
-			return nil, err
-		}
-
-		for _, c := range appComponents {
+	for _, cs := range appComponents {
+		for _, c := range cs {
 			if _, ok := componentHashes[c.Component.Hash]; !ok {
 				componentHashes[c.Component.Hash] = struct{}{}
 				components = append(components, c)
@@ -122,3 +143,8 @@ func GetAllComponents(iq IQ) ([]ComponentDetail, error) {
 
 	return components, nil
 }
+
+// GetAllComponents returns an array with all components along with their
+func GetAllComponents(iq IQ) ([]ComponentDetail, error) {
+	return GetAllComponentsContext(context.Background(), iq)
+}
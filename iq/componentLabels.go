@@ -2,10 +2,12 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -26,44 +28,48 @@ type IqComponentLabel struct {
 	Color          string `json:"color"`
 }
 
-// ComponentLabelApply adds an existing label to a component for a given application
-func ComponentLabelApply(iq IQ, comp Component, appID, label string) error {
-	app, err := GetApplicationByPublicID(iq, appID)
+// ComponentLabelApplyContext adds an existing label to a component for a given application
+func ComponentLabelApplyContext(ctx context.Context, iq IQ, comp Component, appID, label string) error {
+	app, err := GetApplicationByPublicIDContext(ctx, iq, appID)
 	if err != nil {
-		return fmt.Errorf("could not retrieve application with ID %s: %v", appID, err)
+		return fmt.Errorf("could not retrieve application with ID %s: %w", appID, err)
 	}
 
 	endpoint := fmt.Sprintf(restLabelComponent, comp.Hash, url.PathEscape(label), app.ID)
-	_, resp, err := iq.Post(endpoint, nil)
-	if err != nil {
-		if resp == nil || resp.StatusCode != http.StatusNoContent {
-			return fmt.Errorf("could not apply label: %v", err)
-		}
+	if _, _, err := nexus.PostContext(ctx, iq, endpoint, nil); err != nil {
+		return fmt.Errorf("could not apply label: %w", err)
 	}
 
 	return nil
 }
 
-// ComponentLabelUnapply removes an existing association between a label and a component
-func ComponentLabelUnapply(iq IQ, comp Component, appID, label string) error {
-	app, err := GetApplicationByPublicID(iq, appID)
+// ComponentLabelApply adds an existing label to a component for a given application
+func ComponentLabelApply(iq IQ, comp Component, appID, label string) error {
+	return ComponentLabelApplyContext(context.Background(), iq, comp, appID, label)
+}
+
+// ComponentLabelUnapplyContext removes an existing association between a label and a component
+func ComponentLabelUnapplyContext(ctx context.Context, iq IQ, comp Component, appID, label string) error {
+	app, err := GetApplicationByPublicIDContext(ctx, iq, appID)
 	if err != nil {
-		return fmt.Errorf("could not retrieve application with ID %s: %v", appID, err)
+		return fmt.Errorf("could not retrieve application with ID %s: %w", appID, err)
 	}
 
 	endpoint := fmt.Sprintf(restLabelComponent, comp.Hash, url.PathEscape(label), app.ID)
-	resp, err := iq.Del(endpoint)
-	if err != nil {
-		if resp == nil || resp.StatusCode != http.StatusNoContent {
-			return fmt.Errorf("could not unapply label: %v", err)
-		}
+	if _, err := nexus.DelContext(ctx, iq, endpoint); err != nil {
+		return fmt.Errorf("could not unapply label: %w", err)
 	}
 
 	return nil
 }
 
-func getComponentLabels(iq IQ, endpoint string) ([]IqComponentLabel, error) {
-	body, _, err := iq.Get(endpoint)
+// ComponentLabelUnapply removes an existing association between a label and a component
+func ComponentLabelUnapply(iq IQ, comp Component, appID, label string) error {
+	return ComponentLabelUnapplyContext(context.Background(), iq, comp, appID, label)
+}
+
+func getComponentLabels(ctx context.Context, iq IQ, endpoint string) ([]IqComponentLabel, error) {
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
 	if err != nil {
 		return nil, err
 	}
@@ -77,70 +83,95 @@ func getComponentLabels(iq IQ, endpoint string) ([]IqComponentLabel, error) {
 	return labels, nil
 }
 
+// GetComponentLabelsByOrganizationContext retrieves an array of an organization's component label
+func GetComponentLabelsByOrganizationContext(ctx context.Context, iq IQ, organization string) ([]IqComponentLabel, error) {
+	endpoint := fmt.Sprintf(restLabelComponentByOrg, organization)
+	return getComponentLabels(ctx, iq, endpoint)
+}
+
 // GetComponentLabelsByOrganization retrieves an array of an organization's component label
 func GetComponentLabelsByOrganization(iq IQ, organization string) ([]IqComponentLabel, error) {
-	endpoint := fmt.Sprintf(restLabelComponentByOrg, organization)
-	return getComponentLabels(iq, endpoint)
+	return GetComponentLabelsByOrganizationContext(context.Background(), iq, organization)
+}
+
+// GetComponentLabelsByAppIDContext retrieves an array of an organization's component label
+func GetComponentLabelsByAppIDContext(ctx context.Context, iq IQ, appID string) ([]IqComponentLabel, error) {
+	endpoint := fmt.Sprintf(restLabelComponentByApp, appID)
+	return getComponentLabels(ctx, iq, endpoint)
 }
 
 // GetComponentLabelsByAppID retrieves an array of an organization's component label
 func GetComponentLabelsByAppID(iq IQ, appID string) ([]IqComponentLabel, error) {
-	endpoint := fmt.Sprintf(restLabelComponentByApp, appID)
-	return getComponentLabels(iq, endpoint)
+	return GetComponentLabelsByAppIDContext(context.Background(), iq, appID)
 }
 
-func createLabel(iq IQ, endpoint, label, description, color string) (IqComponentLabel, error) {
+func createLabel(ctx context.Context, iq IQ, endpoint, label, description, color string) (IqComponentLabel, error) {
 	var labelResponse IqComponentLabel
 	request, err := json.Marshal(IqComponentLabel{Label: label, Description: description, Color: color})
 	if err != nil {
-		return labelResponse, fmt.Errorf("could not marshal label: %v", err)
+		return labelResponse, fmt.Errorf("could not marshal label: %w", err)
 	}
 
-	body, resp, err := iq.Post(endpoint, bytes.NewBuffer(request))
-	if resp.StatusCode != http.StatusOK {
-		return labelResponse, fmt.Errorf("did not succeeed in creating label: %v", err)
+	body, _, err := nexus.PostContext(ctx, iq, endpoint, bytes.NewBuffer(request))
+	if err != nil {
+		return labelResponse, fmt.Errorf("did not succeeed in creating label: %w", err)
 	}
-	defer resp.Body.Close()
 
 	if err := json.Unmarshal(body, &labelResponse); err != nil {
-		return labelResponse, fmt.Errorf("could not read json of new label: %v", err)
+		return labelResponse, fmt.Errorf("could not read json of new label: %w", err)
 	}
 
 	return labelResponse, nil
 }
 
+// CreateComponentLabelForOrganizationContext creates a label for an organization
+func CreateComponentLabelForOrganizationContext(ctx context.Context, iq IQ, organization, label, description, color string) (IqComponentLabel, error) {
+	endpoint := fmt.Sprintf(restLabelComponentByOrg, organization)
+	return createLabel(ctx, iq, endpoint, label, description, color)
+}
+
 // CreateComponentLabelForOrganization creates a label for an organization
 func CreateComponentLabelForOrganization(iq IQ, organization, label, description, color string) (IqComponentLabel, error) {
-	endpoint := fmt.Sprintf(restLabelComponentByOrg, organization)
-	return createLabel(iq, endpoint, label, description, color)
+	return CreateComponentLabelForOrganizationContext(context.Background(), iq, organization, label, description, color)
+}
+
+// CreateComponentLabelForApplicationContext creates a label for an application
+func CreateComponentLabelForApplicationContext(ctx context.Context, iq IQ, appID, label, description, color string) (IqComponentLabel, error) {
+	endpoint := fmt.Sprintf(restLabelComponentByApp, appID)
+	return createLabel(ctx, iq, endpoint, label, description, color)
 }
 
 // CreateComponentLabelForApplication creates a label for an application
 func CreateComponentLabelForApplication(iq IQ, appID, label, description, color string) (IqComponentLabel, error) {
-	endpoint := fmt.Sprintf(restLabelComponentByApp, appID)
-	return createLabel(iq, endpoint, label, description, color)
+	return CreateComponentLabelForApplicationContext(context.Background(), iq, appID, label, description, color)
 }
 
-// DeleteComponentLabelForOrganization deletes a label from an organization
-func DeleteComponentLabelForOrganization(iq IQ, organization, label string) error {
+// DeleteComponentLabelForOrganizationContext deletes a label from an organization
+func DeleteComponentLabelForOrganizationContext(ctx context.Context, iq IQ, organization, label string) error {
 	endpoint := fmt.Sprintf(restLabelComponentByOrgDel, organization, label)
-	resp, err := iq.Del(endpoint)
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("did not succeeed in deleting label: %v", err)
+	if _, err := nexus.DelContext(ctx, iq, endpoint); err != nil {
+		return fmt.Errorf("did not succeeed in deleting label: %w", err)
 	}
-	defer resp.Body.Close()
 
 	return nil
 }
 
-// DeleteComponentLabelForApplication deletes a label from an application
-func DeleteComponentLabelForApplication(iq IQ, appID, label string) error {
+// DeleteComponentLabelForOrganization deletes a label from an organization
+func DeleteComponentLabelForOrganization(iq IQ, organization, label string) error {
+	return DeleteComponentLabelForOrganizationContext(context.Background(), iq, organization, label)
+}
+
+// DeleteComponentLabelForApplicationContext deletes a label from an application
+func DeleteComponentLabelForApplicationContext(ctx context.Context, iq IQ, appID, label string) error {
 	endpoint := fmt.Sprintf(restLabelComponentByAppDel, appID, label)
-	resp, err := iq.Del(endpoint)
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("did not succeeed in deleting label: %v", err)
+	if _, err := nexus.DelContext(ctx, iq, endpoint); err != nil {
+		return fmt.Errorf("did not succeeed in deleting label: %w", err)
 	}
-	defer resp.Body.Close()
 
 	return nil
 }
+
+// DeleteComponentLabelForApplication deletes a label from an application
+func DeleteComponentLabelForApplication(iq IQ, appID, label string) error {
+	return DeleteComponentLabelForApplicationContext(context.Background(), iq, appID, label)
+}
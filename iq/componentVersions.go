@@ -2,27 +2,35 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restComponentVersions = "api/v2/components/versions"
 
-// ComponentVersions returns all known versions of a given component
-func ComponentVersions(iq IQ, comp Component) (versions []string, err error) {
+// ComponentVersionsContext returns all known versions of a given component
+func ComponentVersionsContext(ctx context.Context, iq IQ, comp Component) (versions []string, err error) {
 	str, err := json.Marshal(comp)
 	if err != nil {
-		return nil, fmt.Errorf("could not process component: %v", err)
+		return nil, fmt.Errorf("could not process component: %w", err)
 	}
 
-	body, _, err := iq.Post(restComponentVersions, bytes.NewBuffer(str))
+	body, _, err := nexus.PostContext(ctx, iq, restComponentVersions, bytes.NewBuffer(str))
 	if err != nil {
-		return nil, fmt.Errorf("could not request component: %v", err)
+		return nil, fmt.Errorf("could not request component: %w", err)
 	}
 
 	if err := json.Unmarshal(body, &versions); err != nil {
-		return nil, fmt.Errorf("could not process versions list: %v", err)
+		return nil, fmt.Errorf("could not process versions list: %w", err)
 	}
 
 	return
 }
+
+// ComponentVersions returns all known versions of a given component
+func ComponentVersions(iq IQ, comp Component) (versions []string, err error) {
+	return ComponentVersionsContext(context.Background(), iq, comp)
+}
@@ -2,9 +2,11 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"sync"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -60,100 +62,105 @@ func createRemediationEndpoint(base, id, stage string) string {
 	return buf.String()
 }
 
-func getRemediation(iq IQ, component Component, endpoint string) (Remediation, error) {
+func getRemediation(ctx context.Context, iq IQ, component Component, endpoint string) (Remediation, error) {
 	request, err := json.Marshal(component)
 	if err != nil {
-		return Remediation{}, fmt.Errorf("could not build the request: %v", err)
+		return Remediation{}, fmt.Errorf("could not build the request: %w", err)
 	}
 
-	body, _, err := iq.Post(endpoint, bytes.NewBuffer(request))
+	body, _, err := nexus.PostContext(ctx, iq, endpoint, bytes.NewBuffer(request))
 	if err != nil {
-		return Remediation{}, fmt.Errorf("could not get remediation: %v", err)
+		return Remediation{}, fmt.Errorf("could not get remediation: %w", err)
 	}
 
 	var results remediationResponse
 	if err = json.Unmarshal(body, &results); err != nil {
-		return Remediation{}, fmt.Errorf("could not parse remediation response: %v", err)
+		return Remediation{}, fmt.Errorf("could not parse remediation response: %w", err)
 	}
 
 	results.Remediation.Component = component
 	return results.Remediation, nil
 }
 
-func getRemediationByAppInternalID(iq IQ, component Component, stage, appInternalID string) (Remediation, error) {
-	return getRemediation(iq, component, createRemediationEndpoint(restRemediationByApp, appInternalID, stage))
+func getRemediationByAppInternalID(ctx context.Context, iq IQ, component Component, stage, appInternalID string) (Remediation, error) {
+	return getRemediation(ctx, iq, component, createRemediationEndpoint(restRemediationByApp, appInternalID, stage))
 }
 
-// GetRemediationByApp retrieves the remediation information on a component based on an application's policies
-func GetRemediationByApp(iq IQ, component Component, stage, applicationID string) (Remediation, error) {
-	app, err := GetApplicationByPublicID(iq, applicationID)
+// GetRemediationByAppContext retrieves the remediation information on a component based on an application's policies
+func GetRemediationByAppContext(ctx context.Context, iq IQ, component Component, stage, applicationID string) (Remediation, error) {
+	app, err := GetApplicationByPublicIDContext(ctx, iq, applicationID)
 	if err != nil {
-		return Remediation{}, fmt.Errorf("could not get application: %v", err)
+		return Remediation{}, fmt.Errorf("could not get application: %w", err)
 	}
 
-	return getRemediationByAppInternalID(iq, component, stage, app.ID)
+	return getRemediationByAppInternalID(ctx, iq, component, stage, app.ID)
 }
 
-// GetRemediationByOrg retrieves the remediation information on a component based on an organization's policies
-func GetRemediationByOrg(iq IQ, component Component, stage, organizationName string) (Remediation, error) {
-	org, err := GetOrganizationByName(iq, organizationName)
+// GetRemediationByApp retrieves the remediation information on a component based on an application's policies
+func GetRemediationByApp(iq IQ, component Component, stage, applicationID string) (Remediation, error) {
+	return GetRemediationByAppContext(context.Background(), iq, component, stage, applicationID)
+}
+
+// GetRemediationByOrgContext retrieves the remediation information on a component based on an organization's policies
+func GetRemediationByOrgContext(ctx context.Context, iq IQ, component Component, stage, organizationName string) (Remediation, error) {
+	org, err := GetOrganizationByNameContext(ctx, iq, organizationName)
 	if err != nil {
-		return Remediation{}, fmt.Errorf("could not get organization: %v", err)
+		return Remediation{}, fmt.Errorf("could not get organization: %w", err)
 	}
 
 	endpoint := createRemediationEndpoint(restRemediationByOrg, org.ID, stage)
 
-	return getRemediation(iq, component, endpoint)
+	return getRemediation(ctx, iq, component, endpoint)
 }
 
-// GetRemediationsByAppReport retrieves the remediation information on each component of a report
-func GetRemediationsByAppReport(iq IQ, applicationID, reportID string) (remediations []Remediation, err error) {
-	report, err := getRawReportByAppReportID(iq, applicationID, reportID)
-	if err != nil {
-		return nil, fmt.Errorf("could not get report %s for app %s: %v", reportID, applicationID, err)
-	}
+// GetRemediationByOrg retrieves the remediation information on a component based on an organization's policies
+func GetRemediationByOrg(iq IQ, component Component, stage, organizationName string) (Remediation, error) {
+	return GetRemediationByOrgContext(context.Background(), iq, component, stage, organizationName)
+}
 
-	app, err := GetApplicationByPublicID(iq, applicationID)
+// GetRemediationsByAppReportContext retrieves the remediation information on each component of a report
+func GetRemediationsByAppReportContext(ctx context.Context, iq IQ, applicationID, reportID string) (remediations []Remediation, err error) {
+	report, err := getRawReportByAppReportID(ctx, iq, applicationID, reportID)
 	if err != nil {
-		return nil, fmt.Errorf("could not get application: %v", err)
+		return nil, fmt.Errorf("could not get report %s for app %s: %w", reportID, applicationID, err)
 	}
 
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-	components := make(chan Component, 20)
-	for w := 1; w <= 20; w++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for c := range components {
-				purl := Component{
-					Hash:       c.Hash,
-					PackageURL: c.PackageURL,
-				}
-				var remediation Remediation
-				remediation, err = getRemediationByAppInternalID(iq, purl, report.ReportInfo.Stage, app.ID)
-				if err != nil {
-					err = fmt.Errorf("did not find remediation for '%v': %v", c, err)
-					break
-				}
-				if len(remediation.VersionChanges) > 0 {
-					mu.Lock()
-					remediations = append(remediations, remediation)
-					mu.Unlock()
-				}
-			}
-		}()
+	app, err := GetApplicationByPublicIDContext(ctx, iq, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get application: %w", err)
 	}
 
-	for _, c := range report.Components {
+	results := make([]Remediation, len(report.Components))
+	err = nexus.FanOut(ctx, iq, len(report.Components), nexus.DefaultFanOut, func(ctx context.Context, i int) error {
+		c := report.Components[i].Component
+		purl := Component{
+			Hash:       c.Hash,
+			PackageURL: c.PackageURL,
+		}
+		remediation, err := getRemediationByAppInternalID(ctx, iq, purl, report.ReportInfo.Stage, app.ID)
 		if err != nil {
-			return
+			return fmt.Errorf("did not find remediation for '%v': %w", c, err)
 		}
-		components <- c.Component
+		results[i] = remediation
+		return nil
+	})
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("stopped retrieving remediations: %w", err)
+	}
+	if err != nil {
+		return nil, err
 	}
-	close(components)
 
-	wg.Wait()
+	for _, remediation := range results {
+		if len(remediation.VersionChanges) > 0 {
+			remediations = append(remediations, remediation)
+		}
+	}
 
 	return
 }
+
+// GetRemediationsByAppReport retrieves the remediation information on each component of a report
+func GetRemediationsByAppReport(iq IQ, applicationID, reportID string) (remediations []Remediation, err error) {
+	return GetRemediationsByAppReportContext(context.Background(), iq, applicationID, reportID)
+}
@@ -2,8 +2,11 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restDataRetentionPolicies = "api/v2/dataRetentionPolicies/organizations/%s"
@@ -26,18 +29,18 @@ type DataRetentionPolicy struct {
 	MaxAge        string `json:"maxAge"`
 }
 
-// GetRetentionPolicies returns the current retention policies
-func GetRetentionPolicies(iq IQ, orgName string) (policies DataRetentionPolicies, err error) {
-	org, err := GetOrganizationByName(iq, orgName)
+// GetRetentionPoliciesContext returns the current retention policies
+func GetRetentionPoliciesContext(ctx context.Context, iq IQ, orgName string) (policies DataRetentionPolicies, err error) {
+	org, err := GetOrganizationByNameContext(ctx, iq, orgName)
 	if err != nil {
-		return policies, fmt.Errorf("could not retrieve organization named %s: %v", orgName, err)
+		return policies, fmt.Errorf("could not retrieve organization named %s: %w", orgName, err)
 	}
 
 	endpoint := fmt.Sprintf(restDataRetentionPolicies, org.ID)
 
-	body, _, err := iq.Get(endpoint)
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
 	if err != nil {
-		return policies, fmt.Errorf("did not retrieve retention policies for organization %s: %v", orgName, err)
+		return policies, fmt.Errorf("did not retrieve retention policies for organization %s: %w", orgName, err)
 	}
 
 	err = json.Unmarshal(body, &policies)
@@ -45,24 +48,34 @@ func GetRetentionPolicies(iq IQ, orgName string) (policies DataRetentionPolicies
 	return
 }
 
-// SetRetentionPolicies updates the retention policies
-func SetRetentionPolicies(iq IQ, orgName string, policies DataRetentionPolicies) error {
-	org, err := GetOrganizationByName(iq, orgName)
+// GetRetentionPolicies returns the current retention policies
+func GetRetentionPolicies(iq IQ, orgName string) (policies DataRetentionPolicies, err error) {
+	return GetRetentionPoliciesContext(context.Background(), iq, orgName)
+}
+
+// SetRetentionPoliciesContext updates the retention policies
+func SetRetentionPoliciesContext(ctx context.Context, iq IQ, orgName string, policies DataRetentionPolicies) error {
+	org, err := GetOrganizationByNameContext(ctx, iq, orgName)
 	if err != nil {
-		return fmt.Errorf("could not retrieve organization named %s: %v", orgName, err)
+		return fmt.Errorf("could not retrieve organization named %s: %w", orgName, err)
 	}
 
 	request, err := json.Marshal(policies)
 	if err != nil {
-		return fmt.Errorf("could not parse policies: %v", err)
+		return fmt.Errorf("could not parse policies: %w", err)
 	}
 
 	endpoint := fmt.Sprintf(restDataRetentionPolicies, org.ID)
 
-	_, _, err = iq.Put(endpoint, bytes.NewBuffer(request))
+	_, _, err = nexus.PutContext(ctx, iq, endpoint, bytes.NewBuffer(request))
 	if err != nil {
-		return fmt.Errorf("did not set retention policies for organization %s: %v", orgName, err)
+		return fmt.Errorf("did not set retention policies for organization %s: %w", orgName, err)
 	}
 
 	return nil
 }
+
+// SetRetentionPolicies updates the retention policies
+func SetRetentionPolicies(iq IQ, orgName string, policies DataRetentionPolicies) error {
+	return SetRetentionPoliciesContext(context.Background(), iq, orgName, policies)
+}
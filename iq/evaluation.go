@@ -2,15 +2,22 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
-const restEvaluation = "api/v2/evaluation/applications/%s"
+const (
+	restEvaluation = "api/v2/evaluation/applications/%s"
+	// restEvaluationResults is the path of the resultsUrl returned by the server when an evaluation is requested
+	restEvaluationResults = "api/v2/evaluation/applications/%s/results/%s"
+)
 
 // Coordinates lists the unique values identifing a component
 type Coordinates struct {
@@ -230,29 +237,29 @@ type iqEvaluationRequest struct {
 	Components []Component `json:"components"`
 }
 
-// EvaluateComponents evaluates the list of components
-func EvaluateComponents(iq IQ, components []Component, applicationID string) (*Evaluation, error) {
+// EvaluateComponentsContext evaluates the list of components
+func EvaluateComponentsContext(ctx context.Context, iq IQ, components []Component, applicationID string) (*Evaluation, error) {
 	request, err := json.Marshal(iqEvaluationRequest{Components: components})
 	if err != nil {
-		return nil, fmt.Errorf("could not build the request: %v", err)
+		return nil, fmt.Errorf("could not build the request: %w", err)
 	}
 
 	requestEndpoint := fmt.Sprintf(restEvaluation, applicationID)
-	body, _, err := iq.Post(requestEndpoint, bytes.NewBuffer(request))
+	body, _, err := nexus.PostContext(ctx, iq, requestEndpoint, bytes.NewBuffer(request))
 	if err != nil {
-		return nil, fmt.Errorf("components not evaluated: %v", err)
+		return nil, fmt.Errorf("components not evaluated: %w", err)
 	}
 
 	var results iqEvaluationRequestResponse
 	if err = json.Unmarshal(body, &results); err != nil {
-		return nil, fmt.Errorf("could not parse evaluation response: %v", err)
+		return nil, fmt.Errorf("could not parse evaluation response: %w", err)
 	}
 
 	getEvaluationResults := func() (*Evaluation, error) {
-		body, resp, e := iq.Get(results.ResultsURL)
+		body, resp, e := nexus.GetContext(ctx, iq, results.ResultsURL)
 		if e != nil {
-			if resp.StatusCode != http.StatusNotFound {
-				return nil, fmt.Errorf("could not retrieve evaluation results: %v", err)
+			if resp == nil || resp.StatusCode != http.StatusNotFound {
+				return nil, fmt.Errorf("could not retrieve evaluation results: %w", e)
 			}
 			return nil, nil
 		}
@@ -267,16 +274,23 @@ func EvaluateComponents(iq IQ, components []Component, applicationID string) (*E
 
 	var eval *Evaluation
 	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	timeout := time.After(5 * time.Minute)
 	for {
 		select {
 		case <-ticker.C:
 			if eval, err = getEvaluationResults(); eval != nil || err != nil {
-				ticker.Stop()
 				return eval, err
 			}
-		case <-time.After(5 * time.Minute):
-			ticker.Stop()
+		case <-timeout:
 			return nil, errors.New("timed out waiting for valid evaluation results")
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for evaluation results: %w", ctx.Err())
 		}
 	}
 }
+
+// EvaluateComponents evaluates the list of components
+func EvaluateComponents(iq IQ, components []Component, applicationID string) (*Evaluation, error) {
+	return EvaluateComponentsContext(context.Background(), iq, components, applicationID)
+}
@@ -1,6 +1,7 @@
 package nexusiq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,6 +10,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -18,8 +20,6 @@ var (
 	activeEvalResult      Evaluation
 )
 
-const restEvaluationResults = "api/v2/evaluation/applications/%s/results/%s"
-
 var dummyComponent = Component{
 	Hash: "045c37a03be19f3e0db8",
 	ComponentID: &ComponentIdentifier{
@@ -117,3 +117,20 @@ func TestEvaluateComponents(t *testing.T) {
 		t.Error("Did not find the expected Component in evaluation results")
 	}
 }
+
+func TestEvaluateComponentsContextCanceled(t *testing.T) {
+	iq, mock := evaluationTestIQ(t)
+	defer mock.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := EvaluateComponentsContext(ctx, iq, []Component{dummyComponent}, "dummyAppId"); err == nil {
+		t.Error("Expected an error when the context is done before results are available")
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Evaluation kept polling for %v after the context was done", elapsed)
+	}
+}
@@ -1,6 +1,9 @@
 package nexusiq
 
 import (
+	"fmt"
+	"time"
+
 	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
@@ -9,15 +12,80 @@ type IQ interface {
 	nexus.Client
 }
 
+// endpoints are the templates of the endpoints used by the helpers, which name requests in the telemetry
+var endpoints = []string{
+	restApplication, restApplicationByPublic, restComponentDetails, restComponentVersions,
+	restDataRetentionPolicies, restEvaluation, restEvaluationResults, restLabelComponent,
+	restLabelComponentByApp, restLabelComponentByAppDel, restLabelComponentByOrg, restLabelComponentByOrgDel,
+	restMetrics, restOrganization, restPolicies, restPolicyViolations, restProductVersion, restRemediationByApp,
+	restRemediationByOrg, restReports, restReportsPolicy, restReportsRaw, restRoleMembersAppDeprecated,
+	restRoleMembersAppGet, restRoleMembersAppGroup, restRoleMembersAppUser, restRoleMembersGlobalGet,
+	restRoleMembersGlobalGroup, restRoleMembersGlobalUser, restRoleMembersOrgDeprecated, restRoleMembersOrgGet,
+	restRoleMembersOrgGroup, restRoleMembersOrgUser, restRoleMembersReposGet, restRoleMembersRepositoryGroup,
+	restRoleMembersRepositoryUser, restRoles, restRolesDeprecated, restSearchComponent, restSourceControl,
+	restSourceControlDelete, restUsers, restUsersPost,
+}
+
 type iqClient struct {
 	nexus.DefaultClient
+	capabilities *nexus.Capabilities
+}
+
+// New creates a new IQ instance, configured by the options
+func New(host, username, password string, opts ...nexus.Option) (IQ, error) {
+	return newClient(host, username, password, opts), nil
 }
 
-// New creates a new IQ instance
-func New(host, username, password string) (IQ, error) {
+func newClient(host, username, password string, opts []nexus.Option) *iqClient {
 	iq := new(iqClient)
-	iq.Host = host
-	iq.Username = username
-	iq.Password = password
-	return iq, nil
+	iq.ServerInfo = nexus.ServerInfo{Host: host, Username: username, Password: password}
+	for _, opt := range append([]nexus.Option{nexus.WithEndpoints(endpoints...)}, opts...) {
+		opt(&iq.DefaultClient)
+	}
+	iq.capabilities = nexus.NewCapabilities(iq, detectVersion)
+	return iq
+}
+
+// NewFromConfig creates a new IQ instance with the authentication, certificates, timeout and retry policy of the configuration.
+// The options are applied over the configuration
+func NewFromConfig(cfg nexus.ServerConfig, opts ...nexus.Option) (IQ, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("could not create IQ Server client: %w", nexus.ErrNoHost)
+	}
+
+	cfgOpts, err := cfg.Options()
+	if err != nil {
+		return nil, fmt.Errorf("could not configure IQ Server client: %w", err)
+	}
+
+	return newClient(cfg.Host, cfg.Auth.Username, cfg.Auth.Password, append(cfgOpts, opts...)), nil
+}
+
+// NewFromProfile creates a new IQ instance from the IQ section of the named profile of the configuration file at path.
+// See nexus.LoadProfile for how the profile is selected and overridden by the environment
+func NewFromProfile(path, profile string, opts ...nexus.Option) (IQ, error) {
+	p, err := nexus.LoadProfile(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConfig(p.IQ, opts...)
+}
+
+// DefaultCachePolicy caches the lists of applications, organizations, policies and roles, which most helpers
+// look up by name or identifier
+func DefaultCachePolicy() nexus.CachePolicy {
+	return nexus.CachePolicy{
+		Classes: []nexus.CacheClass{
+			{Name: "applications", Endpoints: []string{restApplication}, TTL: 5 * time.Minute},
+			{Name: "organizations", Endpoints: []string{restOrganization}, TTL: 5 * time.Minute},
+			{Name: "policies", Endpoints: []string{restPolicies}, TTL: 15 * time.Minute},
+			{Name: "roles", Endpoints: []string{restRoles, restRolesDeprecated}, TTL: 15 * time.Minute},
+		},
+	}
+}
+
+// ReadOnlyEndpoints are the endpoints which are requested with POST but do not change the server, those of
+// the metrics and of component evaluations, so that a nexus.DryRun created with them still sends them
+func ReadOnlyEndpoints() []string {
+	return []string{restEvaluation, restMetrics}
 }
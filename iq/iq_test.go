@@ -1,13 +1,18 @@
 package nexusiq
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
+	"os"
 	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
-func newTestIQ(t *testing.T, handler func(t *testing.T, w http.ResponseWriter, r *http.Request)) (iq IQ, mock *httptest.Server) {
+func newTestIQ(t *testing.T, handler func(t *testing.T, w http.ResponseWriter, r *http.Request), opts ...nexus.Option) (iq IQ, mock *httptest.Server) {
 	mock = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		dump, _ := httputil.DumpRequest(r, true)
 		t.Logf("%q\n", dump)
@@ -15,10 +20,133 @@ func newTestIQ(t *testing.T, handler func(t *testing.T, w http.ResponseWriter, r
 		handler(t, w, r)
 	}))
 
-	iq, err := New(mock.URL, "dummy_user", "dummy_pass")
+	iq, err := New(mock.URL, "dummy_user", "dummy_pass", opts...)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	return
 }
+
+func mutatingHelpersIQ() map[string]func(iq IQ) error {
+	return map[string]func(iq IQ) error{
+		"DeleteApplication":                   func(iq IQ) error { return DeleteApplication(iq, "app") },
+		"SetUser":                             func(iq IQ) error { return SetUser(iq, User{Username: "user"}) },
+		"DeleteUser":                          func(iq IQ) error { return DeleteUser(iq, "user") },
+		"DeleteComponentLabelForOrganization": func(iq IQ) error { return DeleteComponentLabelForOrganization(iq, "org", "label") },
+		"DeleteComponentLabelForApplication":  func(iq IQ) error { return DeleteComponentLabelForApplication(iq, "app", "label") },
+	}
+}
+
+func TestMutatingHelpersNetworkFailure(t *testing.T) {
+	iq, mock := newTestIQ(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {})
+	mock.Close()
+
+	for name, helper := range mutatingHelpersIQ() {
+		if err := helper(iq); err == nil {
+			t.Errorf("%s: expected an error when the server is unreachable", name)
+		}
+	}
+}
+
+func TestMutatingHelpersStatuses(t *testing.T) {
+	tests := []struct {
+		status  int
+		success bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusCreated, true},
+		{http.StatusNoContent, true},
+		{http.StatusBadRequest, false},
+		{http.StatusForbidden, false},
+		{http.StatusInternalServerError, false},
+	}
+
+	for _, test := range tests {
+		iq, mock := newTestIQ(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				fmt.Fprint(w, "{}")
+				return
+			}
+			w.WriteHeader(test.status)
+			if test.status != http.StatusNoContent {
+				fmt.Fprint(w, "{}")
+			}
+		})
+
+		for name, helper := range mutatingHelpersIQ() {
+			if err := helper(iq); (err == nil) != test.success {
+				t.Errorf("%s with status %d: unexpected result: %v", name, test.status, err)
+			}
+		}
+
+		mock.Close()
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer mock.Close()
+
+	iq, err := NewFromConfig(nexus.ServerConfig{
+		Host: mock.URL,
+		Auth: nexus.AuthConfig{Method: nexus.AuthBearer, Token: "abc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = iq.Get("test"); err != nil {
+		t.Errorf("Expected the configured authentication to be used: %v", err)
+	}
+
+	if _, err = NewFromConfig(nexus.ServerConfig{}); !errors.Is(err, nexus.ErrNoHost) {
+		t.Errorf("Expected ErrNoHost but got %v", err)
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer override" || r.Header.Get("User-Agent") != "tests" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer mock.Close()
+
+	iq, err := New(mock.URL, "user", "pass", nexus.WithAuthenticator(nexus.BearerAuth{Token: "override"}), nexus.WithUserAgent("tests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = iq.Get("test"); err != nil {
+		t.Errorf("Expected the options to be used: %v", err)
+	}
+
+	iq, err = NewFromConfig(nexus.ServerConfig{
+		Host: mock.URL,
+		Auth: nexus.AuthConfig{Method: nexus.AuthBearer, Token: "abc"},
+	}, nexus.WithAuthenticator(nexus.BearerAuth{Token: "override"}), nexus.WithUserAgent("tests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = iq.Get("test"); err != nil {
+		t.Errorf("Expected the options to be applied over the configuration: %v", err)
+	}
+}
+
+func TestNewFromProfile(t *testing.T) {
+	os.Setenv("GONEXUS_IQ_HOST", "http://localhost:1234")
+	defer os.Unsetenv("GONEXUS_IQ_HOST")
+
+	iq, err := NewFromProfile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if host := iq.Info().Host; host != "http://localhost:1234" {
+		t.Errorf("Expected the host from the environment but got %q", host)
+	}
+}
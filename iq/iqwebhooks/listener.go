@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 )
 
@@ -24,7 +23,6 @@ func parseRequest(r *http.Request) (whtype WebhookEventType, err error) {
 	switch whtype {
 	case WebhookEventApplicationEvaluation:
 		var event WebhookApplicationEvaluation
-		log.Println("app eval", string(body))
 		if err = json.Unmarshal(body, &event); err == nil {
 			sendApplicationEvaluationEvent(event)
 		}
@@ -0,0 +1,188 @@
+package nexusiqtest
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Organizations returns the organizations of the fake
+func (s *Server) Organizations() []Organization {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Organization(nil), s.organizations...)
+}
+
+func (s *Server) addOrganization(org Organization) Organization {
+	if org.ID == "" {
+		org.ID = s.newID("organization")
+	}
+	if i := s.organizationIndex(org.ID); i >= 0 {
+		s.organizations[i] = org
+	} else {
+		s.organizations = append(s.organizations, org)
+	}
+	return org
+}
+
+func (s *Server) organizationIndex(id string) int {
+	for i, org := range s.organizations {
+		if org.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Applications returns the applications of the fake
+func (s *Server) Applications() []Application {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Application(nil), s.applications...)
+}
+
+func (s *Server) addApplication(app Application) Application {
+	if app.ID == "" {
+		app.ID = s.newID("application")
+	}
+	if app.OrganizationID == "" {
+		app.OrganizationID = RootOrganizationID
+	}
+	if i := s.applicationIndex(app.ID); i >= 0 {
+		s.applications[i] = app
+	} else {
+		s.applications = append(s.applications, app)
+	}
+	return app
+}
+
+func (s *Server) applicationIndex(id string) int {
+	for i, app := range s.applications {
+		if app.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) applicationByPublicID(publicID string) (Application, bool) {
+	for _, app := range s.applications {
+		if app.PublicID == publicID {
+			return app, true
+		}
+	}
+	return Application{}, false
+}
+
+func (s *Server) serveApplications(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		apps := []Application{}
+		if publicID := r.URL.Query().Get("publicId"); publicID != "" {
+			if app, ok := s.applicationByPublicID(publicID); ok {
+				apps = append(apps, app)
+			}
+		} else {
+			apps = append(apps, s.applications...)
+		}
+		writeJSON(w, http.StatusOK, map[string][]Application{"applications": apps})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var app Application
+		if !decode(w, r, &app) {
+			return
+		}
+		if app.PublicID == "" || app.Name == "" {
+			writeError(w, http.StatusBadRequest, "application public ID and name are required")
+			return
+		}
+		if _, exists := s.applicationByPublicID(app.PublicID); exists {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("application with public ID %s already exists", app.PublicID))
+			return
+		}
+		if s.organizationIndex(app.OrganizationID) < 0 {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("organization %s not found", app.OrganizationID))
+			return
+		}
+		app.ID = ""
+		writeJSON(w, http.StatusOK, s.addApplication(app))
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		i := s.applicationIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "application not found")
+			return
+		}
+		s.applications = append(s.applications[:i], s.applications[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 4 && path[1] == "reports" && r.Method == http.MethodGet:
+		s.serveReportData(w, r, path[0], path[2], path[3])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) serveOrganizations(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		orgs := append([]Organization{}, s.organizations...)
+		writeJSON(w, http.StatusOK, map[string][]Organization{"organizations": orgs})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var org Organization
+		if !decode(w, r, &org) {
+			return
+		}
+		if org.Name == "" {
+			writeError(w, http.StatusBadRequest, "organization name is required")
+			return
+		}
+		for _, o := range s.organizations {
+			if o.Name == org.Name {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("organization %s already exists", org.Name))
+				return
+			}
+		}
+		writeJSON(w, http.StatusOK, s.addOrganization(Organization{Name: org.Name, Tags: org.Tags}))
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) servePolicies(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 0 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]Policy{"policies": append([]Policy{}, s.policies...)})
+}
+
+// servePolicyViolations returns the violations of the policies given with the p parameter, grouped by application
+func (s *Server) servePolicyViolations(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 0 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	policies := make(map[string]bool)
+	for _, p := range r.URL.Query()["p"] {
+		policies[p] = true
+	}
+
+	resp := struct {
+		ApplicationViolations []applicationViolation `json:"applicationViolations"`
+	}{[]applicationViolation{}}
+
+	for _, app := range s.applications {
+		var violations []PolicyViolation
+		for _, v := range s.violations {
+			if v.ApplicationID == app.ID && policies[v.PolicyID] {
+				violations = append(violations, v)
+			}
+		}
+		if len(violations) > 0 {
+			resp.ApplicationViolations = append(resp.ApplicationViolations, applicationViolation{app, violations})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
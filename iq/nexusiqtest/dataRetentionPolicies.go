@@ -0,0 +1,61 @@
+package nexusiqtest
+
+import (
+	"net/http"
+)
+
+func defaultDataRetentionPolicies() DataRetentionPolicies {
+	var p DataRetentionPolicies
+	p.ApplicationReports.Stages = map[string]DataRetentionPolicy{
+		"develop":               {InheritPolicy: true},
+		"build":                 {InheritPolicy: true},
+		"stage-release":         {InheritPolicy: true},
+		"release":               {InheritPolicy: true},
+		"operate":               {InheritPolicy: true},
+		"continuous-monitoring": {InheritPolicy: true},
+	}
+	p.SuccessMetrics = DataRetentionPolicy{InheritPolicy: true}
+	return p
+}
+
+// DataRetentionPolicies returns the data retention policies of the given organization
+func (s *Server) DataRetentionPolicies(orgID string) DataRetentionPolicies {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.dataRetentionPolicies[orgID]; ok {
+		return p
+	}
+	return defaultDataRetentionPolicies()
+}
+
+func (s *Server) serveDataRetentionPolicies(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 2 || path[0] != "organizations" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	orgID := path[1]
+	if s.organizationIndex(orgID) < 0 {
+		writeError(w, http.StatusNotFound, "organization not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		p, ok := s.dataRetentionPolicies[orgID]
+		if !ok {
+			p = defaultDataRetentionPolicies()
+		}
+		writeJSON(w, http.StatusOK, p)
+	case http.MethodPut:
+		var p DataRetentionPolicies
+		if !decode(w, r, &p) {
+			return
+		}
+		s.dataRetentionPolicies[orgID] = p
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
@@ -0,0 +1,77 @@
+package nexusiqtest
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type evaluationRequest struct {
+	Components []Component `json:"components"`
+}
+
+type evaluationRequestResponse struct {
+	ResultID      string `json:"resultId"`
+	SubmittedDate string `json:"submittedDate"`
+	ApplicationID string `json:"applicationId"`
+	ResultsURL    string `json:"resultsUrl"`
+}
+
+func (s *Server) evaluate(c Component) EvaluationResult {
+	if s.Evaluator != nil {
+		return s.Evaluator(c)
+	}
+	return EvaluationResult{Component: c, MatchState: "unknown"}
+}
+
+// serveEvaluation accepts component evaluation requests and serves their results
+// once they have been polled EvaluationPolls times
+func (s *Server) serveEvaluation(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 2 && path[0] == "applications" && r.Method == http.MethodPost:
+		if s.applicationIndex(path[1]) < 0 {
+			writeError(w, http.StatusNotFound, "application not found")
+			return
+		}
+
+		var req evaluationRequest
+		if !decode(w, r, &req) {
+			return
+		}
+
+		ev := &evaluation{Evaluation: Evaluation{
+			SubmittedDate:  now(),
+			EvaluationDate: now(),
+			ApplicationID:  path[1],
+			Results:        make([]EvaluationResult, len(req.Components)),
+		}}
+		for i, c := range req.Components {
+			ev.Results[i] = s.evaluate(c)
+		}
+
+		resultID := s.newID("result")
+		s.evaluations[resultID] = ev
+
+		writeJSON(w, http.StatusOK, evaluationRequestResponse{
+			ResultID:      resultID,
+			SubmittedDate: ev.SubmittedDate,
+			ApplicationID: path[1],
+			ResultsURL:    fmt.Sprintf("api/v2/evaluation/applications/%s/results/%s", path[1], resultID),
+		})
+	case len(path) == 4 && path[0] == "applications" && path[2] == "results" && r.Method == http.MethodGet:
+		ev, ok := s.evaluations[path[3]]
+		if !ok || ev.ApplicationID != path[1] {
+			writeError(w, http.StatusNotFound, "evaluation not found")
+			return
+		}
+
+		if ev.polls < s.EvaluationPolls {
+			ev.polls++
+			writeError(w, http.StatusNotFound, "evaluation results not yet available")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, ev.Evaluation)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
@@ -0,0 +1,153 @@
+package nexusiqtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Labels returns the component labels of the fake
+func (s *Server) Labels() []ComponentLabel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ComponentLabel(nil), s.labels...)
+}
+
+// AppliedLabels returns the labels which are applied to components
+func (s *Server) AppliedLabels() []AppliedLabel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]AppliedLabel(nil), s.appliedLabels...)
+}
+
+func (s *Server) addLabel(l ComponentLabel) ComponentLabel {
+	if l.ID == "" {
+		l.ID = s.newID("label")
+	}
+	l.LabelLowercase = strings.ToLower(l.Label)
+	s.labels = append(s.labels, l)
+	return l
+}
+
+// labelIndex finds the label of the owner by ID or by name
+func (s *Server) labelIndex(ownerID, label string) int {
+	for i, l := range s.labels {
+		if l.OwnerID == ownerID && (l.ID == label || l.LabelLowercase == strings.ToLower(label)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// labelOwner resolves the owner of labels from the path; applications can be given by internal or public ID
+func (s *Server) labelOwner(ownerType, id string) (string, bool) {
+	switch ownerType {
+	case OwnerTypeOrganization:
+		return id, s.organizationIndex(id) >= 0
+	case OwnerTypeApplication:
+		if s.applicationIndex(id) >= 0 {
+			return id, true
+		}
+		app, ok := s.applicationByPublicID(id)
+		return app.ID, ok
+	default:
+		return "", false
+	}
+}
+
+func (s *Server) serveLabels(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) < 2 || len(path) > 3 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	ownerID, ok := s.labelOwner(path[0], path[1])
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", path[0], path[1]))
+		return
+	}
+
+	switch {
+	case len(path) == 2 && r.Method == http.MethodGet:
+		labels := []ComponentLabel{}
+		for _, l := range s.labels {
+			if l.OwnerID == ownerID {
+				labels = append(labels, l)
+			}
+		}
+		writeJSON(w, http.StatusOK, labels)
+	case len(path) == 2 && r.Method == http.MethodPost:
+		var label ComponentLabel
+		if !decode(w, r, &label) {
+			return
+		}
+		if label.Label == "" {
+			writeError(w, http.StatusBadRequest, "label name is required")
+			return
+		}
+		if s.labelIndex(ownerID, label.Label) >= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("label %s already exists", label.Label))
+			return
+		}
+		label.ID, label.OwnerID = "", ownerID
+		writeJSON(w, http.StatusOK, s.addLabel(label))
+	case len(path) == 3 && r.Method == http.MethodDelete:
+		i := s.labelIndex(ownerID, path[2])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "label not found")
+			return
+		}
+		s.labels = append(s.labels[:i], s.labels[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// serveComponentLabels applies labels, which are available to the application, to components
+func (s *Server) serveComponentLabels(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 5 || path[1] != "labels" || path[3] != "applications" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	hash, label, appID := path[0], path[2], path[4]
+
+	i := s.applicationIndex(appID)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	app := s.applications[i]
+
+	available := false
+	for _, owner := range []string{app.ID, app.OrganizationID, RootOrganizationID} {
+		available = available || s.labelIndex(owner, label) >= 0
+	}
+	if !available {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("label %s not found", label))
+		return
+	}
+
+	applied := AppliedLabel{ComponentHash: hash, Label: label, ApplicationID: appID}
+
+	var kept []AppliedLabel
+	for _, a := range s.appliedLabels {
+		if a != applied {
+			kept = append(kept, a)
+		}
+	}
+	s.appliedLabels = kept
+
+	switch r.Method {
+	case http.MethodPost:
+		s.appliedLabels = append(s.appliedLabels, applied)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
@@ -0,0 +1,211 @@
+package nexusiqtest
+
+import "encoding/json"
+
+// Category describes an application category which can be assigned to organizations
+type Category struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// Organization describes an organization of the fake
+type Organization struct {
+	ID   string     `json:"id"`
+	Name string     `json:"name"`
+	Tags []Category `json:"tags,omitempty"`
+}
+
+// ApplicationTag associates a category to an application
+type ApplicationTag struct {
+	ID            string `json:"id,omitempty"`
+	TagID         string `json:"tagId,omitempty"`
+	ApplicationID string `json:"applicationId,omitempty"`
+}
+
+// Application describes an application of the fake
+type Application struct {
+	ID              string           `json:"id"`
+	PublicID        string           `json:"publicId,omitempty"`
+	Name            string           `json:"name"`
+	OrganizationID  string           `json:"organizationId"`
+	ContactUserName string           `json:"contactUserName,omitempty"`
+	ApplicationTags []ApplicationTag `json:"applicationTags,omitempty"`
+}
+
+// Policy describes a policy of the fake
+type Policy struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	OwnerID     string `json:"ownerId"`
+	OwnerType   string `json:"ownerType"`
+	ThreatLevel int    `json:"threatLevel"`
+	PolicyType  string `json:"policyType"`
+}
+
+// ConstraintViolation describes why a policy was violated
+type ConstraintViolation struct {
+	ConstraintID   string `json:"constraintId"`
+	ConstraintName string `json:"constraintName"`
+	Reasons        []struct {
+		Reason string `json:"reason"`
+	} `json:"reasons"`
+}
+
+// PolicyViolation describes the violation of a policy by an application.
+// ApplicationID is the internal ID of the violating application
+type PolicyViolation struct {
+	ApplicationID        string                `json:"applicationId,omitempty"`
+	PolicyID             string                `json:"policyId"`
+	PolicyName           string                `json:"policyName"`
+	StageID              string                `json:"stageId,omitempty"`
+	ReportURL            string                `json:"reportUrl,omitempty"`
+	ThreatLevel          int                   `json:"threatLevel"`
+	ConstraintViolations []ConstraintViolation `json:"constraintViolations"`
+}
+
+// Component identifies a component submitted for evaluation
+type Component struct {
+	Hash                string          `json:"hash,omitempty"`
+	ComponentIdentifier json.RawMessage `json:"componentIdentifier,omitempty"`
+	Proprietary         bool            `json:"proprietary,omitempty"`
+	PackageURL          string          `json:"packageUrl,omitempty"`
+	MatchState          string          `json:"matchState,omitempty"`
+	Pathnames           []string        `json:"pathnames,omitempty"`
+}
+
+// EvaluationResult is the result of evaluating a single component
+type EvaluationResult struct {
+	Component    Component       `json:"component"`
+	MatchState   string          `json:"matchState"`
+	CatalogDate  string          `json:"catalogDate"`
+	LicenseData  json.RawMessage `json:"licenseData,omitempty"`
+	SecurityData json.RawMessage `json:"securityData,omitempty"`
+	PolicyData   json.RawMessage `json:"policyData,omitempty"`
+}
+
+// Evaluation describes the results of a component evaluation request
+type Evaluation struct {
+	SubmittedDate  string             `json:"submittedDate"`
+	EvaluationDate string             `json:"evaluationDate"`
+	ApplicationID  string             `json:"applicationId"`
+	Results        []EvaluationResult `json:"results"`
+	IsError        bool               `json:"isError"`
+	ErrorMessage   interface{}        `json:"errorMessage"`
+}
+
+// Report describes an application report. ApplicationID is the internal ID of the application and
+// Raw and Policy hold the raw and policy report data, which are served as given
+type Report struct {
+	ID             string          `json:"id"`
+	ApplicationID  string          `json:"applicationId"`
+	Stage          string          `json:"stage"`
+	EvaluationDate string          `json:"evaluationDate"`
+	Raw            json.RawMessage `json:"raw,omitempty"`
+	Policy         json.RawMessage `json:"policy,omitempty"`
+}
+
+// ComponentLabel describes a label owned by an organization or application
+type ComponentLabel struct {
+	ID             string `json:"id,omitempty"`
+	OwnerID        string `json:"ownerId,omitempty"`
+	Label          string `json:"label"`
+	LabelLowercase string `json:"labelLowercase,omitempty"`
+	Description    string `json:"description,omitempty"`
+	Color          string `json:"color"`
+}
+
+// AppliedLabel records a label applied to a component in the context of an application
+type AppliedLabel struct {
+	ComponentHash string `json:"componentHash"`
+	Label         string `json:"label"`
+	ApplicationID string `json:"applicationId"`
+}
+
+// SourceControlEntry describes the source control configuration of an application
+type SourceControlEntry struct {
+	ID            string `json:"id,omitempty"`
+	ApplicationID string `json:"applicationId"`
+	RepositoryURL string `json:"repositoryUrl"`
+	Token         string `json:"token"`
+}
+
+// User describes a user of the fake
+type User struct {
+	Username  string `json:"username,omitempty"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Password  string `json:"password,omitempty"`
+}
+
+// Role describes a role of the fake
+type Role struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Owner types of role memberships
+const (
+	OwnerTypeOrganization        = "organization"
+	OwnerTypeApplication         = "application"
+	OwnerTypeRepositoryContainer = "repository_container"
+	OwnerTypeGlobal              = "global"
+)
+
+// RoleMembership grants a role to a user or group. OwnerID is empty for the
+// repository_container and global owner types
+type RoleMembership struct {
+	OwnerType       string `json:"ownerType"`
+	OwnerID         string `json:"ownerId,omitempty"`
+	RoleID          string `json:"roleId"`
+	Type            string `json:"type"`
+	UserOrGroupName string `json:"userOrGroupName"`
+}
+
+// DataRetentionPolicy describes how long data is retained
+type DataRetentionPolicy struct {
+	InheritPolicy bool   `json:"inheritPolicy"`
+	EnablePurging bool   `json:"enablePurging"`
+	MaxAge        string `json:"maxAge"`
+}
+
+// DataRetentionPolicies describes the data retention policies of an organization
+type DataRetentionPolicies struct {
+	ApplicationReports struct {
+		Stages map[string]DataRetentionPolicy `json:"stages"`
+	} `json:"applicationReports"`
+	SuccessMetrics DataRetentionPolicy `json:"successMetrics"`
+}
+
+type member struct {
+	OwnerID         string `json:"ownerId,omitempty"`
+	OwnerType       string `json:"ownerType,omitempty"`
+	Type            string `json:"type"`
+	UserOrGroupName string `json:"userOrGroupName"`
+}
+
+type memberMapping struct {
+	RoleID  string   `json:"roleId"`
+	Members []member `json:"members"`
+}
+
+type memberMappings struct {
+	MemberMappings []memberMapping `json:"memberMappings"`
+}
+
+type reportInfo struct {
+	ApplicationID           string `json:"applicationId"`
+	EmbeddableReportHTMLURL string `json:"embeddableReportHtmlUrl"`
+	EvaluationDate          string `json:"evaluationDate"`
+	ReportDataURL           string `json:"reportDataUrl"`
+	ReportHTMLURL           string `json:"reportHtmlUrl"`
+	ReportPdfURL            string `json:"reportPdfUrl"`
+	Stage                   string `json:"stage"`
+}
+
+type applicationViolation struct {
+	Application      Application       `json:"application"`
+	PolicyViolations []PolicyViolation `json:"policyViolations"`
+}
@@ -0,0 +1,108 @@
+package nexusiqtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Reports returns the application reports of the fake
+func (s *Server) Reports() []Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Report(nil), s.reports...)
+}
+
+func (s *Server) addReport(r Report) Report {
+	if r.ID == "" {
+		r.ID = s.newID("report")
+	}
+	if r.EvaluationDate == "" {
+		r.EvaluationDate = now()
+	}
+	s.reports = append(s.reports, r)
+	return r
+}
+
+func (s *Server) reportInfo(app Application, r Report) reportInfo {
+	ui := fmt.Sprintf("ui/links/application/%s/report/%s", app.PublicID, r.ID)
+	return reportInfo{
+		ApplicationID:           app.ID,
+		EmbeddableReportHTMLURL: ui + "/embeddable",
+		EvaluationDate:          r.EvaluationDate,
+		ReportDataURL:           fmt.Sprintf("api/v2/applications/%s/reports/%s/raw", app.PublicID, r.ID),
+		ReportHTMLURL:           ui,
+		ReportPdfURL:            ui + "/pdf",
+		Stage:                   r.Stage,
+	}
+}
+
+func (s *Server) serveReports(w http.ResponseWriter, r *http.Request, path []string) {
+	if r.Method != http.MethodGet || len(path) == 0 || len(path) > 2 || path[0] != "applications" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	infos := []reportInfo{}
+	for _, app := range s.applications {
+		if len(path) == 2 && app.ID != path[1] {
+			continue
+		}
+		for _, report := range s.reports {
+			if report.ApplicationID == app.ID {
+				infos = append(infos, s.reportInfo(app, report))
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, infos)
+}
+
+// serveReportData serves the raw or policy data of a report
+func (s *Server) serveReportData(w http.ResponseWriter, r *http.Request, publicID, reportID, kind string) {
+	app, ok := s.applicationByPublicID(publicID)
+	if !ok {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	var report *Report
+	for i := range s.reports {
+		if s.reports[i].ID == reportID && s.reports[i].ApplicationID == app.ID {
+			report = &s.reports[i]
+		}
+	}
+	if report == nil {
+		writeError(w, http.StatusNotFound, "report not found")
+		return
+	}
+
+	switch kind {
+	case "raw":
+		if report.Raw != nil {
+			writeJSON(w, http.StatusOK, report.Raw)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"components":   []interface{}{},
+			"matchSummary": map[string]int{"knownComponentCount": 0, "totalComponentCount": 0},
+		})
+	case "policy":
+		if report.Policy != nil {
+			writeJSON(w, http.StatusOK, report.Policy)
+			return
+		}
+		evaluated, _ := time.Parse(time.RFC3339, report.EvaluationDate)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"application": app,
+			"components":  []interface{}{},
+			"counts":      json.RawMessage(`{"exactlyMatchedComponentCount":0,"grandfatheredPolicyViolationCount":0,"partiallyMatchedComponentCount":0,"totalComponentCount":0}`),
+			"reportTime":  evaluated.UnixNano() / int64(time.Millisecond),
+			"reportTitle": fmt.Sprintf("Report-%s", report.ID),
+		})
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
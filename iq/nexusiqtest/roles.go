@@ -0,0 +1,133 @@
+package nexusiqtest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Roles returns the roles of the fake
+func (s *Server) Roles() []Role {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Role(nil), s.roles...)
+}
+
+func (s *Server) roleIndex(id string) int {
+	for i, role := range s.roles {
+		if role.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) serveRoles(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 0 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]Role{"roles": append([]Role{}, s.roles...)})
+}
+
+// RoleMemberships returns the role memberships of the fake
+func (s *Server) RoleMemberships() []RoleMembership {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]RoleMembership(nil), s.roleMemberships...)
+}
+
+func (s *Server) grant(m RoleMembership) {
+	s.revoke(m)
+	s.roleMemberships = append(s.roleMemberships, m)
+}
+
+func (s *Server) revoke(m RoleMembership) {
+	var kept []RoleMembership
+	for _, existing := range s.roleMemberships {
+		if existing != m {
+			kept = append(kept, existing)
+		}
+	}
+	s.roleMemberships = kept
+}
+
+// serveRoleMemberships implements the role membership API introduced in release 70
+func (s *Server) serveRoleMemberships(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	ownerType, ownerID, rest := path[0], "", path[1:]
+	switch ownerType {
+	case OwnerTypeOrganization, OwnerTypeApplication:
+		if len(rest) == 0 {
+			writeError(w, http.StatusNotFound, "not found")
+			return
+		}
+		ownerID, rest = rest[0], rest[1:]
+		if (ownerType == OwnerTypeOrganization && s.organizationIndex(ownerID) < 0) ||
+			(ownerType == OwnerTypeApplication && s.applicationIndex(ownerID) < 0) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", ownerType, ownerID))
+			return
+		}
+	case OwnerTypeRepositoryContainer, OwnerTypeGlobal:
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch {
+	case len(rest) == 0 && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+		var mappings []memberMapping
+		for _, m := range s.roleMemberships {
+			if m.OwnerType != ownerType || m.OwnerID != ownerID {
+				continue
+			}
+
+			mem := member{OwnerID: m.OwnerID, OwnerType: strings.ToUpper(m.OwnerType), Type: m.Type, UserOrGroupName: m.UserOrGroupName}
+
+			found := false
+			for i := range mappings {
+				if mappings[i].RoleID == m.RoleID {
+					mappings[i].Members = append(mappings[i].Members, mem)
+					found = true
+				}
+			}
+			if !found {
+				mappings = append(mappings, memberMapping{RoleID: m.RoleID, Members: []member{mem}})
+			}
+		}
+		writeJSON(w, http.StatusOK, memberMappings{append([]memberMapping{}, mappings...)})
+	case len(rest) == 4 && rest[0] == "role" && (rest[2] == "user" || rest[2] == "group"):
+		if s.roleIndex(rest[1]) < 0 {
+			writeError(w, http.StatusNotFound, "role not found")
+			return
+		}
+
+		m := RoleMembership{
+			OwnerType:       ownerType,
+			OwnerID:         ownerID,
+			RoleID:          rest[1],
+			Type:            strings.ToUpper(rest[2]),
+			UserOrGroupName: rest[3],
+		}
+
+		switch r.Method {
+		case http.MethodPut:
+			s.grant(m)
+		case http.MethodDelete:
+			s.revoke(m)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
@@ -0,0 +1,263 @@
+// Package nexusiqtest provides an in-memory fake of the Nexus IQ Server REST API for tests.
+//
+// The fake is stateful and works offline. It starts with the root organization and the default
+// roles and can be seeded with further state, either from Go structs or from JSON.
+//
+//	server := nexusiqtest.NewServer()
+//	defer server.Close()
+//
+//	server.Seed(nexusiqtest.Fixtures{
+//		Applications: []nexusiqtest.Application{{PublicID: "app", Name: "app", OrganizationID: nexusiqtest.RootOrganizationID}},
+//	})
+//	iq, _ := nexusiq.New(server.URL, "admin", "admin123")
+package nexusiqtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	restPrefix         = "/api/v2/"
+	restProductVersion = "/rest/product/version"
+
+	// DefaultVersion is the version of IQ the fake reports unless told otherwise
+	DefaultVersion = "1.100.0-01"
+)
+
+// RootOrganizationID is the ID of the organization every other organization descends from
+const RootOrganizationID = "ROOT_ORGANIZATION_ID"
+
+// Fixtures describes state the fake can be seeded with.
+// IDs which are left empty are generated
+type Fixtures struct {
+	Organizations         []Organization                   `json:"organizations"`
+	Applications          []Application                    `json:"applications"`
+	Policies              []Policy                         `json:"policies"`
+	PolicyViolations      []PolicyViolation                `json:"policyViolations"`
+	Reports               []Report                         `json:"reports"`
+	Labels                []ComponentLabel                 `json:"labels"`
+	SourceControl         []SourceControlEntry             `json:"sourceControl"`
+	Users                 []User                           `json:"users"`
+	Roles                 []Role                           `json:"roles"`
+	RoleMemberships       []RoleMembership                 `json:"roleMemberships"`
+	DataRetentionPolicies map[string]DataRetentionPolicies `json:"dataRetentionPolicies"`
+}
+
+// Server is an in-memory fake of an IQ Server instance
+type Server struct {
+	*httptest.Server
+
+	// Username and Password, if set, are required as basic auth credentials of every request
+	Username, Password string
+
+	// Version is the version of IQ reported by the fake. Helpers which depend on the version of the
+	// server, such as the role memberships, behave accordingly but the fake itself serves every endpoint
+	Version string
+
+	// EvaluationPolls is the number of times the results of an evaluation are polled
+	// and reported as not yet available before they are returned
+	EvaluationPolls int
+
+	// Evaluator, if set, produces the evaluation result of each submitted component.
+	// By default components are reported with an unknown match state
+	Evaluator func(component Component) EvaluationResult
+
+	mu                    sync.Mutex
+	organizations         []Organization
+	applications          []Application
+	policies              []Policy
+	violations            []PolicyViolation
+	reports               []Report
+	evaluations           map[string]*evaluation
+	labels                []ComponentLabel
+	appliedLabels         []AppliedLabel
+	sourceControl         []SourceControlEntry
+	users                 []User
+	roles                 []Role
+	roleMemberships       []RoleMembership
+	dataRetentionPolicies map[string]DataRetentionPolicies
+	nextID                int
+}
+
+type evaluation struct {
+	Evaluation
+	polls int
+}
+
+// NewServer starts a new fake IQ Server. The caller should call Close when finished
+func NewServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewUnstartedServer creates a new fake IQ Server without starting it, e.g. to serve TLS
+func NewUnstartedServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewUnstartedServer(s)
+	return s
+}
+
+func newServer() *Server {
+	return &Server{
+		Version:       DefaultVersion,
+		organizations: []Organization{{ID: RootOrganizationID, Name: "Root Organization"}},
+		roles: []Role{
+			{ID: "1da70fae1fd54d6cb7999871ebdb9a36", Name: "Application Evaluator", Description: "Evaluates applications and views policy violation summary results."},
+			{ID: "90bd9e0ab2e4443dbfd2f9d4d4e5f21b", Name: "Component Evaluator", Description: "Evaluates individual components and views policy violation results for a specified application."},
+			{ID: "1cddabf7fdaa47d6833454af10e0a3ef", Name: "Developer", Description: "Views all information for their assigned organization or application."},
+			{ID: "1b92fae3e55a411793a091fb821c422d", Name: "Owner", Description: "Manages assigned organizations, applications, policies, and policy violations."},
+			{ID: "9c5ac7ad9c6a4e2a9e2b6f1c0d0a1f5e", Name: "Policy Administrator", Description: "Manages all organizations, applications, policies, and policy violations."},
+			{ID: "b9646757e98e486da7d730025f5245f8", Name: "System Administrator", Description: "Manages system configuration and users."},
+		},
+		evaluations:           make(map[string]*evaluation),
+		dataRetentionPolicies: make(map[string]DataRetentionPolicies),
+	}
+}
+
+// Seed adds the given fixtures to the state of the fake
+func (s *Server) Seed(f Fixtures) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, org := range f.Organizations {
+		s.addOrganization(org)
+	}
+	for _, app := range f.Applications {
+		s.addApplication(app)
+	}
+	for _, p := range f.Policies {
+		if p.ID == "" {
+			p.ID = s.newID("policy")
+		}
+		s.policies = append(s.policies, p)
+	}
+	s.violations = append(s.violations, f.PolicyViolations...)
+	for _, r := range f.Reports {
+		s.addReport(r)
+	}
+	for _, l := range f.Labels {
+		s.addLabel(l)
+	}
+	for _, e := range f.SourceControl {
+		s.addSourceControl(e)
+	}
+	for _, u := range f.Users {
+		s.setUser(u)
+	}
+	for _, r := range f.Roles {
+		if r.ID == "" {
+			r.ID = s.newID("role")
+		}
+		s.roles = append(s.roles, r)
+	}
+	for _, m := range f.RoleMemberships {
+		s.grant(m)
+	}
+	for orgID, p := range f.DataRetentionPolicies {
+		s.dataRetentionPolicies[orgID] = p
+	}
+}
+
+// SeedJSON adds the fixtures, as read from JSON, to the state of the fake
+func (s *Server) SeedJSON(r io.Reader) error {
+	var f Fixtures
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return fmt.Errorf("could not read fixtures: %w", err)
+	}
+
+	s.Seed(f)
+
+	return nil
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%d", prefix, s.nextID)
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// ServeHTTP implements the REST API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.Username != "" || s.Password != "" {
+		if user, pass, ok := r.BasicAuth(); !ok || user != s.Username || pass != s.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	if r.URL.Path == restProductVersion {
+		writeJSON(w, http.StatusOK, map[string]string{"name": "sonatype-clm-server", "version": s.Version})
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, restPrefix) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, restPrefix), "/"), "/")
+	switch path[0] {
+	case "applications":
+		s.serveApplications(w, r, path[1:])
+	case "organizations":
+		s.serveOrganizations(w, r, path[1:])
+	case "policies":
+		s.servePolicies(w, r, path[1:])
+	case "policyViolations":
+		s.servePolicyViolations(w, r, path[1:])
+	case "evaluation":
+		s.serveEvaluation(w, r, path[1:])
+	case "reports":
+		s.serveReports(w, r, path[1:])
+	case "labels":
+		s.serveLabels(w, r, path[1:])
+	case "components":
+		s.serveComponentLabels(w, r, path[1:])
+	case "sourceControl":
+		s.serveSourceControl(w, r, path[1:])
+	case "users":
+		s.serveUsers(w, r, path[1:])
+	case "roles":
+		s.serveRoles(w, r, path[1:])
+	case "roleMemberships":
+		s.serveRoleMemberships(w, r, path[1:])
+	case "dataRetentionPolicies":
+		s.serveDataRetentionPolicies(w, r, path[1:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	fmt.Fprint(w, message)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,366 @@
+package nexusiqtest_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	nexusiq "github.com/sonatype-nexus-community/gonexus/iq"
+	"github.com/sonatype-nexus-community/gonexus/iq/nexusiqtest"
+)
+
+const fixtures = `{
+	"organizations": [{"id": "org1", "name": "Engineering"}],
+	"applications": [
+		{"id": "app1", "publicId": "webapp", "name": "Web App", "organizationId": "org1"},
+		{"id": "app2", "publicId": "cli", "name": "CLI", "organizationId": "org1"}
+	],
+	"policies": [
+		{"id": "policy1", "name": "Security-High", "ownerId": "ROOT_ORGANIZATION_ID", "ownerType": "ORGANIZATION", "threatLevel": 9},
+		{"id": "policy2", "name": "License-Banned", "ownerId": "ROOT_ORGANIZATION_ID", "ownerType": "ORGANIZATION", "threatLevel": 10}
+	],
+	"policyViolations": [
+		{"applicationId": "app1", "policyId": "policy1", "policyName": "Security-High", "threatLevel": 9},
+		{"applicationId": "app2", "policyId": "policy2", "policyName": "License-Banned", "threatLevel": 10}
+	],
+	"reports": [
+		{"id": "report1", "applicationId": "app1", "stage": "build", "raw": {"matchSummary": {"knownComponentCount": 2, "totalComponentCount": 3}}}
+	]
+}`
+
+func newFake(t *testing.T) (nexusiq.IQ, *nexusiqtest.Server) {
+	server := nexusiqtest.NewServer()
+	server.Username, server.Password = "admin", "admin123"
+
+	if err := server.SeedJSON(strings.NewReader(fixtures)); err != nil {
+		t.Fatal(err)
+	}
+
+	iq, err := nexusiq.New(server.URL, server.Username, server.Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return iq, server
+}
+
+func TestAuthentication(t *testing.T) {
+	_, server := newFake(t)
+	defer server.Close()
+
+	iq, err := nexusiq.New(server.URL, "admin", "wrong")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := nexusiq.GetAllApplications(iq); !errors.Is(err, nexus.ErrUnauthorized) {
+		t.Errorf("Expected unauthorized error but got %v", err)
+	}
+}
+
+func TestApplications(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	id, err := nexusiq.CreateApplication(iq, "Service", "service", "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app, err := nexusiq.GetApplicationByPublicID(iq, "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.ID != id || app.OrganizationID != "org1" {
+		t.Errorf("Unexpected application %v", app)
+	}
+
+	if _, err := nexusiq.CreateApplication(iq, "Service", "service", "org1"); !errors.Is(err, nexus.ErrBadRequest) {
+		t.Errorf("Expected bad request for duplicate application but got %v", err)
+	}
+
+	apps, err := nexusiq.GetApplicationsByOrganization(iq, "Engineering")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 3 {
+		t.Errorf("Expected 3 applications but got %d", len(apps))
+	}
+
+	if err := nexusiq.DeleteApplication(iq, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nexusiq.GetApplicationByPublicID(iq, "service"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestOrganizations(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	id, err := nexusiq.CreateOrganization(iq, "Operations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	org, err := nexusiq.GetOrganizationByName(iq, "Operations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if org.ID != id {
+		t.Errorf("Retrieved organization %s instead of %s", org.ID, id)
+	}
+
+	if _, err := nexusiq.GetOrganizationByName(iq, "Root Organization"); err != nil {
+		t.Errorf("Expected the root organization to exist: %v", err)
+	}
+}
+
+func TestPolicyViolations(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	violations, err := nexusiq.GetAllPolicyViolations(iq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 2 {
+		t.Errorf("Expected violations in 2 applications but got %d", len(violations))
+	}
+
+	violations, err = nexusiq.GetPolicyViolationsByName(iq, "License-Banned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].Application.PublicID != "cli" {
+		t.Errorf("Unexpected violations %v", violations)
+	}
+}
+
+func TestEvaluation(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	server.EvaluationPolls = 1
+	server.Evaluator = func(c nexusiqtest.Component) nexusiqtest.EvaluationResult {
+		return nexusiqtest.EvaluationResult{Component: c, MatchState: "exact"}
+	}
+
+	component := nexusiq.Component{PackageURL: "pkg:npm/left-pad@1.3.0"}
+	eval, err := nexusiq.EvaluateComponents(iq, []nexusiq.Component{component}, "app1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(eval.Results) != 1 {
+		t.Fatalf("Expected 1 result but got %d", len(eval.Results))
+	}
+	if eval.Results[0].MatchState != "exact" || eval.Results[0].Component.PackageURL != component.PackageURL {
+		t.Errorf("Unexpected result %v", eval.Results[0])
+	}
+}
+
+func TestReports(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	infos, err := nexusiq.GetReportInfosByAppID(iq, "webapp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 1 || infos[0].ReportID() != "report1" {
+		t.Fatalf("Unexpected report infos %v", infos)
+	}
+
+	report, err := nexusiq.GetReportByAppID(iq, "webapp", nexusiq.StageBuild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Raw.MatchSummary.TotalComponentCount != 3 {
+		t.Errorf("Unexpected raw report %v", report.Raw)
+	}
+	if report.Policy.Application.PublicID != "webapp" {
+		t.Errorf("Unexpected policy report %v", report.Policy)
+	}
+
+	if _, err := nexusiq.GetReportByAppID(iq, "webapp", nexusiq.StageRelease); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestComponentLabels(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	if _, err := nexusiq.CreateComponentLabelForOrganization(iq, "org1", "Approved", "Vetted", "light-green"); err != nil {
+		t.Fatal(err)
+	}
+
+	labels, err := nexusiq.GetComponentLabelsByOrganization(iq, "org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 1 || labels[0].Label != "Approved" {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+
+	component := nexusiq.Component{Hash: "045c37a03be19f3e0db8"}
+	if err := nexusiq.ComponentLabelApply(iq, component, "webapp", "Approved"); err != nil {
+		t.Fatal(err)
+	}
+	if applied := server.AppliedLabels(); len(applied) != 1 || applied[0].ComponentHash != component.Hash {
+		t.Errorf("Unexpected applied labels %v", applied)
+	}
+
+	if err := nexusiq.ComponentLabelApply(iq, component, "webapp", "Unknown"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+
+	if err := nexusiq.ComponentLabelUnapply(iq, component, "webapp", "Approved"); err != nil {
+		t.Fatal(err)
+	}
+	if applied := server.AppliedLabels(); len(applied) != 0 {
+		t.Errorf("Expected no applied labels but found %v", applied)
+	}
+
+	if err := nexusiq.DeleteComponentLabelForOrganization(iq, "org1", labels[0].ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSourceControl(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	if err := nexusiq.CreateSourceControlEntry(iq, "webapp", "https://example.com/webapp.git", "token"); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := nexusiq.GetSourceControlEntry(iq, "webapp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.RepositoryURL != "https://example.com/webapp.git" || entry.ApplicationID != "app1" {
+		t.Errorf("Unexpected entry %v", entry)
+	}
+
+	if err := nexusiq.UpdateSourceControlEntry(iq, "webapp", "https://example.com/moved.git", "token"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := nexusiq.GetAllSourceControlEntries(iq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].RepositoryURL != "https://example.com/moved.git" {
+		t.Errorf("Unexpected entries %v", entries)
+	}
+
+	if err := nexusiq.DeleteSourceControlEntryByApp(iq, "webapp"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nexusiq.GetSourceControlEntry(iq, "webapp"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	user := nexusiq.User{Username: "jdoe", FirstName: "Jane", LastName: "Doe", Email: "jdoe@example.com", Password: "secret"}
+	if err := nexusiq.SetUser(iq, user); err != nil {
+		t.Fatal(err)
+	}
+
+	user.Email = "jane@example.com"
+	if err := nexusiq.SetUser(iq, user); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := nexusiq.GetUser(iq, "jdoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Email != "jane@example.com" || got.Password != "" {
+		t.Errorf("Unexpected user %v", got)
+	}
+
+	if err := nexusiq.DeleteUser(iq, "jdoe"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nexusiq.GetUser(iq, "jdoe"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestRoleMemberships(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	if _, err := nexusiq.GetSystemAdminID(iq); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := nexusiq.SetOrganizationUser(iq, "Engineering", "Owner", "jdoe"); err != nil {
+		t.Fatal(err)
+	}
+	if err := nexusiq.SetApplicationGroup(iq, "webapp", "Developer", "devs"); err != nil {
+		t.Fatal(err)
+	}
+	if err := nexusiq.SetGlobalUser(iq, "System Administrator", "admin"); err != nil {
+		t.Fatal(err)
+	}
+
+	auths, err := nexusiq.OrganizationAuthorizations(iq, "Engineering")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auths) != 1 || auths[0].Members[0].UserOrGroupName != "jdoe" {
+		t.Errorf("Unexpected organization authorizations %v", auths)
+	}
+
+	auths, err = nexusiq.ApplicationAuthorizations(iq, "webapp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(auths) != 1 || auths[0].Members[0].Type != nexusiq.MemberTypeGroup {
+		t.Errorf("Unexpected application authorizations %v", auths)
+	}
+
+	if err := nexusiq.RevokeOrganizationUser(iq, "Engineering", "Owner", "jdoe"); err != nil {
+		t.Fatal(err)
+	}
+	if auths, _ = nexusiq.OrganizationAuthorizations(iq, "Engineering"); len(auths) != 0 {
+		t.Errorf("Expected no organization authorizations but found %v", auths)
+	}
+
+	if memberships := server.RoleMemberships(); len(memberships) != 2 {
+		t.Errorf("Expected 2 role memberships but found %d", len(memberships))
+	}
+}
+
+func TestDataRetentionPolicies(t *testing.T) {
+	iq, server := newFake(t)
+	defer server.Close()
+
+	policies, err := nexusiq.GetRetentionPolicies(iq, "Engineering")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	build := policies.ApplicationReports.Stages[nexusiq.StageBuild]
+	build.InheritPolicy, build.EnablePurging, build.MaxAge = false, true, "3 months"
+	policies.ApplicationReports.Stages[nexusiq.StageBuild] = build
+
+	if err := nexusiq.SetRetentionPolicies(iq, "Engineering", policies); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := server.DataRetentionPolicies("org1").ApplicationReports.Stages[nexusiq.StageBuild]
+	if stored.MaxAge != "3 months" || !stored.EnablePurging {
+		t.Errorf("Unexpected stored policy %v", stored)
+	}
+}
@@ -0,0 +1,87 @@
+package nexusiqtest
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// SourceControlEntries returns the source control entries of the fake
+func (s *Server) SourceControlEntries() []SourceControlEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SourceControlEntry(nil), s.sourceControl...)
+}
+
+func (s *Server) addSourceControl(e SourceControlEntry) SourceControlEntry {
+	if e.ID == "" {
+		e.ID = s.newID("sourceControl")
+	}
+	s.sourceControl = append(s.sourceControl, e)
+	return e
+}
+
+func (s *Server) sourceControlIndex(appID string) int {
+	for i, e := range s.sourceControl {
+		if e.ApplicationID == appID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) serveSourceControl(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 || len(path) > 2 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	appID := path[0]
+	if s.applicationIndex(appID) < 0 {
+		writeError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	i := s.sourceControlIndex(appID)
+
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "source control entry not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.sourceControl[i])
+	case len(path) == 1 && r.Method == http.MethodPost:
+		if i >= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("application %s already has a source control entry", appID))
+			return
+		}
+		var entry SourceControlEntry
+		if !decode(w, r, &entry) {
+			return
+		}
+		entry.ID, entry.ApplicationID = "", appID
+		writeJSON(w, http.StatusOK, s.addSourceControl(entry))
+	case len(path) == 1 && r.Method == http.MethodPut:
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "source control entry not found")
+			return
+		}
+		var entry SourceControlEntry
+		if !decode(w, r, &entry) {
+			return
+		}
+		entry.ID, entry.ApplicationID = s.sourceControl[i].ID, appID
+		s.sourceControl[i] = entry
+		writeJSON(w, http.StatusOK, entry)
+	case len(path) == 2 && r.Method == http.MethodDelete:
+		if i < 0 || s.sourceControl[i].ID != path[1] {
+			writeError(w, http.StatusNotFound, "source control entry not found")
+			return
+		}
+		s.sourceControl = append(s.sourceControl[:i], s.sourceControl[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
@@ -0,0 +1,88 @@
+package nexusiqtest
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Users returns the users of the fake, including their passwords
+func (s *Server) Users() []User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]User(nil), s.users...)
+}
+
+func (s *Server) setUser(u User) {
+	if i := s.userIndex(u.Username); i >= 0 {
+		s.users[i] = u
+		return
+	}
+	s.users = append(s.users, u)
+}
+
+func (s *Server) userIndex(username string) int {
+	for i, u := range s.users {
+		if u.Username == username {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) serveUsers(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var user User
+		if !decode(w, r, &user) {
+			return
+		}
+		if user.Username == "" {
+			writeError(w, http.StatusBadRequest, "username is required")
+			return
+		}
+		if s.userIndex(user.Username) >= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("user %s already exists", user.Username))
+			return
+		}
+		s.setUser(user)
+		user.Password = ""
+		writeJSON(w, http.StatusOK, user)
+	case len(path) == 1 && r.Method == http.MethodGet:
+		i := s.userIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		user := s.users[i]
+		user.Password = ""
+		writeJSON(w, http.StatusOK, user)
+	case len(path) == 1 && r.Method == http.MethodPut:
+		i := s.userIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		var user User
+		if !decode(w, r, &user) {
+			return
+		}
+		user.Username = path[0]
+		if user.Password == "" {
+			user.Password = s.users[i].Password
+		}
+		s.users[i] = user
+		user.Password = ""
+		writeJSON(w, http.StatusOK, user)
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		i := s.userIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		s.users = append(s.users[:i], s.users[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
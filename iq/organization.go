@@ -2,8 +2,11 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -35,11 +38,11 @@ type Organization struct {
 	Tags []IQCategory `json:"tags,omitempty"`
 }
 
-// GetOrganizationByName returns details on the named IQ organization
-func GetOrganizationByName(iq IQ, organizationName string) (*Organization, error) {
-	orgs, err := GetAllOrganizations(iq)
+// GetOrganizationByNameContext returns details on the named IQ organization
+func GetOrganizationByNameContext(ctx context.Context, iq IQ, organizationName string) (*Organization, error) {
+	orgs, err := GetAllOrganizationsContext(ctx, iq)
 	if err != nil {
-		return nil, fmt.Errorf("organization '%s' not found: %v", organizationName, err)
+		return nil, fmt.Errorf("organization '%s' not found: %w", organizationName, err)
 	}
 	for _, org := range orgs {
 		if org.Name == organizationName {
@@ -47,13 +50,18 @@ func GetOrganizationByName(iq IQ, organizationName string) (*Organization, error
 		}
 	}
 
-	return nil, fmt.Errorf("organization '%s' not found", organizationName)
+	return nil, fmt.Errorf("organization '%s' %w", organizationName, nexus.ErrNotFound)
 }
 
-// CreateOrganization creates an organization in IQ with the given name
-func CreateOrganization(iq IQ, name string) (string, error) {
+// GetOrganizationByName returns details on the named IQ organization
+func GetOrganizationByName(iq IQ, organizationName string) (*Organization, error) {
+	return GetOrganizationByNameContext(context.Background(), iq, organizationName)
+}
+
+// CreateOrganizationContext creates an organization in IQ with the given name
+func CreateOrganizationContext(ctx context.Context, iq IQ, name string) (string, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("organization '%s' not created: %v", name, err)
+		return fmt.Errorf("organization '%s' not created: %w", name, err)
 	}
 
 	request, err := json.Marshal(iqNewOrgRequest{Name: name})
@@ -61,7 +69,7 @@ func CreateOrganization(iq IQ, name string) (string, error) {
 		return "", doError(err)
 	}
 
-	body, _, err := iq.Post(restOrganization, bytes.NewBuffer(request))
+	body, _, err := nexus.PostContext(ctx, iq, restOrganization, bytes.NewBuffer(request))
 	if err != nil {
 		return "", doError(err)
 	}
@@ -74,13 +82,18 @@ func CreateOrganization(iq IQ, name string) (string, error) {
 	return org.ID, nil
 }
 
-// GetAllOrganizations returns a slice of all of the organizations in an IQ instance
-func GetAllOrganizations(iq IQ) ([]Organization, error) {
+// CreateOrganization creates an organization in IQ with the given name
+func CreateOrganization(iq IQ, name string) (string, error) {
+	return CreateOrganizationContext(context.Background(), iq, name)
+}
+
+// GetAllOrganizationsContext returns a slice of all of the organizations in an IQ instance
+func GetAllOrganizationsContext(ctx context.Context, iq IQ) ([]Organization, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("organizations not found: %v", err)
+		return fmt.Errorf("organizations not found: %w", err)
 	}
 
-	body, _, err := iq.Get(restOrganization)
+	body, _, err := nexus.GetContext(ctx, iq, restOrganization)
 	if err != nil {
 		return nil, doError(err)
 	}
@@ -92,3 +105,8 @@ func GetAllOrganizations(iq IQ) ([]Organization, error) {
 
 	return resp.Organizations, nil
 }
+
+// GetAllOrganizations returns a slice of all of the organizations in an IQ instance
+func GetAllOrganizations(iq IQ) ([]Organization, error) {
+	return GetAllOrganizationsContext(context.Background(), iq)
+}
@@ -1,8 +1,11 @@
 package nexusiq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restPolicies = "api/v2/policies"
@@ -21,26 +24,31 @@ type policiesList struct {
 	Policies []PolicyInfo `json:"policies"`
 }
 
-// GetPolicies returns a list of all of the policies in IQ
-func GetPolicies(iq IQ) ([]PolicyInfo, error) {
-	body, _, err := iq.Get(restPolicies)
+// GetPoliciesContext returns a list of all of the policies in IQ
+func GetPoliciesContext(ctx context.Context, iq IQ) ([]PolicyInfo, error) {
+	body, _, err := nexus.GetContext(ctx, iq, restPolicies)
 	if err != nil {
-		return nil, fmt.Errorf("could not get list of policies: %v", err)
+		return nil, fmt.Errorf("could not get list of policies: %w", err)
 	}
 
 	var resp policiesList
 	if err = json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("could not read endpoint response: %v", err)
+		return nil, fmt.Errorf("could not read endpoint response: %w", err)
 	}
 
 	return resp.Policies, nil
 }
 
-// GetPolicyInfoByName returns an information object for the named policy
-func GetPolicyInfoByName(iq IQ, policyName string) (PolicyInfo, error) {
-	policies, err := GetPolicies(iq)
+// GetPolicies returns a list of all of the policies in IQ
+func GetPolicies(iq IQ) ([]PolicyInfo, error) {
+	return GetPoliciesContext(context.Background(), iq)
+}
+
+// GetPolicyInfoByNameContext returns an information object for the named policy
+func GetPolicyInfoByNameContext(ctx context.Context, iq IQ, policyName string) (PolicyInfo, error) {
+	policies, err := GetPoliciesContext(ctx, iq)
 	if err != nil {
-		return PolicyInfo{}, fmt.Errorf("did not find policy with name %s: %v", policyName, err)
+		return PolicyInfo{}, fmt.Errorf("did not find policy with name %s: %w", policyName, err)
 	}
 
 	for _, p := range policies {
@@ -51,3 +59,8 @@ func GetPolicyInfoByName(iq IQ, policyName string) (PolicyInfo, error) {
 
 	return PolicyInfo{}, fmt.Errorf("did not find policy with name %s", policyName)
 }
+
+// GetPolicyInfoByName returns an information object for the named policy
+func GetPolicyInfoByName(iq IQ, policyName string) (PolicyInfo, error) {
+	return GetPolicyInfoByNameContext(context.Background(), iq, policyName)
+}
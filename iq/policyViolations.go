@@ -2,8 +2,11 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restPolicyViolations = "api/v2/policyViolations"
@@ -18,11 +21,11 @@ type violationResponse struct {
 	ApplicationViolations []ApplicationViolation `json:"applicationViolations"`
 }
 
-// GetAllPolicyViolations returns all policy violations
-func GetAllPolicyViolations(iq IQ) ([]ApplicationViolation, error) {
-	policyInfos, err := GetPolicies(iq)
+// GetAllPolicyViolationsContext returns all policy violations
+func GetAllPolicyViolationsContext(ctx context.Context, iq IQ) ([]ApplicationViolation, error) {
+	policyInfos, err := GetPoliciesContext(ctx, iq)
 	if err != nil {
-		return nil, fmt.Errorf("could not get policies: %v", err)
+		return nil, fmt.Errorf("could not get policies: %w", err)
 	}
 
 	var endpoint bytes.Buffer
@@ -33,25 +36,30 @@ func GetAllPolicyViolations(iq IQ) ([]ApplicationViolation, error) {
 		endpoint.WriteString(i.ID)
 	}
 
-	body, _, err := iq.Get(endpoint.String())
+	body, _, err := nexus.GetContext(ctx, iq, endpoint.String())
 	if err != nil {
-		return nil, fmt.Errorf("could not get policy violations: %v", err)
+		return nil, fmt.Errorf("could not get policy violations: %w", err)
 	}
 
 	var resp violationResponse
 	err = json.Unmarshal(body, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("could not read policy violations response: %v", err)
+		return nil, fmt.Errorf("could not read policy violations response: %w", err)
 	}
 
 	return resp.ApplicationViolations, nil
 }
 
-// GetPolicyViolationsByName returns the policy violations by policy name
-func GetPolicyViolationsByName(iq IQ, policyNames ...string) ([]ApplicationViolation, error) {
-	policies, err := GetPolicies(iq)
+// GetAllPolicyViolations returns all policy violations
+func GetAllPolicyViolations(iq IQ) ([]ApplicationViolation, error) {
+	return GetAllPolicyViolationsContext(context.Background(), iq)
+}
+
+// GetPolicyViolationsByNameContext returns the policy violations by policy name
+func GetPolicyViolationsByNameContext(ctx context.Context, iq IQ, policyNames ...string) ([]ApplicationViolation, error) {
+	policies, err := GetPoliciesContext(ctx, iq)
 	if err != nil {
-		return nil, fmt.Errorf("did not find policy: %v", err)
+		return nil, fmt.Errorf("did not find policy: %w", err)
 	}
 
 	var endpoint bytes.Buffer
@@ -67,16 +75,21 @@ func GetPolicyViolationsByName(iq IQ, policyNames ...string) ([]ApplicationViola
 		}
 	}
 
-	body, _, err := iq.Get(endpoint.String())
+	body, _, err := nexus.GetContext(ctx, iq, endpoint.String())
 	if err != nil {
-		return nil, fmt.Errorf("could not get policy violations: %v", err)
+		return nil, fmt.Errorf("could not get policy violations: %w", err)
 	}
 
 	var resp violationResponse
 	err = json.Unmarshal(body, &resp)
 	if err != nil {
-		return nil, fmt.Errorf("could not read policy violations response: %v", err)
+		return nil, fmt.Errorf("could not read policy violations response: %w", err)
 	}
 
 	return resp.ApplicationViolations, nil
 }
+
+// GetPolicyViolationsByName returns the policy violations by policy name
+func GetPolicyViolationsByName(iq IQ, policyNames ...string) ([]ApplicationViolation, error) {
+	return GetPolicyViolationsByNameContext(context.Background(), iq, policyNames...)
+}
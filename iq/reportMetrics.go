@@ -2,10 +2,13 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restMetrics = "api/v2/reports/metrics"
@@ -126,7 +129,7 @@ func (b *MetricsRequestBuilder) WithOrganization(v string) *MetricsRequestBuilde
 	return b
 }
 
-func (b *MetricsRequestBuilder) build(iq IQ) (req metricRequest, err error) {
+func (b *MetricsRequestBuilder) build(ctx context.Context, iq IQ) (req metricRequest, err error) {
 	// If timePeriod is MONTH - an ISO 8601 year and month without timezone.
 	// If timePeriod is WEEK  - an ISO 8601 week year and week (e.g. week of 29 December 2008 is "2009-W01")
 	formatTime := func(t time.Time) string {
@@ -163,9 +166,9 @@ func (b *MetricsRequestBuilder) build(iq IQ) (req metricRequest, err error) {
 	if b.apps != nil {
 		req.ApplicationIDS = make([]string, len(b.apps))
 		for i, a := range b.apps {
-			app, er := GetApplicationByPublicID(iq, a)
+			app, er := GetApplicationByPublicIDContext(ctx, iq, a)
 			if er != nil {
-				return req, fmt.Errorf("could not find application with public id %s: %v", a, er)
+				return req, fmt.Errorf("could not find application with public id %s: %w", a, er)
 			}
 			req.ApplicationIDS[i] = app.ID
 		}
@@ -174,9 +177,9 @@ func (b *MetricsRequestBuilder) build(iq IQ) (req metricRequest, err error) {
 	if b.orgs != nil {
 		req.OrganizationIDS = make([]string, len(b.orgs))
 		for i, o := range b.orgs {
-			org, er := GetOrganizationByName(iq, o)
+			org, er := GetOrganizationByNameContext(ctx, iq, o)
 			if er != nil {
-				return req, fmt.Errorf("could not find organization with name %s: %v", o, er)
+				return req, fmt.Errorf("could not find organization with name %s: %w", o, er)
 			}
 			req.OrganizationIDS[i] = org.ID
 		}
@@ -190,30 +193,35 @@ func NewMetricsRequestBuilder() *MetricsRequestBuilder {
 	return new(MetricsRequestBuilder)
 }
 
-// GenerateMetrics creates metrics from the given qualifiers
-func GenerateMetrics(iq IQ, builder *MetricsRequestBuilder) ([]Metrics, error) {
+// GenerateMetricsContext creates metrics from the given qualifiers
+func GenerateMetricsContext(ctx context.Context, iq IQ, builder *MetricsRequestBuilder) ([]Metrics, error) {
 	// TODO: Accept header: application/json or text/csv
 
-	req, err := builder.build(iq)
+	req, err := builder.build(ctx, iq)
 	if err != nil {
-		return nil, fmt.Errorf("could not build request: %v", err)
+		return nil, fmt.Errorf("could not build request: %w", err)
 	}
 
 	buf, err := json.Marshal(req)
 	if err != nil {
-		return nil, fmt.Errorf("could not marshal request: %v", err)
+		return nil, fmt.Errorf("could not marshal request: %w", err)
 	}
 
-	body, _, err := iq.Post(restMetrics, bytes.NewBuffer(buf))
+	body, _, err := nexus.PostContext(ctx, iq, restMetrics, bytes.NewBuffer(buf))
 	if err != nil {
-		return nil, fmt.Errorf("could not issue request to IQ: %v", err)
+		return nil, fmt.Errorf("could not issue request to IQ: %w", err)
 	}
 
 	var metrics []Metrics
 	err = json.Unmarshal(body, &metrics)
 	if err != nil {
-		return nil, fmt.Errorf("could not read response from IQ: %v", err)
+		return nil, fmt.Errorf("could not read response from IQ: %w", err)
 	}
 
 	return metrics, nil
 }
+
+// GenerateMetrics creates metrics from the given qualifiers
+func GenerateMetrics(iq IQ, builder *MetricsRequestBuilder) ([]Metrics, error) {
+	return GenerateMetricsContext(context.Background(), iq, builder)
+}
@@ -1,6 +1,9 @@
 package nexusiq
 
 import (
+	nexus "github.com/sonatype-nexus-community/gonexus"
+
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -197,7 +200,7 @@ func TestMetricsRequestBuilder(t *testing.T) {
 	defer mock.Close()
 
 	for _, test := range tests {
-		got, err := test.input.build(iq)
+		got, err := test.input.build(context.Background(), iq)
 		if err != nil {
 			t.Errorf("Unexpected error building metrics request: %v", err)
 			t.Error("input", test.input)
@@ -243,6 +246,25 @@ func TestGenerateMetrics(t *testing.T) {
 	}
 }
 
+func TestGenerateMetricsDryRun(t *testing.T) {
+	iq, mock := reportMetricsTestIQ(t)
+	defer mock.Close()
+
+	dryRun := nexus.NewDryRun(iq, ReadOnlyEndpoints()...)
+	input := NewMetricsRequestBuilder().Monthly().StartingOn(time.Now().Add(-(30 * (24 * time.Hour)))).WithApplication(dummyApps[0].PublicID)
+
+	got, err := GenerateMetrics(dryRun, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, dummyMetrics[0].metrics) {
+		t.Errorf("Expected the metrics to be generated by the server but got %v", got)
+	}
+	if n := len(dryRun.Plan().Requests); n != 0 {
+		t.Errorf("Expected the read-only request not to be planned but the plan has %d requests", n)
+	}
+}
+
 func ExampleGenerateMetrics() {
 	iq, err := New("http://localhost:8070", "admin", "admin123")
 	if err != nil {
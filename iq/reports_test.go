@@ -1,6 +1,7 @@
 package nexusiq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -204,7 +205,7 @@ func Test_getRawReportByAppReportID(t *testing.T) {
 
 	testIdx := 0
 
-	report, err := getRawReportByAppReportID(iq, dummyApps[testIdx].PublicID, fmt.Sprintf("%d", testIdx))
+	report, err := getRawReportByAppReportID(context.Background(), iq, dummyApps[testIdx].PublicID, fmt.Sprintf("%d", testIdx))
 	if err != nil {
 		t.Fatal(err)
 	}
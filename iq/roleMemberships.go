@@ -2,10 +2,13 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -53,13 +56,6 @@ type Member struct {
 	UserOrGroupName string `json:"userOrGroupName"`
 }
 
-func hasRev70API(iq IQ) bool {
-	api := fmt.Sprintf(restRoleMembersOrgGet, RootOrganization)
-	request, _ := iq.NewRequest("HEAD", api, nil)
-	_, resp, _ := iq.Do(request)
-	return resp.StatusCode != http.StatusNotFound
-}
-
 func newMapping(roleID, memberType, memberName string) MemberMapping {
 	return MemberMapping{
 		RoleID: roleID,
@@ -78,17 +74,17 @@ func newMappings(roleID, memberType, memberName string) memberMappings {
 	}
 }
 
-func organizationAuthorizationsByID(iq IQ, orgID string) ([]MemberMapping, error) {
+func organizationAuthorizationsByID(ctx context.Context, iq IQ, orgID string) ([]MemberMapping, error) {
 	var endpoint string
-	if hasRev70API(iq) {
+	if supports(ctx, iq, FeatureRoleMemberships) {
 		endpoint = fmt.Sprintf(restRoleMembersOrgGet, orgID)
 	} else {
 		endpoint = fmt.Sprintf(restRoleMembersOrgDeprecated, orgID)
 	}
 
-	body, _, err := iq.Get(endpoint)
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve role mapping for organization %s: %v", orgID, err)
+		return nil, fmt.Errorf("could not retrieve role mapping for organization %s: %w", orgID, err)
 	}
 
 	var mappings memberMappings
@@ -97,15 +93,15 @@ func organizationAuthorizationsByID(iq IQ, orgID string) ([]MemberMapping, error
 	return mappings.MemberMappings, err
 }
 
-func organizationAuthorizationsByRoleID(iq IQ, roleID string) ([]MemberMapping, error) {
-	orgs, err := GetAllOrganizations(iq)
+func organizationAuthorizationsByRoleID(ctx context.Context, iq IQ, roleID string) ([]MemberMapping, error) {
+	orgs, err := GetAllOrganizationsContext(ctx, iq)
 	if err != nil {
-		return nil, fmt.Errorf("could not find organizations: %v", err)
+		return nil, fmt.Errorf("could not find organizations: %w", err)
 	}
 
 	mappings := make([]MemberMapping, 0)
 	for _, org := range orgs {
-		orgMaps, _ := organizationAuthorizationsByID(iq, org.ID)
+		orgMaps, _ := organizationAuthorizationsByID(ctx, iq, org.ID)
 		for _, m := range orgMaps {
 			if m.RoleID == roleID {
 				mappings = append(mappings, m)
@@ -116,40 +112,50 @@ func organizationAuthorizationsByRoleID(iq IQ, roleID string) ([]MemberMapping,
 	return mappings, nil
 }
 
+// OrganizationAuthorizationsContext returns the member mappings of an organization
+func OrganizationAuthorizationsContext(ctx context.Context, iq IQ, name string) ([]MemberMapping, error) {
+	org, err := GetOrganizationByNameContext(ctx, iq, name)
+	if err != nil {
+		return nil, fmt.Errorf("could not find organization with name %s: %w", name, err)
+	}
+
+	return organizationAuthorizationsByID(ctx, iq, org.ID)
+}
+
 // OrganizationAuthorizations returns the member mappings of an organization
 func OrganizationAuthorizations(iq IQ, name string) ([]MemberMapping, error) {
-	org, err := GetOrganizationByName(iq, name)
+	return OrganizationAuthorizationsContext(context.Background(), iq, name)
+}
+
+// OrganizationAuthorizationsByRoleContext returns the member mappings of all organizations which match the given role
+func OrganizationAuthorizationsByRoleContext(ctx context.Context, iq IQ, roleName string) ([]MemberMapping, error) {
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return nil, fmt.Errorf("could not find organization with name %s: %v", name, err)
+		return nil, fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
-	return organizationAuthorizationsByID(iq, org.ID)
+	return organizationAuthorizationsByRoleID(ctx, iq, role.ID)
 }
 
 // OrganizationAuthorizationsByRole returns the member mappings of all organizations which match the given role
 func OrganizationAuthorizationsByRole(iq IQ, roleName string) ([]MemberMapping, error) {
-	role, err := RoleByName(iq, roleName)
-	if err != nil {
-		return nil, fmt.Errorf("could not find role with name %s: %v", roleName, err)
-	}
-
-	return organizationAuthorizationsByRoleID(iq, role.ID)
+	return OrganizationAuthorizationsByRoleContext(context.Background(), iq, roleName)
 }
 
-func setOrganizationAuth(iq IQ, name, roleName, member, memberType string) error {
-	org, err := GetOrganizationByName(iq, name)
+func setOrganizationAuth(ctx context.Context, iq IQ, name, roleName, member, memberType string) error {
+	org, err := GetOrganizationByNameContext(ctx, iq, name)
 	if err != nil {
-		return fmt.Errorf("could not find organization with name %s: %v", name, err)
+		return fmt.Errorf("could not find organization with name %s: %w", name, err)
 	}
 
-	role, err := RoleByName(iq, roleName)
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return fmt.Errorf("could not find role with name %s: %v", roleName, err)
+		return fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
 	var endpoint string
 	var payload io.Reader
-	if hasRev70API(iq) {
+	if supports(ctx, iq, FeatureRoleMemberships) {
 		switch memberType {
 		case MemberTypeUser:
 			endpoint = fmt.Sprintf(restRoleMembersOrgUser, org.ID, role.ID, member)
@@ -158,7 +164,7 @@ func setOrganizationAuth(iq IQ, name, roleName, member, memberType string) error
 		}
 	} else {
 		endpoint = fmt.Sprintf(restRoleMembersOrgDeprecated, org.ID)
-		current, err := OrganizationAuthorizations(iq, name)
+		current, err := OrganizationAuthorizationsContext(ctx, iq, name)
 		if err != nil && current == nil {
 			current = make([]MemberMapping, 0)
 		}
@@ -166,40 +172,50 @@ func setOrganizationAuth(iq IQ, name, roleName, member, memberType string) error
 
 		buf, err := json.Marshal(memberMappings{MemberMappings: current})
 		if err != nil {
-			return fmt.Errorf("could not create mapping: %v", err)
+			return fmt.Errorf("could not create mapping: %w", err)
 		}
 		payload = bytes.NewBuffer(buf)
 	}
 
-	_, _, err = iq.Put(endpoint, payload)
+	_, _, err = nexus.PutContext(ctx, iq, endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("could not update organization role mapping: %v", err)
+		return fmt.Errorf("could not update organization role mapping: %w", err)
 	}
 
 	return nil
 }
 
+// SetOrganizationUserContext sets the role and user that can have access to an organization
+func SetOrganizationUserContext(ctx context.Context, iq IQ, name, roleName, user string) error {
+	return setOrganizationAuth(ctx, iq, name, roleName, user, MemberTypeUser)
+}
+
 // SetOrganizationUser sets the role and user that can have access to an organization
 func SetOrganizationUser(iq IQ, name, roleName, user string) error {
-	return setOrganizationAuth(iq, name, roleName, user, MemberTypeUser)
+	return SetOrganizationUserContext(context.Background(), iq, name, roleName, user)
+}
+
+// SetOrganizationGroupContext sets the role and group that can have access to an organization
+func SetOrganizationGroupContext(ctx context.Context, iq IQ, name, roleName, group string) error {
+	return setOrganizationAuth(ctx, iq, name, roleName, group, MemberTypeGroup)
 }
 
 // SetOrganizationGroup sets the role and group that can have access to an organization
 func SetOrganizationGroup(iq IQ, name, roleName, group string) error {
-	return setOrganizationAuth(iq, name, roleName, group, MemberTypeGroup)
+	return SetOrganizationGroupContext(context.Background(), iq, name, roleName, group)
 }
 
-func applicationAuthorizationsByID(iq IQ, appID string) ([]MemberMapping, error) {
+func applicationAuthorizationsByID(ctx context.Context, iq IQ, appID string) ([]MemberMapping, error) {
 	var endpoint string
-	if hasRev70API(iq) {
+	if supports(ctx, iq, FeatureRoleMemberships) {
 		endpoint = fmt.Sprintf(restRoleMembersAppGet, appID)
 	} else {
 		endpoint = fmt.Sprintf(restRoleMembersAppDeprecated, appID)
 	}
 
-	body, _, err := iq.Get(endpoint)
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve role mapping for application %s: %v", appID, err)
+		return nil, fmt.Errorf("could not retrieve role mapping for application %s: %w", appID, err)
 	}
 
 	var mappings memberMappings
@@ -208,15 +224,15 @@ func applicationAuthorizationsByID(iq IQ, appID string) ([]MemberMapping, error)
 	return mappings.MemberMappings, err
 }
 
-func applicationAuthorizationsByRoleID(iq IQ, roleID string) ([]MemberMapping, error) {
-	apps, err := GetAllApplications(iq)
+func applicationAuthorizationsByRoleID(ctx context.Context, iq IQ, roleID string) ([]MemberMapping, error) {
+	apps, err := GetAllApplicationsContext(ctx, iq)
 	if err != nil {
-		return nil, fmt.Errorf("could not find applications: %v", err)
+		return nil, fmt.Errorf("could not find applications: %w", err)
 	}
 
 	mappings := make([]MemberMapping, 0)
 	for _, app := range apps {
-		appMaps, _ := applicationAuthorizationsByID(iq, app.ID)
+		appMaps, _ := applicationAuthorizationsByID(ctx, iq, app.ID)
 		for _, m := range appMaps {
 			if m.RoleID == roleID {
 				mappings = append(mappings, m)
@@ -227,40 +243,50 @@ func applicationAuthorizationsByRoleID(iq IQ, roleID string) ([]MemberMapping, e
 	return mappings, nil
 }
 
+// ApplicationAuthorizationsContext returns the member mappings of an application
+func ApplicationAuthorizationsContext(ctx context.Context, iq IQ, name string) ([]MemberMapping, error) {
+	app, err := GetApplicationByPublicIDContext(ctx, iq, name)
+	if err != nil {
+		return nil, fmt.Errorf("could not find application with name %s: %w", name, err)
+	}
+
+	return applicationAuthorizationsByID(ctx, iq, app.ID)
+}
+
 // ApplicationAuthorizations returns the member mappings of an application
 func ApplicationAuthorizations(iq IQ, name string) ([]MemberMapping, error) {
-	app, err := GetApplicationByPublicID(iq, name)
+	return ApplicationAuthorizationsContext(context.Background(), iq, name)
+}
+
+// ApplicationAuthorizationsByRoleContext returns the member mappings of all applications which match the given role
+func ApplicationAuthorizationsByRoleContext(ctx context.Context, iq IQ, roleName string) ([]MemberMapping, error) {
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return nil, fmt.Errorf("could not find application with name %s: %v", name, err)
+		return nil, fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
-	return applicationAuthorizationsByID(iq, app.ID)
+	return applicationAuthorizationsByRoleID(ctx, iq, role.ID)
 }
 
 // ApplicationAuthorizationsByRole returns the member mappings of all applications which match the given role
 func ApplicationAuthorizationsByRole(iq IQ, roleName string) ([]MemberMapping, error) {
-	role, err := RoleByName(iq, roleName)
-	if err != nil {
-		return nil, fmt.Errorf("could not find role with name %s: %v", roleName, err)
-	}
-
-	return applicationAuthorizationsByRoleID(iq, role.ID)
+	return ApplicationAuthorizationsByRoleContext(context.Background(), iq, roleName)
 }
 
-func setApplicationAuth(iq IQ, name, roleName, member, memberType string) error {
-	app, err := GetApplicationByPublicID(iq, name)
+func setApplicationAuth(ctx context.Context, iq IQ, name, roleName, member, memberType string) error {
+	app, err := GetApplicationByPublicIDContext(ctx, iq, name)
 	if err != nil {
-		return fmt.Errorf("could not find application with name %s: %v", name, err)
+		return fmt.Errorf("could not find application with name %s: %w", name, err)
 	}
 
-	role, err := RoleByName(iq, roleName)
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return fmt.Errorf("could not find role with name %s: %v", roleName, err)
+		return fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
 	var endpoint string
 	var payload io.Reader
-	if hasRev70API(iq) {
+	if supports(ctx, iq, FeatureRoleMemberships) {
 		switch memberType {
 		case MemberTypeUser:
 			endpoint = fmt.Sprintf(restRoleMembersAppUser, app.ID, role.ID, member)
@@ -269,7 +295,7 @@ func setApplicationAuth(iq IQ, name, roleName, member, memberType string) error
 		}
 	} else {
 		endpoint = fmt.Sprintf(restRoleMembersAppDeprecated, app.ID)
-		current, err := ApplicationAuthorizations(iq, name)
+		current, err := ApplicationAuthorizationsContext(ctx, iq, name)
 		if err != nil && current == nil {
 			current = make([]MemberMapping, 0)
 		}
@@ -277,34 +303,44 @@ func setApplicationAuth(iq IQ, name, roleName, member, memberType string) error
 
 		buf, err := json.Marshal(memberMappings{MemberMappings: current})
 		if err != nil {
-			return fmt.Errorf("could not create mapping: %v", err)
+			return fmt.Errorf("could not create mapping: %w", err)
 		}
 		payload = bytes.NewBuffer(buf)
 	}
 
-	_, _, err = iq.Put(endpoint, payload)
+	_, _, err = nexus.PutContext(ctx, iq, endpoint, payload)
 	if err != nil {
-		return fmt.Errorf("could not update organization role mapping: %v", err)
+		return fmt.Errorf("could not update organization role mapping: %w", err)
 	}
 
 	return nil
 }
 
+// SetApplicationUserContext sets the role and user that can have access to an application
+func SetApplicationUserContext(ctx context.Context, iq IQ, name, roleName, user string) error {
+	return setApplicationAuth(ctx, iq, name, roleName, user, MemberTypeUser)
+}
+
 // SetApplicationUser sets the role and user that can have access to an application
 func SetApplicationUser(iq IQ, name, roleName, user string) error {
-	return setApplicationAuth(iq, name, roleName, user, MemberTypeUser)
+	return SetApplicationUserContext(context.Background(), iq, name, roleName, user)
+}
+
+// SetApplicationGroupContext sets the role and group that can have access to an application
+func SetApplicationGroupContext(ctx context.Context, iq IQ, name, roleName, group string) error {
+	return setApplicationAuth(ctx, iq, name, roleName, group, MemberTypeGroup)
 }
 
 // SetApplicationGroup sets the role and group that can have access to an application
 func SetApplicationGroup(iq IQ, name, roleName, group string) error {
-	return setApplicationAuth(iq, name, roleName, group, MemberTypeGroup)
+	return SetApplicationGroupContext(context.Background(), iq, name, roleName, group)
 }
 
-func revokeLT70(iq IQ, authType, authName, roleName, memberType, memberName string) error {
+func revokeLT70(ctx context.Context, iq IQ, authType, authName, roleName, memberType, memberName string) error {
 	var err error
-	role, err := RoleByName(iq, roleName)
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return fmt.Errorf("could not find role with name %s: %v", roleName, err)
+		return fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
 	var (
@@ -313,22 +349,22 @@ func revokeLT70(iq IQ, authType, authName, roleName, memberType, memberName stri
 	)
 	switch authType {
 	case "organization":
-		org, err := GetOrganizationByName(iq, authName)
+		org, err := GetOrganizationByNameContext(ctx, iq, authName)
 		if err == nil {
 			authID = org.ID
 			baseEndpoint = restRoleMembersOrgDeprecated
-			mapping, err = OrganizationAuthorizations(iq, authName)
+			mapping, err = OrganizationAuthorizationsContext(ctx, iq, authName)
 		}
 	case "application":
-		app, err := GetApplicationByPublicID(iq, authName)
+		app, err := GetApplicationByPublicIDContext(ctx, iq, authName)
 		if err == nil {
 			authID = app.ID
 			baseEndpoint = restRoleMembersAppDeprecated
-			mapping, err = ApplicationAuthorizations(iq, authName)
+			mapping, err = ApplicationAuthorizationsContext(ctx, iq, authName)
 		}
 	}
 	if err != nil && mapping != nil {
-		return fmt.Errorf("could not get current authorizations for %s: %v", authName, err)
+		return fmt.Errorf("could not get current authorizations for %s: %w", authName, err)
 	}
 
 	for i, auth := range mapping {
@@ -345,22 +381,22 @@ func revokeLT70(iq IQ, authType, authName, roleName, memberType, memberName stri
 
 	buf, err := json.Marshal(memberMappings{MemberMappings: mapping})
 	if err != nil {
-		return fmt.Errorf("could not create mapping: %v", err)
+		return fmt.Errorf("could not create mapping: %w", err)
 	}
 
 	endpoint := fmt.Sprintf(baseEndpoint, authID)
-	_, _, err = iq.Put(endpoint, bytes.NewBuffer(buf))
+	_, _, err = nexus.PutContext(ctx, iq, endpoint, bytes.NewBuffer(buf))
 	if err != nil {
-		return fmt.Errorf("could not remove role mapping: %v", err)
+		return fmt.Errorf("could not remove role mapping: %w", err)
 	}
 
 	return nil
 }
 
-func revoke(iq IQ, authType, authName, roleName, memberType, memberName string) error {
-	role, err := RoleByName(iq, roleName)
+func revoke(ctx context.Context, iq IQ, authType, authName, roleName, memberType, memberName string) error {
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return fmt.Errorf("could not find role with name %s: %v", roleName, err)
+		return fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
 	var (
@@ -368,7 +404,7 @@ func revoke(iq IQ, authType, authName, roleName, memberType, memberName string)
 	)
 	switch authType {
 	case "organization":
-		org, err := GetOrganizationByName(iq, authName)
+		org, err := GetOrganizationByNameContext(ctx, iq, authName)
 		if err == nil {
 			authID = org.ID
 			switch memberType {
@@ -379,7 +415,7 @@ func revoke(iq IQ, authType, authName, roleName, memberType, memberName string)
 			}
 		}
 	case "application":
-		app, err := GetApplicationByPublicID(iq, authName)
+		app, err := GetApplicationByPublicIDContext(ctx, iq, authName)
 		if err == nil {
 			authID = app.ID
 			switch memberType {
@@ -392,50 +428,70 @@ func revoke(iq IQ, authType, authName, roleName, memberType, memberName string)
 	}
 
 	endpoint := fmt.Sprintf(baseEndpoint, authID, role.ID, memberName)
-	_, err = iq.Del(endpoint)
+	_, err = nexus.DelContext(ctx, iq, endpoint)
 	return err
 }
 
+// RevokeOrganizationUserContext removes a user and role from the named organization
+func RevokeOrganizationUserContext(ctx context.Context, iq IQ, name, roleName, user string) error {
+	if !supports(ctx, iq, FeatureRoleMemberships) {
+		return revokeLT70(ctx, iq, "organization", name, roleName, MemberTypeUser, user)
+	}
+	return revoke(ctx, iq, "organization", name, roleName, MemberTypeUser, user)
+}
+
 // RevokeOrganizationUser removes a user and role from the named organization
 func RevokeOrganizationUser(iq IQ, name, roleName, user string) error {
-	if !hasRev70API(iq) {
-		return revokeLT70(iq, "organization", name, roleName, MemberTypeUser, user)
+	return RevokeOrganizationUserContext(context.Background(), iq, name, roleName, user)
+}
+
+// RevokeOrganizationGroupContext removes a group and role from the named organization
+func RevokeOrganizationGroupContext(ctx context.Context, iq IQ, name, roleName, group string) error {
+	if !supports(ctx, iq, FeatureRoleMemberships) {
+		return revokeLT70(ctx, iq, "organization", name, roleName, MemberTypeGroup, group)
 	}
-	return revoke(iq, "organization", name, roleName, MemberTypeUser, user)
+	return revoke(ctx, iq, "organization", name, roleName, MemberTypeGroup, group)
 }
 
 // RevokeOrganizationGroup removes a group and role from the named organization
 func RevokeOrganizationGroup(iq IQ, name, roleName, group string) error {
-	if !hasRev70API(iq) {
-		return revokeLT70(iq, "organization", name, roleName, MemberTypeGroup, group)
+	return RevokeOrganizationGroupContext(context.Background(), iq, name, roleName, group)
+}
+
+// RevokeApplicationUserContext removes a user and role from the named application
+func RevokeApplicationUserContext(ctx context.Context, iq IQ, name, roleName, user string) error {
+	if !supports(ctx, iq, FeatureRoleMemberships) {
+		return revokeLT70(ctx, iq, "application", name, roleName, MemberTypeUser, user)
 	}
-	return revoke(iq, "organization", name, roleName, MemberTypeGroup, group)
+	return revoke(ctx, iq, "application", name, roleName, MemberTypeUser, user)
 }
 
 // RevokeApplicationUser removes a user and role from the named application
 func RevokeApplicationUser(iq IQ, name, roleName, user string) error {
-	if !hasRev70API(iq) {
-		return revokeLT70(iq, "application", name, roleName, MemberTypeUser, user)
+	return RevokeApplicationUserContext(context.Background(), iq, name, roleName, user)
+}
+
+// RevokeApplicationGroupContext removes a group and role from the named application
+func RevokeApplicationGroupContext(ctx context.Context, iq IQ, name, roleName, group string) error {
+	if !supports(ctx, iq, FeatureRoleMemberships) {
+		return revokeLT70(ctx, iq, "application", name, roleName, MemberTypeGroup, group)
 	}
-	return revoke(iq, "application", name, roleName, MemberTypeUser, user)
+	return revoke(ctx, iq, "application", name, roleName, MemberTypeGroup, group)
 }
 
 // RevokeApplicationGroup removes a group and role from the named application
 func RevokeApplicationGroup(iq IQ, name, roleName, group string) error {
-	if !hasRev70API(iq) {
-		return revokeLT70(iq, "application", name, roleName, MemberTypeGroup, group)
-	}
-	return revoke(iq, "application", name, roleName, MemberTypeGroup, group)
+	return RevokeApplicationGroupContext(context.Background(), iq, name, roleName, group)
 }
 
-func repositoriesAuth(iq IQ, method, roleName, memberType, member string) error {
-	if !hasRev70API(iq) {
-		return fmt.Errorf("did not find revision 70 API")
+func repositoriesAuth(ctx context.Context, iq IQ, method, roleName, memberType, member string) error {
+	if err := Capabilities(iq).Require(ctx, FeatureRoleMemberships); err != nil {
+		return err
 	}
 
-	role, err := RoleByName(iq, roleName)
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return fmt.Errorf("could not find role with name %s: %v", roleName, err)
+		return fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
 	var endpoint string
@@ -448,21 +504,21 @@ func repositoriesAuth(iq IQ, method, roleName, memberType, member string) error
 
 	switch method {
 	case http.MethodPut:
-		_, _, err = iq.Put(endpoint, nil)
+		_, _, err = nexus.PutContext(ctx, iq, endpoint, nil)
 	case http.MethodDelete:
-		_, err = iq.Del(endpoint)
+		_, err = nexus.DelContext(ctx, iq, endpoint)
 	}
 	if err != nil {
-		return fmt.Errorf("could not affect repositories role mapping: %v", err)
+		return fmt.Errorf("could not affect repositories role mapping: %w", err)
 	}
 
 	return nil
 }
 
-func repositoriesAuthorizationsByRoleID(iq IQ, roleID string) ([]MemberMapping, error) {
-	auths, err := RepositoriesAuthorizations(iq)
+func repositoriesAuthorizationsByRoleID(ctx context.Context, iq IQ, roleID string) ([]MemberMapping, error) {
+	auths, err := RepositoriesAuthorizationsContext(ctx, iq)
 	if err != nil {
-		return nil, fmt.Errorf("could not find authorization mappings for repositories: %v", err)
+		return nil, fmt.Errorf("could not find authorization mappings for repositories: %w", err)
 	}
 
 	mappings := make([]MemberMapping, 0)
@@ -475,65 +531,99 @@ func repositoriesAuthorizationsByRoleID(iq IQ, roleID string) ([]MemberMapping,
 	return mappings, nil
 }
 
-// RepositoriesAuthorizations returns the member mappings of all repositories
-func RepositoriesAuthorizations(iq IQ) ([]MemberMapping, error) {
-	body, _, err := iq.Get(restRoleMembersReposGet)
+// RepositoriesAuthorizationsContext returns the member mappings of all repositories
+func RepositoriesAuthorizationsContext(ctx context.Context, iq IQ) ([]MemberMapping, error) {
+	if err := Capabilities(iq).Require(ctx, FeatureRoleMemberships); err != nil {
+		return nil, err
+	}
+
+	body, _, err := nexus.GetContext(ctx, iq, restRoleMembersReposGet)
 	if err != nil {
-		return nil, fmt.Errorf("could not get repositories mappings: %v", err)
+		return nil, fmt.Errorf("could not get repositories mappings: %w", err)
 	}
 
 	var mappings memberMappings
 	err = json.Unmarshal(body, &mappings)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal mapping: %v", err)
+		return nil, fmt.Errorf("could not unmarshal mapping: %w", err)
 	}
 
 	return mappings.MemberMappings, nil
 }
 
-// RepositoriesAuthorizationsByRole returns the member mappings of all repositories which match the given role
-func RepositoriesAuthorizationsByRole(iq IQ, roleName string) ([]MemberMapping, error) {
-	role, err := RoleByName(iq, roleName)
+// RepositoriesAuthorizations returns the member mappings of all repositories
+func RepositoriesAuthorizations(iq IQ) ([]MemberMapping, error) {
+	return RepositoriesAuthorizationsContext(context.Background(), iq)
+}
+
+// RepositoriesAuthorizationsByRoleContext returns the member mappings of all repositories which match the given role
+func RepositoriesAuthorizationsByRoleContext(ctx context.Context, iq IQ, roleName string) ([]MemberMapping, error) {
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return nil, fmt.Errorf("could not find role with name %s: %v", roleName, err)
+		return nil, fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
-	return repositoriesAuthorizationsByRoleID(iq, role.ID)
+	return repositoriesAuthorizationsByRoleID(ctx, iq, role.ID)
+}
+
+// RepositoriesAuthorizationsByRole returns the member mappings of all repositories which match the given role
+func RepositoriesAuthorizationsByRole(iq IQ, roleName string) ([]MemberMapping, error) {
+	return RepositoriesAuthorizationsByRoleContext(context.Background(), iq, roleName)
+}
+
+// SetRepositoriesUserContext sets the role and user that can have access to the repositories
+func SetRepositoriesUserContext(ctx context.Context, iq IQ, roleName, user string) error {
+	return repositoriesAuth(ctx, iq, http.MethodPut, roleName, MemberTypeUser, user)
 }
 
 // SetRepositoriesUser sets the role and user that can have access to the repositories
 func SetRepositoriesUser(iq IQ, roleName, user string) error {
-	return repositoriesAuth(iq, http.MethodPut, roleName, MemberTypeUser, user)
+	return SetRepositoriesUserContext(context.Background(), iq, roleName, user)
+}
+
+// SetRepositoriesGroupContext sets the role and group that can have access to the repositories
+func SetRepositoriesGroupContext(ctx context.Context, iq IQ, roleName, group string) error {
+	return repositoriesAuth(ctx, iq, http.MethodPut, roleName, MemberTypeGroup, group)
 }
 
 // SetRepositoriesGroup sets the role and group that can have access to the repositories
 func SetRepositoriesGroup(iq IQ, roleName, group string) error {
-	return repositoriesAuth(iq, http.MethodPut, roleName, MemberTypeGroup, group)
+	return SetRepositoriesGroupContext(context.Background(), iq, roleName, group)
+}
+
+// RevokeRepositoriesUserContext revoke the role and user that can have access to the repositories
+func RevokeRepositoriesUserContext(ctx context.Context, iq IQ, roleName, user string) error {
+	return repositoriesAuth(ctx, iq, http.MethodDelete, roleName, MemberTypeUser, user)
 }
 
 // RevokeRepositoriesUser revoke the role and user that can have access to the repositories
 func RevokeRepositoriesUser(iq IQ, roleName, user string) error {
-	return repositoriesAuth(iq, http.MethodDelete, roleName, MemberTypeUser, user)
+	return RevokeRepositoriesUserContext(context.Background(), iq, roleName, user)
+}
+
+// RevokeRepositoriesGroupContext revoke the role and group that can have access to the repositories
+func RevokeRepositoriesGroupContext(ctx context.Context, iq IQ, roleName, group string) error {
+	return repositoriesAuth(ctx, iq, http.MethodDelete, roleName, MemberTypeGroup, group)
 }
 
 // RevokeRepositoriesGroup revoke the role and group that can have access to the repositories
 func RevokeRepositoriesGroup(iq IQ, roleName, group string) error {
-	return repositoriesAuth(iq, http.MethodDelete, roleName, MemberTypeGroup, group)
+	return RevokeRepositoriesGroupContext(context.Background(), iq, roleName, group)
 }
 
-func membersByRoleID(iq IQ, roleID string) ([]MemberMapping, error) {
+func membersByRoleID(ctx context.Context, iq IQ, roleID string) ([]MemberMapping, error) {
 	members := make([]MemberMapping, 0)
 
-	if m, err := organizationAuthorizationsByRoleID(iq, roleID); err == nil && len(m) > 0 {
+	if m, err := organizationAuthorizationsByRoleID(ctx, iq, roleID); err == nil && len(m) > 0 {
 		members = append(members, m...)
 	}
 
-	if m, err := applicationAuthorizationsByRoleID(iq, roleID); err == nil && len(m) > 0 {
+	if m, err := applicationAuthorizationsByRoleID(ctx, iq, roleID); err == nil && len(m) > 0 {
 		members = append(members, m...)
 	}
 
-	if hasRev70API(iq) {
-		if m, err := repositoriesAuthorizationsByRoleID(iq, roleID); err == nil && len(m) > 0 {
+	if supports(ctx, iq, FeatureRoleMemberships) {
+		if m, err := repositoriesAuthorizationsByRoleID(ctx, iq, roleID); err == nil && len(m) > 0 {
 			members = append(members, m...)
 		}
 	}
@@ -541,39 +631,53 @@ func membersByRoleID(iq IQ, roleID string) ([]MemberMapping, error) {
 	return members, nil
 }
 
-// MembersByRole returns all users and groups by role name
-func MembersByRole(iq IQ, roleName string) ([]MemberMapping, error) {
-	role, err := RoleByName(iq, roleName)
+// MembersByRoleContext returns all users and groups by role name
+func MembersByRoleContext(ctx context.Context, iq IQ, roleName string) ([]MemberMapping, error) {
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return nil, fmt.Errorf("could not find role with name %s: %v", roleName, err)
+		return nil, fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
-	return membersByRoleID(iq, role.ID)
+	return membersByRoleID(ctx, iq, role.ID)
 }
 
-// GlobalAuthorizations returns all of the users and roles who have the administrator role across all of IQ
-func GlobalAuthorizations(iq IQ) ([]MemberMapping, error) {
-	body, _, err := iq.Get(restRoleMembersGlobalGet)
+// MembersByRole returns all users and groups by role name
+func MembersByRole(iq IQ, roleName string) ([]MemberMapping, error) {
+	return MembersByRoleContext(context.Background(), iq, roleName)
+}
+
+// GlobalAuthorizationsContext returns all of the users and roles who have the administrator role across all of IQ
+func GlobalAuthorizationsContext(ctx context.Context, iq IQ) ([]MemberMapping, error) {
+	if err := Capabilities(iq).Require(ctx, FeatureRoleMemberships); err != nil {
+		return nil, err
+	}
+
+	body, _, err := nexus.GetContext(ctx, iq, restRoleMembersGlobalGet)
 	if err != nil {
-		return nil, fmt.Errorf("could not get global members: %v", err)
+		return nil, fmt.Errorf("could not get global members: %w", err)
 	}
 
 	var mappings memberMappings
 	err = json.Unmarshal(body, &mappings)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal mapping: %v", err)
+		return nil, fmt.Errorf("could not unmarshal mapping: %w", err)
 	}
 
 	return mappings.MemberMappings, nil
 }
 
-func globalAuth(iq IQ, method, roleName, memberType, member string) error {
-	if !hasRev70API(iq) {
-		return fmt.Errorf("did not find revision 70 API")
+// GlobalAuthorizations returns all of the users and roles who have the administrator role across all of IQ
+func GlobalAuthorizations(iq IQ) ([]MemberMapping, error) {
+	return GlobalAuthorizationsContext(context.Background(), iq)
+}
+
+func globalAuth(ctx context.Context, iq IQ, method, roleName, memberType, member string) error {
+	if err := Capabilities(iq).Require(ctx, FeatureRoleMemberships); err != nil {
+		return err
 	}
 
-	role, err := RoleByName(iq, roleName)
+	role, err := RoleByNameContext(ctx, iq, roleName)
 	if err != nil {
-		return fmt.Errorf("could not find role with name %s: %v", roleName, err)
+		return fmt.Errorf("could not find role with name %s: %w", roleName, err)
 	}
 
 	var endpoint string
@@ -586,33 +690,53 @@ func globalAuth(iq IQ, method, roleName, memberType, member string) error {
 
 	switch method {
 	case http.MethodPut:
-		_, _, err = iq.Put(endpoint, nil)
+		_, _, err = nexus.PutContext(ctx, iq, endpoint, nil)
 	case http.MethodDelete:
-		_, err = iq.Del(endpoint)
+		_, err = nexus.DelContext(ctx, iq, endpoint)
 	}
 	if err != nil {
-		return fmt.Errorf("could not affect global role mapping: %v", err)
+		return fmt.Errorf("could not affect global role mapping: %w", err)
 	}
 
 	return nil
 }
 
+// SetGlobalUserContext sets the role and user that can have access to the repositories
+func SetGlobalUserContext(ctx context.Context, iq IQ, roleName, user string) error {
+	return globalAuth(ctx, iq, http.MethodPut, roleName, MemberTypeUser, user)
+}
+
 // SetGlobalUser sets the role and user that can have access to the repositories
 func SetGlobalUser(iq IQ, roleName, user string) error {
-	return globalAuth(iq, http.MethodPut, roleName, MemberTypeUser, user)
+	return SetGlobalUserContext(context.Background(), iq, roleName, user)
+}
+
+// SetGlobalGroupContext sets the role and group that can have access to the global
+func SetGlobalGroupContext(ctx context.Context, iq IQ, roleName, group string) error {
+	return globalAuth(ctx, iq, http.MethodPut, roleName, MemberTypeGroup, group)
 }
 
 // SetGlobalGroup sets the role and group that can have access to the global
 func SetGlobalGroup(iq IQ, roleName, group string) error {
-	return globalAuth(iq, http.MethodPut, roleName, MemberTypeGroup, group)
+	return SetGlobalGroupContext(context.Background(), iq, roleName, group)
+}
+
+// RevokeGlobalUserContext revoke the role and user that can have access to the global
+func RevokeGlobalUserContext(ctx context.Context, iq IQ, roleName, user string) error {
+	return globalAuth(ctx, iq, http.MethodDelete, roleName, MemberTypeUser, user)
 }
 
 // RevokeGlobalUser revoke the role and user that can have access to the global
 func RevokeGlobalUser(iq IQ, roleName, user string) error {
-	return globalAuth(iq, http.MethodDelete, roleName, MemberTypeUser, user)
+	return RevokeGlobalUserContext(context.Background(), iq, roleName, user)
+}
+
+// RevokeGlobalGroupContext revoke the role and group that can have access to the global
+func RevokeGlobalGroupContext(ctx context.Context, iq IQ, roleName, group string) error {
+	return globalAuth(ctx, iq, http.MethodDelete, roleName, MemberTypeGroup, group)
 }
 
 // RevokeGlobalGroup revoke the role and group that can have access to the global
 func RevokeGlobalGroup(iq IQ, roleName, group string) error {
-	return globalAuth(iq, http.MethodDelete, roleName, MemberTypeGroup, group)
+	return RevokeGlobalGroupContext(context.Background(), iq, roleName, group)
 }
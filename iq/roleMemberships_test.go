@@ -1,6 +1,7 @@
 package nexusiq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -363,6 +364,8 @@ func roleMembershipsTestFunc(t *testing.T, w http.ResponseWriter, r *http.Reques
 func roleMembershipsTestIQ(t *testing.T, useDeprecated bool) (IQ, *httptest.Server) {
 	return newTestIQ(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		switch {
+		case r.URL.Path[1:] == restProductVersion:
+			w.WriteHeader(http.StatusNotFound)
 		case r.URL.Path[1:] == restOrganization:
 			organizationTestFunc(t, w, r)
 		case r.URL.Path[1:] == restApplication:
@@ -860,7 +863,7 @@ func testMembersByRole(t *testing.T, iq IQ) {
 			}
 		}
 	}
-	if hasRev70API(iq) {
+	if supports(context.Background(), iq, FeatureRoleMemberships) {
 		for _, m := range dummyRoleMappingsRepos {
 			if m.RoleID == role.ID {
 				want = append(want, m)
@@ -1,9 +1,11 @@
 package nexusiq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -22,29 +24,36 @@ type Role struct {
 	Description string `json:"description"`
 }
 
-// Roles returns a slice of all the roles in the IQ instance
-func Roles(iq IQ) ([]Role, error) {
-	body, resp, err := iq.Get(restRoles)
-	if resp.StatusCode == http.StatusNotFound {
-		body, _, err = iq.Get(restRolesDeprecated)
+// RolesContext returns a slice of all the roles in the IQ instance
+func RolesContext(ctx context.Context, iq IQ) ([]Role, error) {
+	endpoint := restRoles
+	if !supports(ctx, iq, FeatureRoles) {
+		endpoint = restRolesDeprecated
 	}
+
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve roles: %v", err)
+		return nil, fmt.Errorf("could not retrieve roles: %w", err)
 	}
 
 	var list rolesResponse
 	if err = json.Unmarshal(body, &list); err != nil {
-		return nil, fmt.Errorf("could not marshal roles response: %v", err)
+		return nil, fmt.Errorf("could not marshal roles response: %w", err)
 	}
 
 	return list.Roles, nil
 }
 
-// RoleByName returns the named role
-func RoleByName(iq IQ, name string) (Role, error) {
-	roles, err := Roles(iq)
+// Roles returns a slice of all the roles in the IQ instance
+func Roles(iq IQ) ([]Role, error) {
+	return RolesContext(context.Background(), iq)
+}
+
+// RoleByNameContext returns the named role
+func RoleByNameContext(ctx context.Context, iq IQ, name string) (Role, error) {
+	roles, err := RolesContext(ctx, iq)
 	if err != nil {
-		return Role{}, fmt.Errorf("did not find role with name %s: %v", name, err)
+		return Role{}, fmt.Errorf("did not find role with name %s: %w", name, err)
 	}
 
 	for _, r := range roles {
@@ -53,15 +62,25 @@ func RoleByName(iq IQ, name string) (Role, error) {
 		}
 	}
 
-	return Role{}, fmt.Errorf("did not find role with name %s", name)
+	return Role{}, fmt.Errorf("did not find role with name %s: %w", name, nexus.ErrNotFound)
 }
 
-// GetSystemAdminID returns the identifier of the System Administrator role
-func GetSystemAdminID(iq IQ) (string, error) {
-	role, err := RoleByName(iq, "System Administrator")
+// RoleByName returns the named role
+func RoleByName(iq IQ, name string) (Role, error) {
+	return RoleByNameContext(context.Background(), iq, name)
+}
+
+// GetSystemAdminIDContext returns the identifier of the System Administrator role
+func GetSystemAdminIDContext(ctx context.Context, iq IQ) (string, error) {
+	role, err := RoleByNameContext(ctx, iq, "System Administrator")
 	if err != nil {
-		return "", fmt.Errorf("did not get admin role: %v", err)
+		return "", fmt.Errorf("did not get admin role: %w", err)
 	}
 
 	return role.ID, nil
 }
+
+// GetSystemAdminID returns the identifier of the System Administrator role
+func GetSystemAdminID(iq IQ) (string, error) {
+	return GetSystemAdminIDContext(context.Background(), iq)
+}
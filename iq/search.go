@@ -2,9 +2,9 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
 
 	nexus "github.com/sonatype-nexus-community/gonexus"
@@ -117,18 +117,23 @@ func NewSearchQueryBuilder() *SearchQueryBuilder {
 	return b
 }
 
-// SearchComponents allows searching the indicated IQ instance for specific components
-func SearchComponents(iq IQ, query nexus.SearchQueryBuilder) ([]SearchResult, error) {
+// SearchComponentsContext allows searching the indicated IQ instance for specific components
+func SearchComponentsContext(ctx context.Context, iq IQ, query nexus.SearchQueryBuilder) ([]SearchResult, error) {
 	endpoint := restSearchComponent + "?" + query.Build()
-	body, resp, err := iq.Get(endpoint)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("could not find component: %v", err)
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not find component: %w", err)
 	}
 
 	var searchResp searchResponse
 	if err := json.Unmarshal(body, &searchResp); err != nil {
-		return nil, fmt.Errorf("could not process response: %v", err)
+		return nil, fmt.Errorf("could not process response: %w", err)
 	}
 
 	return searchResp.Results, nil
 }
+
+// SearchComponents allows searching the indicated IQ instance for specific components
+func SearchComponents(iq IQ, query nexus.SearchQueryBuilder) ([]SearchResult, error) {
+	return SearchComponentsContext(context.Background(), iq, query)
+}
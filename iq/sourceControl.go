@@ -2,9 +2,11 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -20,10 +22,10 @@ type SourceControlEntry struct {
 	Token         string `json:"token"`
 }
 
-func getSourceControlEntryByInternalID(iq IQ, applicationID string) (entry SourceControlEntry, err error) {
+func getSourceControlEntryByInternalID(ctx context.Context, iq IQ, applicationID string) (entry SourceControlEntry, err error) {
 	endpoint := fmt.Sprintf(restSourceControl, applicationID)
 
-	body, _, err := iq.Get(endpoint)
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
 	if err != nil {
 		return
 	}
@@ -33,40 +35,62 @@ func getSourceControlEntryByInternalID(iq IQ, applicationID string) (entry Sourc
 	return
 }
 
-// GetSourceControlEntry lists of all of the Source Control entries for the given application
-func GetSourceControlEntry(iq IQ, applicationID string) (SourceControlEntry, error) {
-	appInfo, err := GetApplicationByPublicID(iq, applicationID)
+// GetSourceControlEntryContext lists of all of the Source Control entries for the given application
+func GetSourceControlEntryContext(ctx context.Context, iq IQ, applicationID string) (SourceControlEntry, error) {
+	appInfo, err := GetApplicationByPublicIDContext(ctx, iq, applicationID)
 	if err != nil {
-		return SourceControlEntry{}, fmt.Errorf("no source control entry for '%s': %v", applicationID, err)
+		return SourceControlEntry{}, fmt.Errorf("no source control entry for '%s': %w", applicationID, err)
 	}
 
-	return getSourceControlEntryByInternalID(iq, appInfo.ID)
+	return getSourceControlEntryByInternalID(ctx, iq, appInfo.ID)
 }
 
-// GetAllSourceControlEntries lists of all of the Source Control entries in the IQ instance
-func GetAllSourceControlEntries(iq IQ) ([]SourceControlEntry, error) {
-	apps, err := GetAllApplications(iq)
+// GetSourceControlEntry lists of all of the Source Control entries for the given application
+func GetSourceControlEntry(iq IQ, applicationID string) (SourceControlEntry, error) {
+	return GetSourceControlEntryContext(context.Background(), iq, applicationID)
+}
+
+// GetAllSourceControlEntriesContext lists of all of the Source Control entries in the IQ instance
+func GetAllSourceControlEntriesContext(ctx context.Context, iq IQ) ([]SourceControlEntry, error) {
+	apps, err := GetAllApplicationsContext(ctx, iq)
+	if err != nil {
+		return nil, fmt.Errorf("no source control entries: %w", err)
+	}
+
+	// Applications without an entry are skipped
+	found := make([]*SourceControlEntry, len(apps))
+	err = nexus.FanOut(ctx, iq, len(apps), 1, func(ctx context.Context, i int) error {
+		if entry, err := getSourceControlEntryByInternalID(ctx, iq, apps[i].ID); err == nil {
+			found[i] = &entry
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("no source control entries: %v", err)
+		return nil, fmt.Errorf("no source control entries: %w", err)
 	}
 
 	entries := make([]SourceControlEntry, 0)
-	for _, app := range apps {
-		if entry, err := getSourceControlEntryByInternalID(iq, app.ID); err == nil {
-			entries = append(entries, entry)
+	for _, entry := range found {
+		if entry != nil {
+			entries = append(entries, *entry)
 		}
 	}
 
 	return entries, nil
 }
 
-// CreateSourceControlEntry creates a source control entry in IQ
-func CreateSourceControlEntry(iq IQ, applicationID, repositoryURL, token string) error {
+// GetAllSourceControlEntries lists of all of the Source Control entries in the IQ instance
+func GetAllSourceControlEntries(iq IQ) ([]SourceControlEntry, error) {
+	return GetAllSourceControlEntriesContext(context.Background(), iq)
+}
+
+// CreateSourceControlEntryContext creates a source control entry in IQ
+func CreateSourceControlEntryContext(ctx context.Context, iq IQ, applicationID, repositoryURL, token string) error {
 	doError := func(err error) error {
-		return fmt.Errorf("source control entry not created for '%s': %v", applicationID, err)
+		return fmt.Errorf("source control entry not created for '%s': %w", applicationID, err)
 	}
 
-	appInfo, err := GetApplicationByPublicID(iq, applicationID)
+	appInfo, err := GetApplicationByPublicIDContext(ctx, iq, applicationID)
 	if err != nil {
 		return doError(err)
 	}
@@ -77,20 +101,25 @@ func CreateSourceControlEntry(iq IQ, applicationID, repositoryURL, token string)
 	}
 
 	endpoint := fmt.Sprintf(restSourceControl, appInfo.ID)
-	if _, _, err = iq.Post(endpoint, bytes.NewBuffer(request)); err != nil {
+	if _, _, err = nexus.PostContext(ctx, iq, endpoint, bytes.NewBuffer(request)); err != nil {
 		return doError(err)
 	}
 
 	return nil
 }
 
-// UpdateSourceControlEntry updates a source control entry in IQ
-func UpdateSourceControlEntry(iq IQ, applicationID, repositoryURL, token string) error {
+// CreateSourceControlEntry creates a source control entry in IQ
+func CreateSourceControlEntry(iq IQ, applicationID, repositoryURL, token string) error {
+	return CreateSourceControlEntryContext(context.Background(), iq, applicationID, repositoryURL, token)
+}
+
+// UpdateSourceControlEntryContext updates a source control entry in IQ
+func UpdateSourceControlEntryContext(ctx context.Context, iq IQ, applicationID, repositoryURL, token string) error {
 	doError := func(err error) error {
-		return fmt.Errorf("source control entry not updated for '%s': %v", applicationID, err)
+		return fmt.Errorf("source control entry not updated for '%s': %w", applicationID, err)
 	}
 
-	appInfo, err := GetApplicationByPublicID(iq, applicationID)
+	appInfo, err := GetApplicationByPublicIDContext(ctx, iq, applicationID)
 	if err != nil {
 		return doError(err)
 	}
@@ -101,61 +130,76 @@ func UpdateSourceControlEntry(iq IQ, applicationID, repositoryURL, token string)
 	}
 
 	endpoint := fmt.Sprintf(restSourceControl, appInfo.ID)
-	if _, _, err = iq.Put(endpoint, bytes.NewBuffer(request)); err != nil {
+	if _, _, err = nexus.PutContext(ctx, iq, endpoint, bytes.NewBuffer(request)); err != nil {
 		return doError(err)
 	}
 
 	return nil
 }
 
-func deleteSourceControlEntry(iq IQ, appInternalID, sourceControlID string) error {
+// UpdateSourceControlEntry updates a source control entry in IQ
+func UpdateSourceControlEntry(iq IQ, applicationID, repositoryURL, token string) error {
+	return UpdateSourceControlEntryContext(context.Background(), iq, applicationID, repositoryURL, token)
+}
+
+func deleteSourceControlEntry(ctx context.Context, iq IQ, appInternalID, sourceControlID string) error {
 	endpoint := fmt.Sprintf(restSourceControlDelete, appInternalID, sourceControlID)
 
-	resp, err := iq.Del(endpoint)
-	if err != nil && resp.StatusCode != http.StatusNoContent {
-		return err
+	_, err := nexus.DelContext(ctx, iq, endpoint)
+	return err
+}
+
+// DeleteSourceControlEntryContext deletes a source control entry in IQ
+func DeleteSourceControlEntryContext(ctx context.Context, iq IQ, applicationID, sourceControlID string) error {
+	appInfo, err := GetApplicationByPublicIDContext(ctx, iq, applicationID)
+	if err != nil {
+		return fmt.Errorf("source control entry not deleted from '%s': %w", applicationID, err)
 	}
 
-	return nil
+	return deleteSourceControlEntry(ctx, iq, appInfo.ID, sourceControlID)
 }
 
 // DeleteSourceControlEntry deletes a source control entry in IQ
 func DeleteSourceControlEntry(iq IQ, applicationID, sourceControlID string) error {
-	appInfo, err := GetApplicationByPublicID(iq, applicationID)
-	if err != nil {
-		return fmt.Errorf("source control entry not deleted from '%s': %v", applicationID, err)
-	}
-
-	return deleteSourceControlEntry(iq, appInfo.ID, sourceControlID)
+	return DeleteSourceControlEntryContext(context.Background(), iq, applicationID, sourceControlID)
 }
 
-// DeleteSourceControlEntryByApp deletes a source control entry in IQ for the given application
-func DeleteSourceControlEntryByApp(iq IQ, applicationID string) error {
+// DeleteSourceControlEntryByAppContext deletes a source control entry in IQ for the given application
+func DeleteSourceControlEntryByAppContext(ctx context.Context, iq IQ, applicationID string) error {
 	doError := func(err error) error {
-		return fmt.Errorf("source control entry not deleted from '%s': %v", applicationID, err)
+		return fmt.Errorf("source control entry not deleted from '%s': %w", applicationID, err)
 	}
 
-	appInfo, err := GetApplicationByPublicID(iq, applicationID)
+	appInfo, err := GetApplicationByPublicIDContext(ctx, iq, applicationID)
 	if err != nil {
 		return doError(err)
 	}
 
-	entry, err := getSourceControlEntryByInternalID(iq, appInfo.ID)
+	entry, err := getSourceControlEntryByInternalID(ctx, iq, appInfo.ID)
 	if err != nil {
 		return doError(err)
 	}
 
-	return deleteSourceControlEntry(iq, appInfo.ID, entry.ID)
+	return deleteSourceControlEntry(ctx, iq, appInfo.ID, entry.ID)
+}
+
+// DeleteSourceControlEntryByApp deletes a source control entry in IQ for the given application
+func DeleteSourceControlEntryByApp(iq IQ, applicationID string) error {
+	return DeleteSourceControlEntryByAppContext(context.Background(), iq, applicationID)
 }
 
 // DeleteSourceControlEntryByEntry deletes a source control entry in IQ for the given entry ID
 /*
-func DeleteSourceControlEntryByEntry(iq IQ, sourceControlID string) error {
-	entry, err := getSourceControlEntryByInternalID(iq, appInfo.ID)
+func DeleteSourceControlEntryByEntryContext(ctx context.Context, iq IQ, sourceControlID string) error {
+	entry, err := getSourceControlEntryByInternalID(ctx, iq, appInfo.ID)
 	if err != nil {
 		return err
 	}
 
-	return deleteSourceControlEntry(iq, entry.ApplicationID, entry.ID)
+	return deleteSourceControlEntry(ctx, iq, entry.ApplicationID, entry.ID)
+}
+
+func DeleteSourceControlEntryByEntry(iq IQ, sourceControlID string) error {
+	return DeleteSourceControlEntryByEntryContext(context.Background(), iq, sourceControlID)
 }
 */
@@ -1,6 +1,7 @@
 package nexusiq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -8,7 +9,12 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 var dummyEntries = []SourceControlEntry{
@@ -98,7 +104,7 @@ func sourceControlTestFunc(t *testing.T, w http.ResponseWriter, r *http.Request)
 	}
 }
 
-func sourceControlTestIQ(t *testing.T) (iq IQ, mock *httptest.Server) {
+func sourceControlTestIQ(t *testing.T, opts ...nexus.Option) (iq IQ, mock *httptest.Server) {
 	return newTestIQ(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 		switch {
 		case strings.HasPrefix(r.URL.Path[1:], restApplication):
@@ -106,7 +112,7 @@ func sourceControlTestIQ(t *testing.T) (iq IQ, mock *httptest.Server) {
 		default:
 			sourceControlTestFunc(t, w, r)
 		}
-	})
+	}, opts...)
 }
 
 func TestGetSourceControlEntryByInternalID(t *testing.T) {
@@ -115,7 +121,7 @@ func TestGetSourceControlEntryByInternalID(t *testing.T) {
 
 	dummyEntryIdx := 2
 
-	entry, err := getSourceControlEntryByInternalID(iq, dummyEntries[dummyEntryIdx].ApplicationID)
+	entry, err := getSourceControlEntryByInternalID(context.Background(), iq, dummyEntries[dummyEntryIdx].ApplicationID)
 	if err != nil {
 		t.Error(err)
 	}
@@ -155,6 +161,126 @@ func TestGetAllSourceControlEntries(t *testing.T) {
 	t.Logf("%v\n", entries)
 }
 
+func TestGetAllSourceControlEntriesMaxInFlight(t *testing.T) {
+	var current, max int32
+	iq, mock := sourceControlTestIQ(t,
+		nexus.WithLimiter(nexus.NewLimiter(nexus.RateLimit{MaxInFlight: 2})),
+		nexus.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return nexus.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				if n := atomic.AddInt32(&current, 1); n > atomic.LoadInt32(&max) {
+					atomic.StoreInt32(&max, n)
+				}
+				defer atomic.AddInt32(&current, -1)
+				time.Sleep(5 * time.Millisecond)
+				return next.RoundTrip(r)
+			})
+		}),
+	)
+	defer mock.Close()
+
+	entries, err := GetAllSourceControlEntriesContext(context.Background(), iq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(entries, dummyEntries) {
+		t.Errorf("Expected the entries in the order of the applications but got %v", entries)
+	}
+	if n := atomic.LoadInt32(&max); n > 2 {
+		t.Errorf("Expected at most 2 requests in flight but found %d", n)
+	}
+}
+
+func TestGetAllSourceControlEntriesSequential(t *testing.T) {
+	var current, max int32
+	iq, mock := sourceControlTestIQ(t,
+		nexus.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return nexus.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				if n := atomic.AddInt32(&current, 1); n > atomic.LoadInt32(&max) {
+					atomic.StoreInt32(&max, n)
+				}
+				defer atomic.AddInt32(&current, -1)
+				time.Sleep(5 * time.Millisecond)
+				return next.RoundTrip(r)
+			})
+		}),
+	)
+	defer mock.Close()
+
+	if _, err := GetAllSourceControlEntriesContext(context.Background(), iq); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&max); n != 1 {
+		t.Errorf("Expected one request at a time without a MaxInFlight cap but found %d", n)
+	}
+}
+
+// TestGetAllSourceControlEntriesConcurrentSetters is meant to be run with -race
+func TestGetAllSourceControlEntriesConcurrentSetters(t *testing.T) {
+	iq, mock := sourceControlTestIQ(t,
+		nexus.WithLogger(nexus.NopLogger),
+		nexus.WithTelemetry(nexus.NewRecorder()),
+		nexus.WithLimiter(nexus.NewLimiter(nexus.RateLimit{MaxInFlight: 2})),
+	)
+	defer mock.Close()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			iq.SetDebug(i%2 == 0)
+			iq.SetCertFile("")
+			iq.Info()
+		}
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entries, err := GetAllSourceControlEntries(iq)
+			if err != nil {
+				t.Error(err)
+			}
+			if !reflect.DeepEqual(entries, dummyEntries) {
+				t.Errorf("Unexpected entries %v", entries)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(done)
+	wg.Wait()
+}
+
+func TestGetAllSourceControlEntriesTelemetry(t *testing.T) {
+	recorder := nexus.NewRecorder()
+	iq, mock := sourceControlTestIQ(t, nexus.WithTelemetry(recorder))
+	defer mock.Close()
+
+	if _, err := GetAllSourceControlEntries(iq); err != nil {
+		t.Fatal(err)
+	}
+
+	route := nexus.Attribute{Key: nexus.AttrEndpoint, Value: "api/v2/sourceControl/{id}"}
+	if n := recorder.Counter(nexus.MetricRequests, route); n != int64(len(dummyApps)) {
+		t.Errorf("Expected %d requests to %v but got %d", len(dummyApps), route.Value, n)
+	}
+	for _, s := range recorder.Spans() {
+		if s.Name != "GET api/v2/applications" && s.Name != "GET api/v2/sourceControl/{id}" {
+			t.Errorf("Unexpected span name %q", s.Name)
+		}
+	}
+}
+
 func TestGetSourceControlEntry(t *testing.T) {
 	iq, mock := sourceControlTestIQ(t)
 	defer mock.Close()
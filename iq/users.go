@@ -2,9 +2,11 @@ package nexusiq
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -21,12 +23,12 @@ type User struct {
 	Password  string `json:"password,omitempty"`
 }
 
-// GetUser returns user details for the given name
-func GetUser(iq IQ, username string) (user User, err error) {
+// GetUserContext returns user details for the given name
+func GetUserContext(ctx context.Context, iq IQ, username string) (user User, err error) {
 	endpoint := fmt.Sprintf(restUsers, username)
-	body, _, err := iq.Get(endpoint)
+	body, _, err := nexus.GetContext(ctx, iq, endpoint)
 	if err != nil {
-		return user, fmt.Errorf("could not retrieve details on username %s: %v", username, err)
+		return user, fmt.Errorf("could not retrieve details on username %s: %w", username, err)
 	}
 
 	err = json.Unmarshal(body, &user)
@@ -34,32 +36,42 @@ func GetUser(iq IQ, username string) (user User, err error) {
 	return user, err
 }
 
-// SetUser creates a new user
-func SetUser(iq IQ, user User) (err error) {
+// GetUser returns user details for the given name
+func GetUser(iq IQ, username string) (user User, err error) {
+	return GetUserContext(context.Background(), iq, username)
+}
+
+// SetUserContext creates a new user
+func SetUserContext(ctx context.Context, iq IQ, user User) (err error) {
 	buf, err := json.Marshal(user)
 	if err != nil {
-		return fmt.Errorf("could not read user details: %v", err)
+		return fmt.Errorf("could not read user details: %w", err)
 	}
 	str := bytes.NewBuffer(buf)
 
-	if _, er := GetUser(iq, user.Username); er != nil {
-		_, resp, er := iq.Post(restUsersPost, str)
-		if er != nil && resp.StatusCode != http.StatusNoContent {
-			return er
-		}
+	if _, er := GetUserContext(ctx, iq, user.Username); er != nil {
+		_, _, err = nexus.PostContext(ctx, iq, restUsersPost, str)
 	} else {
 		endpoint := fmt.Sprintf(restUsers, user.Username)
-		_, _, err = iq.Put(endpoint, str)
+		_, _, err = nexus.PutContext(ctx, iq, endpoint, str)
 	}
 
 	return err
 }
 
+// SetUser creates a new user
+func SetUser(iq IQ, user User) (err error) {
+	return SetUserContext(context.Background(), iq, user)
+}
+
+// DeleteUserContext removes the named user
+func DeleteUserContext(ctx context.Context, iq IQ, username string) error {
+	endpoint := fmt.Sprintf(restUsers, username)
+	_, err := nexus.DelContext(ctx, iq, endpoint)
+	return err
+}
+
 // DeleteUser removes the named user
 func DeleteUser(iq IQ, username string) error {
-	endpoint := fmt.Sprintf(restUsers, username)
-	if resp, err := iq.Del(endpoint); err != nil && resp.StatusCode != http.StatusNoContent {
-		return err
-	}
-	return nil
+	return DeleteUserContext(context.Background(), iq, username)
 }
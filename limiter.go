@@ -0,0 +1,215 @@
+package nexus
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultFanOut is the number of concurrent requests made by the helpers which were written to send
+// requests concurrently, such as nexusiq.GetRemediationsByAppReport, when the client has no MaxInFlight cap
+const DefaultFanOut = 20
+
+// RateLimit describes how fast, and how many concurrent, requests are sent to a server
+type RateLimit struct {
+	// RequestsPerSecond is the average rate at which requests are sent. Zero means unlimited
+	RequestsPerSecond float64 `json:"requestsPerSecond,omitempty" yaml:"requestsPerSecond,omitempty"`
+
+	// Burst is the number of requests which can be sent at once before the rate applies. Defaults to 1
+	Burst int `json:"burst,omitempty" yaml:"burst,omitempty"`
+
+	// MaxInFlight caps the number of requests awaiting a response, or whose body is being read.
+	// Zero means unlimited
+	MaxInFlight int `json:"maxInFlight,omitempty" yaml:"maxInFlight,omitempty"`
+}
+
+// Limiter enforces a RateLimit with a token bucket and a semaphore.
+// It can be shared by several clients of the same server, so that they are limited together
+type Limiter struct {
+	limit RateLimit
+	slots chan struct{}
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates a Limiter which starts with a full bucket
+func NewLimiter(limit RateLimit) *Limiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	l := &Limiter{limit: limit, tokens: float64(limit.Burst)}
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// Limit returns the limits enforced by the Limiter
+func (l *Limiter) Limit() RateLimit {
+	if l == nil {
+		return RateLimit{}
+	}
+	return l.limit
+}
+
+// Wait blocks until the rate allows another request, or the context is done
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil || l.limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait until it is available
+func (l *Limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if !l.last.IsZero() {
+		elapsed := now.Sub(l.last).Seconds()
+		l.tokens = math.Min(float64(l.limit.Burst), l.tokens+elapsed*l.limit.RequestsPerSecond)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.limit.RequestsPerSecond * float64(time.Second))
+}
+
+// Acquire waits for an in-flight slot and for the rate to allow a request.
+// The returned function releases the slot and must be called once the request is complete
+func (l *Limiter) Acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release = func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		var once sync.Once
+		release = func() { once.Do(func() { <-l.slots }) }
+	}
+
+	if err = l.Wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// releaseBody releases the in-flight slot of a request once its response body is closed
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+func (s *DefaultClient) limiter() *Limiter {
+	return s.rateLimiter
+}
+
+// clientLimiter returns the Limiter of the client, or of the client it wraps
+func clientLimiter(client Client) *Limiter {
+	for {
+		switch c := client.(type) {
+		case interface{ limiter() *Limiter }:
+			return c.limiter()
+		case interface{ Unwrap() Client }:
+			client = c.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// FanOut calls fn for each index from 0 to n-1, concurrently but no more than the MaxInFlight cap of
+// the client's Limiter. Without a cap, no more than fanOut calls are made at once, which is 1 for helpers
+// which send their requests one after the other. The first error cancels the remaining calls and is returned
+func FanOut(ctx context.Context, client Client, n, fanOut int, fn func(ctx context.Context, i int) error) error {
+	workers := fanOut
+	if max := clientLimiter(client).Limit().MaxInFlight; max > 0 {
+		workers = max
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	indices := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				if err := fn(ctx, i); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
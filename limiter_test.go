@@ -0,0 +1,188 @@
+package nexus
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyServer serves requests slowly and records the highest number of requests it served concurrently
+func concurrencyServer(delay time.Duration) (*httptest.Server, *int32) {
+	var current, max int32
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		w.Write([]byte("ok"))
+	}))
+	return mock, &max
+}
+
+func TestLimiterRate(t *testing.T) {
+	limiter := NewLimiter(RateLimit{RequestsPerSecond: 50, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 7; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The burst is free, the remaining 5 requests are spaced by 20ms
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected the requests to be rate limited but they took %s", elapsed)
+	}
+}
+
+func TestLimiterWaitCanceled(t *testing.T) {
+	limiter := NewLimiter(RateLimit{RequestsPerSecond: 0.1})
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the wait to be canceled but got %v", err)
+	}
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	mock, max := concurrencyServer(20 * time.Millisecond)
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL}, WithLimiter(NewLimiter(RateLimit{MaxInFlight: 3})))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 12; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Get("test"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(max); n > 3 {
+		t.Errorf("Expected at most 3 requests in flight but found %d", n)
+	}
+}
+
+func TestLimiterStreamHoldsSlot(t *testing.T) {
+	mock, _ := concurrencyServer(0)
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL}, WithLimiter(NewLimiter(RateLimit{MaxInFlight: 1})))
+
+	request, err := client.NewRequest(http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _, err := client.Stream(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err = client.GetContext(ctx, "test"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the request to wait for the unread stream but got %v", err)
+	}
+
+	ioutil.ReadAll(body)
+	body.Close()
+
+	if _, _, err = client.Get("test"); err != nil {
+		t.Errorf("Expected closing the stream to release its slot but got %v", err)
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	mock, max := concurrencyServer(10 * time.Millisecond)
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL}, WithLimiter(NewLimiter(RateLimit{MaxInFlight: 4})))
+
+	var workers, calls int32
+	results := make([]int, 25)
+	err := FanOut(context.Background(), client, len(results), 1, func(ctx context.Context, i int) error {
+		if n := atomic.AddInt32(&workers, 1); n > 4 {
+			t.Errorf("Expected at most 4 concurrent calls but found %d", n)
+		}
+		defer atomic.AddInt32(&workers, -1)
+		atomic.AddInt32(&calls, 1)
+
+		_, _, err := client.GetContext(ctx, "test")
+		results[i] = i
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 25 || atomic.LoadInt32(max) > 4 {
+		t.Errorf("Expected 25 calls with at most 4 in flight but got %d calls and %d in flight", calls, *max)
+	}
+	for i, r := range results {
+		if r != i {
+			t.Fatalf("Expected every index to be called but %d was not", i)
+		}
+	}
+}
+
+func TestFanOutWithoutCap(t *testing.T) {
+	for _, fanOut := range []int{1, 3} {
+		var workers, max int32
+		err := FanOut(context.Background(), new(DefaultClient), 12, fanOut, func(ctx context.Context, i int) error {
+			if n := atomic.AddInt32(&workers, 1); n > atomic.LoadInt32(&max) {
+				atomic.StoreInt32(&max, n)
+			}
+			defer atomic.AddInt32(&workers, -1)
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n := atomic.LoadInt32(&max); n > int32(fanOut) {
+			t.Errorf("Expected at most %d concurrent calls without a cap but found %d", fanOut, n)
+		}
+	}
+}
+
+func TestFanOutError(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	var calls int32
+	err := FanOut(context.Background(), new(DefaultClient), 1000, DefaultFanOut, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 3 {
+			return errFailed
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if !errors.Is(err, errFailed) {
+		t.Errorf("Expected the first error but got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n > DefaultFanOut+1 {
+		t.Errorf("Expected the remaining calls to be canceled but %d were made", n)
+	}
+}
@@ -0,0 +1,125 @@
+package nexus
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Logger is the structured logger which the library writes to.
+// The keysAndValues are alternating keys and values describing the message
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// NopLogger discards all messages
+var NopLogger Logger = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// defaultLogger is used by clients which were not given a Logger
+var defaultLogger = NewStdLogger(log.New(os.Stderr, "", log.LstdFlags))
+
+// NewStdLogger creates a Logger which writes to the given standard library logger
+func NewStdLogger(l *log.Logger) Logger {
+	return stdLogger{l}
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+func (s stdLogger) output(level, msg string, keysAndValues []interface{}) {
+	var buf strings.Builder
+	buf.WriteString(level)
+	buf.WriteString(": ")
+	buf.WriteString(msg)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		var value interface{} = "(MISSING)"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		fmt.Fprintf(&buf, " %v=%q", keysAndValues[i], fmt.Sprint(value))
+	}
+
+	s.l.Output(3, buf.String())
+}
+
+func (s stdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	s.output("debug", msg, keysAndValues)
+}
+
+func (s stdLogger) Info(msg string, keysAndValues ...interface{}) {
+	s.output("info", msg, keysAndValues)
+}
+
+func (s stdLogger) Warn(msg string, keysAndValues ...interface{}) {
+	s.output("warning", msg, keysAndValues)
+}
+
+func (s stdLogger) Error(msg string, keysAndValues ...interface{}) {
+	s.output("error", msg, keysAndValues)
+}
+
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders lists the headers which carry credentials
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// credentialEndpoints are the endpoints whose requests carry a credential as their whole body,
+// such as the plain text password sent to change the password of an RM user
+var credentialEndpoints = []string{"/change-password"}
+
+var (
+	redactHeaders = regexp.MustCompile(`(?im)^(` + strings.Join(sensitiveHeaders, "|") + `):.*$`)
+	// redactFields matches the string values of the JSON fields named as credentials: keys such as apiKey,
+	// secretAccessKey, accessKeyId or privateKey, and fields ending in password, passCode, passphrase, secret,
+	// token or credentials. Other fields merely containing "key" or "pass", such as componentKey, are kept
+	redactFields = regexp.MustCompile(`(?i)("(?:[a-z0-9_.-]*(?:api|secret|access|private|encryption)[_-]?key(?:[_-]?id)?|` +
+		`[a-z0-9_.-]*(?:password|passcode|passphrase|secret|token|credentials?)|keypair)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// redact removes credentials from a dump of an HTTP request or response
+func redact(dump []byte) string {
+	s := redactHeaders.ReplaceAllString(string(dump), "$1: "+redacted)
+	return redactFields.ReplaceAllString(s, `$1"`+redacted+`"`)
+}
+
+// isCredentialBody determines if the whole body of the request is a credential, which is the case of
+// the bodies which are not JSON sent to the credential endpoints
+func isCredentialBody(request *http.Request) bool {
+	if request.Body == nil || request.Body == http.NoBody || strings.Contains(request.Header.Get("Content-Type"), "json") {
+		return false
+	}
+
+	for _, endpoint := range credentialEndpoints {
+		if strings.HasSuffix(request.URL.Path, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+// dumpRequest dumps the request with its credentials redacted
+func dumpRequest(request *http.Request) string {
+	if isCredentialBody(request) {
+		dump, _ := httputil.DumpRequest(request, false)
+		return redact(dump) + redacted
+	}
+
+	dump, _ := httputil.DumpRequest(request, true)
+	return redact(dump)
+}
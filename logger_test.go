@@ -0,0 +1,118 @@
+package nexus
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) record(level, msg string, keysAndValues []interface{}) {
+	l.messages = append(l.messages, fmt.Sprint(level, " ", msg, " ", keysAndValues))
+}
+
+func (l *recordingLogger) Debug(msg string, kv ...interface{}) { l.record("debug", msg, kv) }
+func (l *recordingLogger) Info(msg string, kv ...interface{})  { l.record("info", msg, kv) }
+func (l *recordingLogger) Warn(msg string, kv ...interface{})  { l.record("warn", msg, kv) }
+func (l *recordingLogger) Error(msg string, kv ...interface{}) { l.record("error", msg, kv) }
+
+func TestDebugLogging(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "NXSESSIONID=secret-session")
+		fmt.Fprint(w, "ok")
+	}))
+	defer mock.Close()
+
+	logger := new(recordingLogger)
+
+	client := NewClient(ServerInfo{Host: mock.URL, Username: "admin", Password: "admin123"}, WithLogger(logger))
+
+	if _, _, err := client.Post("test", strings.NewReader(`{"token":"secret-token"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logger.messages) != 0 {
+		t.Errorf("Did not expect any messages without debug enabled: %v", logger.messages)
+	}
+
+	client.SetDebug(true)
+
+	if _, _, err := client.Post("test", strings.NewReader(`{"token":"secret-token"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logger.messages) != 2 {
+		t.Fatalf("Expected the request and response to be logged: %v", logger.messages)
+	}
+
+	all := strings.Join(logger.messages, "\n")
+	for _, secret := range []string{"YWRtaW46YWRtaW4xMjM=", "secret-token", "secret-session"} {
+		if strings.Contains(all, secret) {
+			t.Errorf("Debug output contains credentials %q: %s", secret, all)
+		}
+	}
+
+	if !strings.Contains(all, "POST /test") {
+		t.Errorf("Debug output does not contain the request: %s", all)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	dump := "GET / HTTP/1.1\r\nAuthorization: Basic Zm9vOmJhcg==\r\nProxy-Authorization: Bearer abc\r\n\r\n" +
+		`{"username":"user","password":"p\"ss","passCode":"pc","token":"t","name":"n",` +
+		`"s3":{"secretAccessKey":"sak","encryptionKey":"ek","accessKeyId":"aki"},"keypair":"kp","passphrase":"pp","clientCredentials":"cc",` +
+		`"apiKey":"ak","privateKey":"pk","componentKey":"ck","keyword":"kw","publicKey":"pub","monkey":"m","passive":"pa"}`
+
+	got := redact([]byte(dump))
+
+	for _, secret := range []string{"Zm9vOmJhcg==", "abc", `p\"ss`, `"pc"`, `"t"`, `"sak"`, `"ek"`, `"aki"`, `"kp"`, `"pp"`, `"cc"`, `"ak"`, `"pk"`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("Did not redact %s: %s", secret, got)
+		}
+	}
+
+	kept := []string{
+		`"username":"user"`, `"name":"n"`, `"componentKey":"ck"`, `"keyword":"kw"`, `"publicKey":"pub"`, `"monkey":"m"`,
+		`"passive":"pa"`, "Authorization: [REDACTED]",
+	}
+	for _, k := range kept {
+		if !strings.Contains(got, k) {
+			t.Errorf("Expected %s to be kept: %s", k, got)
+		}
+	}
+}
+
+func TestDumpRequestCredentialBody(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPut, "http://nexus.example/service/rest/v1/security/users/jdoe/change-password", strings.NewReader("s3cr3t"))
+	request.Header.Set("Content-Type", "text/plain")
+
+	got := dumpRequest(request)
+	if strings.Contains(got, "s3cr3t") || !strings.Contains(got, "/security/users/jdoe/change-password HTTP/1.1") {
+		t.Errorf("Expected the plain text password to be redacted: %s", got)
+	}
+
+	request = httptest.NewRequest(http.MethodPut, "http://nexus.example/api/v2/users/jdoe", strings.NewReader(`{"firstName":"John"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	if got := dumpRequest(request); !strings.Contains(got, `{"firstName":"John"}`) {
+		t.Errorf("Expected the body of other endpoints to be kept: %s", got)
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0))
+
+	logger.Warn("something happened", "file", "certs.pem", "odd")
+
+	if got, want := buf.String(), "warning: something happened file=\"certs.pem\" odd=\"(MISSING)\"\n"; got != want {
+		t.Errorf("Expected %q but got %q", want, got)
+	}
+}
@@ -0,0 +1,36 @@
+package nexus
+
+import "net/http"
+
+// Middleware wraps the round trip of every request sent by the client.
+// It allows adding headers, auditing, collecting metrics or injecting faults
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc allows using a function as an http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(request)
+func (f RoundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// HeaderMiddleware creates a Middleware which sets the given headers on every request
+func HeaderMiddleware(headers http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			for k, v := range headers {
+				request.Header[http.CanonicalHeaderKey(k)] = v
+			}
+			return next.RoundTrip(request)
+		})
+	}
+}
+
+// roundTripper chains the middleware of the client in front of the http.Client
+func (cfg settings) roundTripper() http.RoundTripper {
+	var rt http.RoundTripper = RoundTripperFunc(cfg.roundTrip)
+	for i := len(cfg.middleware) - 1; i >= 0; i-- {
+		rt = cfg.middleware[i](rt)
+	}
+	return rt
+}
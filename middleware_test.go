@@ -0,0 +1,79 @@
+package nexus
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Order", r.Header.Get("X-Order"))
+	}))
+	defer mock.Close()
+
+	appendOrder := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				r.Header.Set("X-Order", r.Header.Get("X-Order")+name)
+				return next.RoundTrip(r)
+			})
+		}
+	}
+
+	client := NewClient(ServerInfo{Host: mock.URL},
+		WithMiddleware(appendOrder("a"), appendOrder("b")),
+		WithMiddleware(appendOrder("c")),
+	)
+
+	_, resp, err := client.Get("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := resp.Header.Get("X-Order"); got != "abc" {
+		t.Errorf("Middleware not called in expected order: %q", got)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Custom") != "value" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL}, WithMiddleware(HeaderMiddleware(http.Header{"x-custom": []string{"value"}})))
+
+	if _, _, err := client.Get("test"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	var calls int
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer mock.Close()
+
+	injected := errors.New("injected fault")
+
+	client := NewClient(ServerInfo{Host: mock.URL},
+		WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return nil, injected
+			})
+		}),
+	)
+
+	if _, _, err := client.Get("test"); !errors.Is(err, injected) {
+		t.Errorf("Expected the injected fault but got: %v", err)
+	}
+
+	if calls != 0 {
+		t.Error("Request reached the server")
+	}
+}
@@ -1,15 +1,14 @@
 package nexus
 
 import (
-	"crypto/tls"
-	"crypto/x509"
-	"errors"
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/http/httputil"
+	"sync"
 	"time"
 )
 
@@ -31,21 +30,185 @@ type Client interface {
 	SetCertFile(certFile string)
 }
 
-// DefaultClient provides an HTTP wrapper with optimized for communicating with a Nexus server
+// ContextClient is implemented by clients which can create requests bound to a context, such as DefaultClient.
+// Clients which do not implement it have the context added to the requests they create, see NewRequestWithContext
+type ContextClient interface {
+	NewRequestWithContext(ctx context.Context, method, endpoint string, payload io.Reader) (*http.Request, error)
+}
+
+// Streamer is implemented by clients which can return the body of a response without reading it, such as DefaultClient.
+// The responses of clients which do not implement it are read in full, see Stream
+type Streamer interface {
+	Stream(request *http.Request) (io.ReadCloser, *http.Response, error)
+}
+
+// NewRequestWithContext creates a request of the client bound to the given context
+func NewRequestWithContext(ctx context.Context, client Client, method, endpoint string, payload io.Reader) (*http.Request, error) {
+	if c, ok := client.(ContextClient); ok {
+		return c.NewRequestWithContext(ctx, method, endpoint, payload)
+	}
+
+	request, err := client.NewRequest(method, endpoint, payload)
+	if err != nil {
+		return nil, err
+	}
+	return request.WithContext(ctx), nil
+}
+
+// Stream performs the request with the client and returns the unread body if the response status is successful (2xx).
+// The caller is responsible for closing the returned body
+func Stream(client Client, request *http.Request) (io.ReadCloser, *http.Response, error) {
+	if s, ok := client.(Streamer); ok {
+		return s.Stream(request)
+	}
+
+	body, resp, err := client.Do(request)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ioutil.NopCloser(bytes.NewReader(body)), resp, nil
+}
+
+func doContext(ctx context.Context, client Client, method, endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	request, err := NewRequestWithContext(ctx, client, method, endpoint, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client.Do(request)
+}
+
+// GetContext performs an HTTP GET against the indicated endpoint with the client, using the given context
+func GetContext(ctx context.Context, client Client, endpoint string) ([]byte, *http.Response, error) {
+	return doContext(ctx, client, http.MethodGet, endpoint, nil)
+}
+
+// PostContext performs an HTTP POST against the indicated endpoint with the client, using the given context
+func PostContext(ctx context.Context, client Client, endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	return doContext(ctx, client, http.MethodPost, endpoint, payload)
+}
+
+// PutContext performs an HTTP PUT against the indicated endpoint with the client, using the given context
+func PutContext(ctx context.Context, client Client, endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	return doContext(ctx, client, http.MethodPut, endpoint, payload)
+}
+
+// DelContext performs an HTTP DELETE against the indicated endpoint with the client, using the given context
+func DelContext(ctx context.Context, client Client, endpoint string) (*http.Response, error) {
+	_, resp, err := doContext(ctx, client, http.MethodDelete, endpoint, nil)
+	return resp, err
+}
+
+// DefaultClient provides an HTTP wrapper with optimized for communicating with a Nexus server.
+// It is configured with Options when it is created, see NewClient, and cannot be changed afterwards except through
+// the deprecated SetDebug and SetCertFile, so it is safe for concurrent use
 type DefaultClient struct {
 	ServerInfo
-	Debug bool
+	debug       bool
+	retryPolicy RetryPolicy
+
+	// rateLimiter, if set, limits the rate and concurrency of the requests
+	rateLimiter *Limiter
+
+	// cache, if set, serves GET requests from previous responses
+	cache *Cache
+
+	// telemetry, if set, receives a span and metrics for each attempt of a request
+	telemetry Telemetry
+	// endpoints are the templates of the endpoints of the server, such as api/v2/users/%s, which
+	// are used to name the endpoints of requests in the telemetry
+	endpoints []string
+
+	// logger receives the messages of the client, including the debug output. Defaults to stderr
+	logger Logger
+
+	// middleware wraps every request sent by the client
+	middleware []Middleware
+
+	// auth, if set, authenticates requests instead of the basic auth credentials in ServerInfo
+	auth Authenticator
+
+	// httpClient, if set, is used for all requests instead of the client built from the other settings
+	httpClient *http.Client
+	// transport, if set, is used instead of http.DefaultTransport
+	transport http.RoundTripper
+	// timeout limits the time taken by each attempt of a request, or only the wait for the headers of a streamed response.
+	// Defaults to 30 seconds
+	timeout time.Duration
+
+	// userAgent, if set, is sent with every request
+	userAgent string
+
+	mu            sync.RWMutex
+	built         *http.Client
+	builtStream   *http.Client
+	builtCertFile string
+}
+
+// settings are the settings of the client which a request uses, copied when it is sent so that it is not
+// affected by concurrent changes
+type settings struct {
+	host       string
+	debug      bool
+	logger     Logger
+	retry      RetryPolicy
+	limiter    *Limiter
+	cache      *Cache
+	telemetry  Telemetry
+	endpoints  []string
+	middleware []Middleware
+	client     *http.Client
+	stream     *http.Client
+}
+
+func (s *DefaultClient) settings() settings {
+	client, stream := s.clients()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return settings{
+		host:       s.Host,
+		debug:      s.debug,
+		logger:     orDefaultLogger(s.logger),
+		retry:      s.retryPolicy,
+		limiter:    s.rateLimiter,
+		cache:      s.cache,
+		telemetry:  s.telemetry,
+		endpoints:  s.endpoints,
+		middleware: s.middleware,
+		client:     client,
+		stream:     stream,
+	}
 }
 
 // NewRequest created an http.Request object based on an endpoint and fills in basic auth
-func (s *DefaultClient) NewRequest(method, endpoint string, payload io.Reader) (request *http.Request, err error) {
-	url := fmt.Sprintf("%s/%s", s.Host, endpoint)
-	request, err = http.NewRequest(method, url, payload)
+func (s *DefaultClient) NewRequest(method, endpoint string, payload io.Reader) (*http.Request, error) {
+	return s.NewRequestWithContext(context.Background(), method, endpoint, payload)
+}
+
+// NewRequestWithContext creates an http.Request object bound to the given context
+// based on an endpoint and fills in the credentials
+func (s *DefaultClient) NewRequestWithContext(ctx context.Context, method, endpoint string, payload io.Reader) (request *http.Request, err error) {
+	info := s.Info()
+	auth, userAgent := s.auth, s.userAgent
+
+	url := fmt.Sprintf("%s/%s", info.Host, endpoint)
+	request, err = http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return
 	}
 
-	request.SetBasicAuth(s.Username, s.Password)
+	if auth != nil {
+		if err = auth.Authenticate(request); err != nil {
+			return nil, fmt.Errorf("could not authenticate request: %w", err)
+		}
+	} else {
+		request.SetBasicAuth(info.Username, info.Password)
+	}
+	if userAgent != "" {
+		request.Header.Set("User-Agent", userAgent)
+	}
 	if payload != nil {
 		request.Header.Set("Content-Type", "application/json")
 	}
@@ -53,100 +216,219 @@ func (s *DefaultClient) NewRequest(method, endpoint string, payload io.Reader) (
 	return
 }
 
-// Do performs an http.Request and reads the body if StatusOK
-func (s *DefaultClient) Do(request *http.Request) (body []byte, resp *http.Response, err error) {
-	if s.Debug {
-		dump, _ := httputil.DumpRequest(request, true)
-		log.Println("debug: http request:")
-		log.Printf("%q\n", dump)
+// Do performs an http.Request and reads the body if the response status is successful (2xx).
+// The request is aborted when the context of the request is done.
+// Failed attempts are retried as described by the client's RetryPolicy
+func (s *DefaultClient) Do(request *http.Request) ([]byte, *http.Response, error) {
+	cfg := s.settings()
+	return cfg.cache.do(request, relativePath(cfg.host, request.URL), func(request *http.Request) ([]byte, *http.Response, error) {
+		return s.retry(cfg, request, s.do)
+	})
+}
+
+// Stream performs an http.Request and returns the unread body if the response status is successful (2xx).
+// The caller is responsible for closing the returned body.
+// The timeout of the client only limits the wait for the response headers, so reading the body is only
+// limited by the context of the request.
+// Failed attempts are retried as described by the client's RetryPolicy
+func (s *DefaultClient) Stream(request *http.Request) (io.ReadCloser, *http.Response, error) {
+	cfg := s.settings()
+	cfg.client = cfg.stream
+	_, resp, err := s.retry(cfg, request, s.stream)
+	if err != nil {
+		return nil, resp, err
 	}
+	return resp.Body, resp, nil
+}
 
-	client := &http.Client{Timeout: 30 * time.Second}
+// attemptFunc makes a single attempt of a request
+type attemptFunc func(cfg settings, request *http.Request) ([]byte, *http.Response, error)
 
-	if s.CertFile != "" {
-		rootCAs, err := x509.SystemCertPool()
-		if err != nil {
-			log.Println("warning: failed to get the system cert pool:", err)
+func (s *DefaultClient) retry(cfg settings, request *http.Request, attemptFunc attemptFunc) (body []byte, resp *http.Response, err error) {
+	for attempt := 1; ; attempt++ {
+		body, resp, err = instrument(cfg, request, attempt, attemptFunc)
+
+		wait, retry := cfg.retry.shouldRetry(request, attempt, resp, err)
+		if !retry {
+			return
 		}
-		if rootCAs == nil {
-			rootCAs = x509.NewCertPool()
+
+		if cfg.retry.OnRetry != nil {
+			cfg.retry.OnRetry(RetryEvent{
+				Request:  request,
+				Response: resp,
+				Err:      err,
+				Attempt:  attempt,
+				Wait:     wait,
+			})
 		}
 
-		certs, err := ioutil.ReadFile(s.CertFile)
-		if err != nil {
-			log.Printf("warning: failed to append %s to RootCAs: %s\n", s.CertFile, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+			timer.Stop()
+			return
 		}
 
-		if ok := rootCAs.AppendCertsFromPEM(certs); !ok {
-			log.Println("warning: no certs appended, using system certs only")
+		next, er := rewind(request)
+		if er != nil {
+			return
 		}
+		request = next
+	}
+}
+
+func (s *DefaultClient) send(cfg settings, request *http.Request) (*http.Response, error) {
+	release, err := cfg.limiter.Acquire(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cfg.roundTripper().RoundTrip(request)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = releaseBody{resp.Body, release}
+	return resp, nil
+}
+
+func (cfg settings) roundTrip(request *http.Request) (*http.Response, error) {
+	if cfg.debug {
+		cfg.logger.Debug("http request", "dump", dumpRequest(request))
+	}
+
+	resp, err := cfg.client.Do(request)
 
-		client.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{
-				RootCAs: rootCAs,
-			},
+	if cfg.debug {
+		if err != nil {
+			cfg.logger.Debug("http request failed", "error", err)
+		} else {
+			dump, _ := httputil.DumpResponse(resp, false)
+			cfg.logger.Debug("http response", "dump", redact(dump))
 		}
 	}
 
-	resp, err = client.Do(request)
+	return resp, err
+}
+
+func orDefaultLogger(logger Logger) Logger {
+	if logger != nil {
+		return logger
+	}
+	return defaultLogger
+}
+
+func (s *DefaultClient) do(cfg settings, request *http.Request) (body []byte, resp *http.Response, err error) {
+	resp, err = s.send(cfg, request)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
-	// TODO: Trying to decide if this is a horrible idea or just kinda bad
-	if resp.StatusCode == http.StatusOK {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		body, err = ioutil.ReadAll(resp.Body)
 		return
 	}
 
-	err = errors.New(resp.Status)
-	return
+	// The response to a conditional request is not an error when the resource did not change
+	if resp.StatusCode == http.StatusNotModified && (request.Header.Get("If-None-Match") != "" || request.Header.Get("If-Modified-Since") != "") {
+		return nil, resp, nil
+	}
+
+	return nil, resp, responseError(request, resp)
 }
 
-func (s *DefaultClient) http(method, endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
-	request, err := s.NewRequest(method, endpoint, payload)
+func (s *DefaultClient) stream(cfg settings, request *http.Request) ([]byte, *http.Response, error) {
+	resp, err := s.send(cfg, request)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.Do(request)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil, resp, nil
+	}
+
+	defer resp.Body.Close()
+	return nil, resp, responseError(request, resp)
+}
+
+// responseError keeps the start of the body for the error and drains the rest so that the connection can be reused
+func responseError(request *http.Request, resp *http.Response) error {
+	errBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	io.Copy(ioutil.Discard, resp.Body)
+
+	return newError(request, resp, errBody)
 }
 
 // Get performs an HTTP GET against the indicated endpoint
 func (s *DefaultClient) Get(endpoint string) ([]byte, *http.Response, error) {
-	return s.http(http.MethodGet, endpoint, nil)
+	return s.GetContext(context.Background(), endpoint)
+}
+
+// GetContext performs an HTTP GET against the indicated endpoint using the given context
+func (s *DefaultClient) GetContext(ctx context.Context, endpoint string) ([]byte, *http.Response, error) {
+	return GetContext(ctx, s, endpoint)
 }
 
 // Post performs an HTTP POST against the indicated endpoint
 func (s *DefaultClient) Post(endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
-	return s.http(http.MethodPost, endpoint, payload)
+	return s.PostContext(context.Background(), endpoint, payload)
+}
+
+// PostContext performs an HTTP POST against the indicated endpoint using the given context
+func (s *DefaultClient) PostContext(ctx context.Context, endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	return PostContext(ctx, s, endpoint, payload)
 }
 
 // Put performs an HTTP PUT against the indicated endpoint
 func (s *DefaultClient) Put(endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
-	return s.http(http.MethodPut, endpoint, payload)
+	return s.PutContext(context.Background(), endpoint, payload)
+}
+
+// PutContext performs an HTTP PUT against the indicated endpoint using the given context
+func (s *DefaultClient) PutContext(ctx context.Context, endpoint string, payload io.Reader) ([]byte, *http.Response, error) {
+	return PutContext(ctx, s, endpoint, payload)
 }
 
 // Del performs an HTTP DELETE against the indicated endpoint
 func (s *DefaultClient) Del(endpoint string) (resp *http.Response, err error) {
-	_, resp, err = s.http(http.MethodDelete, endpoint, nil)
-	return
+	return s.DelContext(context.Background(), endpoint)
+}
+
+// DelContext performs an HTTP DELETE against the indicated endpoint using the given context
+func (s *DefaultClient) DelContext(ctx context.Context, endpoint string) (*http.Response, error) {
+	return DelContext(ctx, s, endpoint)
 }
 
 // Info return information about the Nexus server
 func (s *DefaultClient) Info() ServerInfo {
-	return ServerInfo{s.Host, s.Username, s.Password, s.CertFile}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ServerInfo
 }
 
-// SetDebug will enable or disable debug output on HTTP communication
+// SetDebug will enable or disable debug output on HTTP communication.
+// Requests and responses are written to the client's Logger with any credentials redacted.
+//
+// Deprecated: clients are configured when they are created, use WithDebug instead
 func (s *DefaultClient) SetDebug(enable bool) {
-	s.Debug = enable
+	s.mu.Lock()
+	s.debug = enable
+	s.mu.Unlock()
 }
 
-// SetCertFile sets the certificate to use for HTTP communication
+// SetCertFile sets the certificate to use for HTTP communication. The requests in flight complete with
+// the previous certificate, as a new transport is built for the following ones.
+//
+// Deprecated: clients are configured when they are created, use WithCertFile instead
 func (s *DefaultClient) SetCertFile(certFile string) {
+	s.mu.Lock()
 	s.CertFile = certFile
+	s.build()
+	s.mu.Unlock()
 }
 
 // SearchQueryBuilder is the interface that a search builder should follow
@@ -0,0 +1,235 @@
+package nexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newStatusTestClient(status int) (*DefaultClient, *httptest.Server) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if status != http.StatusNoContent && status != http.StatusNotModified {
+			fmt.Fprint(w, "body")
+		}
+	}))
+
+	return &DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}}, mock
+}
+
+func TestDoSuccessStatuses(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusOK, "body"},
+		{http.StatusCreated, "body"},
+		{http.StatusAccepted, "body"},
+		{http.StatusNoContent, ""},
+	}
+
+	for _, test := range tests {
+		client, mock := newStatusTestClient(test.status)
+
+		body, resp, err := client.Post("test", nil)
+		if err != nil {
+			t.Errorf("Status %d: unexpected error: %v", test.status, err)
+		}
+
+		if resp == nil || resp.StatusCode != test.status {
+			t.Errorf("Status %d: did not receive expected response: %v", test.status, resp)
+		}
+
+		if string(body) != test.body {
+			t.Errorf("Status %d: expected body %q but got %q", test.status, test.body, body)
+		}
+
+		mock.Close()
+	}
+}
+
+func TestDoErrorStatuses(t *testing.T) {
+	for _, status := range []int{
+		http.StatusNotModified,
+		http.StatusBadRequest,
+		http.StatusNotFound,
+		http.StatusConflict,
+		http.StatusInternalServerError,
+	} {
+		client, mock := newStatusTestClient(status)
+
+		_, resp, err := client.Get("test")
+
+		var nerr *Error
+		if !errors.As(err, &nerr) || nerr.StatusCode != status {
+			t.Errorf("Status %d: did not receive expected error: %v", status, err)
+		}
+
+		if resp == nil || resp.StatusCode != status {
+			t.Errorf("Status %d: did not receive expected response: %v", status, resp)
+		}
+
+		mock.Close()
+	}
+}
+
+func TestDoNetworkFailure(t *testing.T) {
+	mock := httptest.NewServer(http.NotFoundHandler())
+	mock.Close()
+
+	client := &DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}}
+
+	_, resp, err := client.Get("test")
+	if err == nil {
+		t.Error("Expected an error when the server is unreachable")
+	}
+
+	if resp != nil {
+		t.Errorf("Did not expect a response: %v", resp)
+	}
+
+	var nerr *Error
+	if errors.As(err, &nerr) {
+		t.Error("Transport failure reported as a server error")
+	}
+}
+
+func TestStream(t *testing.T) {
+	client, mock := newStatusTestClient(http.StatusOK)
+	defer mock.Close()
+
+	request, err := client.NewRequest(http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, resp, err := client.Stream(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	if resp.Header.Get("Content-Length") != "4" {
+		t.Errorf("Did not receive expected headers: %v", resp.Header)
+	}
+
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "body" {
+		t.Errorf("Unexpected body: %q", content)
+	}
+}
+
+func TestStreamLongerThanTimeout(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 4; i++ {
+			fmt.Fprint(w, "part")
+			w.(http.Flusher).Flush()
+			time.Sleep(30 * time.Millisecond)
+		}
+	}))
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL}, WithTimeout(50*time.Millisecond))
+
+	request, err := client.NewRequest(http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _, err := client.Stream(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	start := time.Now()
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatalf("Expected the stream not to be cut off by the timeout but got: %v", err)
+	}
+
+	if string(content) != "partpartpartpart" || time.Since(start) < 50*time.Millisecond {
+		t.Errorf("Expected the whole body to be read after the timeout but got %q in %s", content, time.Since(start))
+	}
+
+	if _, _, err := client.Get("test"); err == nil {
+		t.Error("Expected the timeout to still limit requests which are not streamed")
+	}
+}
+
+func TestStreamHeaderTimeout(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL}, WithTimeout(50*time.Millisecond))
+
+	request, err := client.NewRequest(http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := client.Stream(request); err == nil {
+		t.Error("Expected the wait for the headers to time out")
+	}
+}
+
+func TestStreamCanceled(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "part")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request, err := client.NewRequestWithContext(ctx, http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _, err := client.Stream(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	cancel()
+	if _, err := ioutil.ReadAll(body); err == nil {
+		t.Error("Expected the stream to end with an error once its context was canceled")
+	}
+}
+
+func TestStreamErrorStatus(t *testing.T) {
+	client, mock := newStatusTestClient(http.StatusNotFound)
+	defer mock.Close()
+
+	request, err := client.NewRequest(http.MethodGet, "test", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _, err := client.Stream(request)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a not found error but got: %v", err)
+	}
+
+	if body != nil {
+		t.Error("Did not expect a body")
+	}
+}
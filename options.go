@@ -0,0 +1,93 @@
+package nexus
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a client when it is created, e.g. by NewClient, nexusrm.New or nexusiq.New.
+// Options are the only way to configure a client, so it can be shared by goroutines from the start
+type Option func(*DefaultClient)
+
+// NewClient creates a client of the server, configured by the options
+func NewClient(info ServerInfo, opts ...Option) *DefaultClient {
+	s := &DefaultClient{ServerInfo: info}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithTimeout sets the time limit of each attempt of a request, including reading the response body.
+// Streamed responses are only limited while waiting for their headers, see DefaultClient.Stream
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *DefaultClient) { s.timeout = timeout }
+}
+
+// WithCertFile sets the certificate to use for HTTP communication
+func WithCertFile(certFile string) Option {
+	return func(s *DefaultClient) { s.CertFile = certFile }
+}
+
+// WithDebug enables or disables the debug output of the HTTP communication.
+// Requests and responses are written to the client's Logger with any credentials redacted
+func WithDebug(enable bool) Option {
+	return func(s *DefaultClient) { s.debug = enable }
+}
+
+// WithTransport sets the http.RoundTripper used to communicate with the server, e.g. for proxies or custom dialers
+func WithTransport(transport http.RoundTripper) Option {
+	return func(s *DefaultClient) { s.transport = transport }
+}
+
+// WithHTTPClient sets the http.Client used for all communication with the server, whose transport, timeout and certificates are used as-is
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *DefaultClient) { s.httpClient = client }
+}
+
+// WithAuthenticator sets how requests are authenticated with the server, instead of the username and password
+func WithAuthenticator(auth Authenticator) Option {
+	return func(s *DefaultClient) { s.auth = auth }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(s *DefaultClient) { s.userAgent = userAgent }
+}
+
+// WithLogger sets the Logger which the client writes to
+func WithLogger(logger Logger) Option {
+	return func(s *DefaultClient) { s.logger = logger }
+}
+
+// WithRetryPolicy sets the policy used to retry failed HTTP requests
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(s *DefaultClient) { s.retryPolicy = policy }
+}
+
+// WithLimiter sets the Limiter which every request, including each retry, has to go through
+func WithLimiter(limiter *Limiter) Option {
+	return func(s *DefaultClient) { s.rateLimiter = limiter }
+}
+
+// WithTelemetry sets where the client reports a span and metrics for each attempt of a request.
+// The spans of streamed responses end once their headers are received
+func WithTelemetry(telemetry Telemetry) Option {
+	return func(s *DefaultClient) { s.telemetry = telemetry }
+}
+
+// WithCache sets the Cache which serves the GET requests of the client
+func WithCache(cache *Cache) Option {
+	return func(s *DefaultClient) { s.cache = cache }
+}
+
+// WithMiddleware adds middleware to the client, which is called in the order it is given
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(s *DefaultClient) { s.middleware = append(s.middleware, middleware...) }
+}
+
+// WithEndpoints sets the templates of the endpoints of the server, such as api/v2/users/%s, which
+// are used to name the endpoints of requests in the telemetry
+func WithEndpoints(endpoints ...string) Option {
+	return func(s *DefaultClient) { s.endpoints = endpoints }
+}
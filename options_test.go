@@ -0,0 +1,113 @@
+package nexus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	transport := http.DefaultTransport
+	httpClient := new(http.Client)
+	auth := BasicAuth{Username: "user", Password: "pass"}
+	limiter := NewLimiter(RateLimit{MaxInFlight: 1})
+	cache := NewCache(CachePolicy{})
+	recorder := NewRecorder()
+	policy := RetryPolicy{MaxAttempts: 3}
+
+	client := NewClient(ServerInfo{Host: "http://nexus.example"},
+		WithTimeout(time.Minute),
+		WithCertFile("ca.pem"),
+		WithDebug(true),
+		WithTransport(transport),
+		WithHTTPClient(httpClient),
+		WithAuthenticator(auth),
+		WithUserAgent("tests"),
+		WithLogger(NopLogger),
+		WithRetryPolicy(policy),
+		WithLimiter(limiter),
+		WithTelemetry(recorder),
+		WithCache(cache),
+		WithMiddleware(HeaderMiddleware(nil), HeaderMiddleware(nil)),
+		WithEndpoints("api/v2/users/%s"),
+	)
+
+	if client.Host != "http://nexus.example" || client.timeout != time.Minute || client.CertFile != "ca.pem" || !client.debug || client.userAgent != "tests" {
+		t.Errorf("Unexpected settings %+v", client)
+	}
+	if client.transport != transport || client.httpClient != httpClient || !reflect.DeepEqual(client.auth, auth) || client.logger != NopLogger {
+		t.Errorf("Unexpected settings %+v", client)
+	}
+	if !reflect.DeepEqual(client.retryPolicy, policy) || client.rateLimiter != limiter || client.telemetry != recorder || client.cache != cache {
+		t.Errorf("Unexpected settings %+v", client)
+	}
+	if len(client.middleware) != 2 || len(client.endpoints) != 1 {
+		t.Errorf("Expected 2 middleware and 1 endpoint but got %d and %d", len(client.middleware), len(client.endpoints))
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("User-Agent")))
+	}))
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL}, WithUserAgent("gonexus-tests"))
+
+	body, _, err := client.Get("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "gonexus-tests" {
+		t.Errorf("Expected the user agent to be sent but got %q", body)
+	}
+}
+
+// TestConcurrentSetters is meant to be run with -race
+func TestConcurrentSetters(t *testing.T) {
+	mock, _ := concurrencyServer(time.Millisecond)
+	defer mock.Close()
+
+	client := NewClient(ServerInfo{Host: mock.URL},
+		WithLogger(NopLogger),
+		WithCache(NewCache(CachePolicy{Classes: []CacheClass{{Endpoints: []string{"test"}, TTL: time.Second}}})),
+		WithTelemetry(NewRecorder()),
+		WithLimiter(NewLimiter(RateLimit{MaxInFlight: 2})),
+	)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			client.SetDebug(i%2 == 0)
+			client.SetCertFile("")
+			client.Info()
+		}
+	}()
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, _, err := client.Get("test"); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(done)
+	wg.Wait()
+}
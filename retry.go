@@ -0,0 +1,178 @@
+package nexus
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes if, and how, DefaultClient retries a failed request
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request, including the first one.
+	// A value of 1 or less disables retries
+	MaxAttempts int
+
+	// InitialBackoff is the time to wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the time waited between any two attempts. Requests whose Retry-After header asks for
+	// a longer wait are not retried
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the backoff grows after each attempt. Defaults to 2
+	Multiplier float64
+
+	// Jitter is the fraction (0.0 - 1.0) of each backoff which is randomized
+	Jitter float64
+
+	// RetryStatuses lists the HTTP status codes which are considered transient.
+	// Defaults to 429, 502, 503 and 504
+	RetryStatuses []int
+
+	// RetryNonIdempotent allows retrying methods such as POST which are not idempotent
+	RetryNonIdempotent bool
+
+	// OnRetry, if set, is called before waiting for each retry
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a failed attempt which is about to be retried
+type RetryEvent struct {
+	Request  *http.Request
+	Response *http.Response
+	Err      error
+	Attempt  int
+	Wait     time.Duration
+}
+
+// DefaultRetryPolicy returns a policy which retries transient failures of idempotent requests up to 4 times
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+var defaultRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p RetryPolicy) retryableStatus(code int) bool {
+	statuses := p.RetryStatuses
+	if statuses == nil {
+		statuses = defaultRetryStatuses
+	}
+	for _, s := range statuses {
+		if s == code {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldRetry determines if the given attempt is to be retried and, if so, how long to wait before doing so
+func (p RetryPolicy) shouldRetry(request *http.Request, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || request.Context().Err() != nil {
+		return 0, false
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(request.Method) {
+		return 0, false
+	}
+
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return 0, false
+	}
+
+	switch {
+	case resp == nil && err != nil:
+	case resp != nil && p.retryableStatus(resp.StatusCode):
+	default:
+		return 0, false
+	}
+
+	wait := p.backoff(attempt)
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			// The server asked for a longer wait than the policy allows, so the request fails instead of blocking
+			if p.MaxBackoff > 0 && after > p.MaxBackoff {
+				return 0, false
+			}
+			wait = after
+		}
+	}
+
+	return wait, true
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		backoff -= backoff * jitter * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// rewind prepares the request to be sent again
+func rewind(request *http.Request) (*http.Request, error) {
+	next := request.Clone(request.Context())
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
@@ -0,0 +1,249 @@
+package nexus
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc, configure ...func(*RetryPolicy)) (*DefaultClient, *httptest.Server) {
+	mock := httptest.NewServer(handler)
+	return retryTestClient(mock.URL, configure...), mock
+}
+
+func retryTestClient(host string, configure ...func(*RetryPolicy)) *DefaultClient {
+	policy := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+	for _, c := range configure {
+		c(&policy)
+	}
+
+	return NewClient(ServerInfo{Host: host}, WithRetryPolicy(policy))
+}
+
+func TestRetryTransientStatus(t *testing.T) {
+	var attempts int
+	var events []RetryEvent
+	client, mock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}, func(p *RetryPolicy) {
+		p.OnRetry = func(e RetryEvent) {
+			events = append(events, e)
+		}
+	})
+	defer mock.Close()
+
+	body, _, err := client.Get("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != "ok" {
+		t.Errorf("Unexpected body: %q", body)
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts but got %d", attempts)
+	}
+
+	if len(events) != 2 || events[0].Attempt != 1 || events[1].Attempt != 2 {
+		t.Errorf("Did not observe the expected retries: %v", events)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var attempts int
+	client, mock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	defer mock.Close()
+
+	_, resp, err := client.Get("test")
+	if err == nil {
+		t.Error("Expected an error once all attempts failed")
+	}
+
+	if resp == nil || resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected the last response to be returned: %v", resp)
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts but got %d", attempts)
+	}
+}
+
+func TestRetryNonTransientStatus(t *testing.T) {
+	var attempts int
+	client, mock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer mock.Close()
+
+	if _, _, err := client.Get("test"); err == nil {
+		t.Error("Expected an error")
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt but got %d", attempts)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	var bodies []string
+	client, mock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	defer mock.Close()
+
+	if _, _, err := client.Post("test", bytes.NewBufferString("payload")); err == nil {
+		t.Error("Expected POST to not be retried by default")
+	}
+
+	if len(bodies) != 1 {
+		t.Fatalf("Expected a single attempt but got %d", len(bodies))
+	}
+
+	bodies = nil
+	client = retryTestClient(mock.URL, func(p *RetryPolicy) { p.RetryNonIdempotent = true })
+
+	if _, _, err := client.Post("test", bytes.NewBufferString("payload")); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 || bodies[0] != "payload" || bodies[1] != "payload" {
+		t.Errorf("Expected the payload to be sent on each attempt: %q", bodies)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var attempts int
+	var wait time.Duration
+	client, mock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}, func(p *RetryPolicy) {
+		p.MaxBackoff = 2 * time.Second
+		p.OnRetry = func(e RetryEvent) {
+			wait = e.Wait
+		}
+	})
+	defer mock.Close()
+
+	if _, _, err := client.Get("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if wait != time.Second {
+		t.Errorf("Expected to wait 1s as indicated by Retry-After but waited %v", wait)
+	}
+}
+
+func TestRetryAfterAboveMaxBackoff(t *testing.T) {
+	var attempts int
+	client, mock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer mock.Close()
+
+	start := time.Now()
+	_, resp, err := client.Get("test")
+	if err == nil {
+		t.Error("Expected an error")
+	}
+
+	if resp == nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the response to be returned: %v", resp)
+	}
+
+	if attempts != 1 || time.Since(start) > time.Second {
+		t.Errorf("Expected a single attempt without waiting but got %d in %s", attempts, time.Since(start))
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	var attempts int
+	client, mock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, func(p *RetryPolicy) {
+		p.InitialBackoff = time.Minute
+		p.MaxBackoff = time.Minute
+	})
+	defer mock.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, _, err := client.GetContext(ctx, "test"); err == nil {
+		t.Error("Expected an error")
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt but got %d", attempts)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+	}
+
+	for _, test := range tests {
+		if got := p.backoff(test.attempt); got != test.want {
+			t.Errorf("Attempt %d: expected backoff %v but got %v", test.attempt, test.want, got)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1); got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("Jittered backoff %v outside of expected range", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("120"); !ok || d != 2*time.Minute {
+		t.Errorf("Did not parse seconds: %v %v", d, ok)
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(future); !ok || d <= 59*time.Minute {
+		t.Errorf("Did not parse HTTP date: %v %v", d, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("Parsed an invalid value")
+	}
+}
@@ -1,9 +1,11 @@
 package nexusrm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -31,57 +33,42 @@ type listAssetsResponse struct {
 	ContinuationToken string                `json:"continuationToken"`
 }
 
-// GetAssets returns a list of assets in the indicated repository
-func GetAssets(rm RM, repo string) (items []RepositoryItemAsset, err error) {
-	continuation := ""
-
-	get := func() (listResp listAssetsResponse, err error) {
-		url := fmt.Sprintf(restListAssetsByRepo, repo)
-
-		if continuation != "" {
-			url += "&continuationToken=" + continuation
-		}
-
-		body, resp, err := rm.Get(url)
-		if err != nil || resp.StatusCode != http.StatusOK {
-			return
-		}
-
-		err = json.Unmarshal(body, &listResp)
-
-		return
-	}
-
+// GetAssetsContext returns a list of assets in the indicated repository
+func GetAssetsContext(ctx context.Context, rm RM, repo string) (items []RepositoryItemAsset, err error) {
 	items = make([]RepositoryItemAsset, 0)
-	for {
-		resp, err := get()
-		if err != nil {
-			return items, fmt.Errorf("could not get assets: %v", err)
-		}
-
-		items = append(items, resp.Items...)
 
-		if resp.ContinuationToken == "" {
-			break
+	p := AssetsPaginator(rm, repo)
+	for p.Next(ctx) {
+		var page []RepositoryItemAsset
+		if err := p.Decode(&page); err != nil {
+			return items, fmt.Errorf("could not get assets: %w", err)
 		}
+		items = append(items, page...)
+	}
 
-		continuation = resp.ContinuationToken
+	if err := p.Err(); err != nil {
+		return items, fmt.Errorf("could not get assets: %w", err)
 	}
 
 	return items, nil
 }
 
-// GetAssetByID returns an asset by ID
-func GetAssetByID(rm RM, id string) (items RepositoryItemAsset, err error) {
+// GetAssets returns a list of assets in the indicated repository
+func GetAssets(rm RM, repo string) (items []RepositoryItemAsset, err error) {
+	return GetAssetsContext(context.Background(), rm, repo)
+}
+
+// GetAssetByIDContext returns an asset by ID
+func GetAssetByIDContext(ctx context.Context, rm RM, id string) (items RepositoryItemAsset, err error) {
 	doError := func(err error) error {
-		return fmt.Errorf("no asset with id '%s': %v", id, err)
+		return fmt.Errorf("no asset with id '%s': %w", id, err)
 	}
 
 	var item RepositoryItemAsset
 
 	url := fmt.Sprintf("%s/%s", restAssets, id)
-	body, resp, err := rm.Get(url)
-	if err != nil || resp.StatusCode != http.StatusOK {
+	body, _, err := nexus.GetContext(ctx, rm, url)
+	if err != nil {
 		return item, doError(err)
 	}
 
@@ -92,13 +79,23 @@ func GetAssetByID(rm RM, id string) (items RepositoryItemAsset, err error) {
 	return item, nil
 }
 
-// DeleteAssetByID deletes the asset indicated by ID
-func DeleteAssetByID(rm RM, id string) error {
+// GetAssetByID returns an asset by ID
+func GetAssetByID(rm RM, id string) (items RepositoryItemAsset, err error) {
+	return GetAssetByIDContext(context.Background(), rm, id)
+}
+
+// DeleteAssetByIDContext deletes the asset indicated by ID
+func DeleteAssetByIDContext(ctx context.Context, rm RM, id string) error {
 	url := fmt.Sprintf("%s/%s", restAssets, id)
 
-	if resp, err := rm.Del(url); err != nil && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("asset not deleted '%s': %v", id, err)
+	if _, err := nexus.DelContext(ctx, rm, url); err != nil {
+		return fmt.Errorf("asset not deleted '%s': %w", id, err)
 	}
 
 	return nil
 }
+
+// DeleteAssetByID deletes the asset indicated by ID
+func DeleteAssetByID(rm RM, id string) error {
+	return DeleteAssetByIDContext(context.Background(), rm, id)
+}
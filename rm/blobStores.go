@@ -0,0 +1,439 @@
+package nexusrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const (
+	restBlobStores           = "service/rest/v1/blobstores"
+	restBlobStore            = "service/rest/v1/blobstores/%s"
+	restBlobStoreByType      = "service/rest/v1/blobstores/%s/%s"
+	restBlobStoreQuotaStatus = "service/rest/v1/blobstores/%s/quota-status"
+)
+
+// BlobStoreType is the type of a blob store, named as in Repository Manager
+type BlobStoreType string
+
+// Types of blob stores
+const (
+	BlobStoreTypeFile  BlobStoreType = "File"
+	BlobStoreTypeS3    BlobStoreType = "S3"
+	BlobStoreTypeGroup BlobStoreType = "Group"
+)
+
+// endpoint returns how the type is named in the blob store endpoints
+func (t BlobStoreType) endpoint() string {
+	return strings.ToLower(string(t))
+}
+
+// SoftQuotaType is what the soft quota of a blob store limits
+type SoftQuotaType string
+
+// Types of soft quotas
+const (
+	SoftQuotaSpaceRemaining SoftQuotaType = "spaceRemainingQuota"
+	SoftQuotaSpaceUsed      SoftQuotaType = "spaceUsedQuota"
+)
+
+// SoftQuota raises a violation, without refusing writes, when a blob store uses more or has less space than the limit
+type SoftQuota struct {
+	Type SoftQuotaType `json:"type"`
+	// Limit is in bytes
+	Limit int64 `json:"limit"`
+}
+
+// BlobStore summarizes a blob store as listed by RM
+type BlobStore struct {
+	Name                  string        `json:"name"`
+	Type                  BlobStoreType `json:"type"`
+	SoftQuota             *SoftQuota    `json:"softQuota,omitempty"`
+	BlobCount             int64         `json:"blobCount"`
+	TotalSizeInBytes      int64         `json:"totalSizeInBytes"`
+	AvailableSpaceInBytes int64         `json:"availableSpaceInBytes"`
+}
+
+// FileBlobStore is a blob store on the file system of RM. A relative path is resolved against the blobs directory
+type FileBlobStore struct {
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	SoftQuota *SoftQuota `json:"softQuota,omitempty"`
+}
+
+// S3Bucket is the bucket of an S3 blob store. Expiration is the number of days before deleted blobs are removed, -1 never
+type S3Bucket struct {
+	Region     string `json:"region"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix,omitempty"`
+	Expiration int    `json:"expiration"`
+}
+
+// S3Encryption is how the blobs of an S3 blob store are encrypted
+type S3Encryption struct {
+	// EncryptionType is either s3ManagedEncryption or kmsManagedEncryption
+	EncryptionType string `json:"encryptionType,omitempty"`
+	EncryptionKey  string `json:"encryptionKey,omitempty"`
+}
+
+// S3BucketSecurity holds the credentials of an S3 blob store. Without them, those of the environment of RM are used
+type S3BucketSecurity struct {
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	Role            string `json:"role,omitempty"`
+	SessionToken    string `json:"sessionToken,omitempty"`
+}
+
+// S3AdvancedBucketConnection points an S3 blob store to an S3 compatible service other than AWS
+type S3AdvancedBucketConnection struct {
+	Endpoint       string `json:"endpoint,omitempty"`
+	SignerType     string `json:"signerType,omitempty"`
+	ForcePathStyle bool   `json:"forcePathStyle"`
+}
+
+// S3BucketConfiguration describes the bucket of an S3 blob store and how to connect to it
+type S3BucketConfiguration struct {
+	Bucket                   S3Bucket                    `json:"bucket"`
+	Encryption               *S3Encryption               `json:"encryption,omitempty"`
+	BucketSecurity           *S3BucketSecurity           `json:"bucketSecurity,omitempty"`
+	AdvancedBucketConnection *S3AdvancedBucketConnection `json:"advancedBucketConnection,omitempty"`
+}
+
+// S3BlobStore is a blob store in an S3 bucket
+type S3BlobStore struct {
+	Name                string                `json:"name"`
+	SoftQuota           *SoftQuota            `json:"softQuota,omitempty"`
+	BucketConfiguration S3BucketConfiguration `json:"bucketConfiguration"`
+}
+
+// BlobStoreS3 was the options of the Groovy script which created an S3 blob store.
+//
+// Deprecated: S3 blob stores are created through the REST API with an S3BlobStore, see CreateS3BlobStore
+type BlobStoreS3 = S3BlobStore
+
+// FillPolicy is how a group blob store chooses the member which receives new blobs
+type FillPolicy string
+
+// Fill policies of group blob stores
+const (
+	FillPolicyWriteToFirst FillPolicy = "writeToFirst"
+	FillPolicyRoundRobin   FillPolicy = "roundRobin"
+)
+
+// GroupBlobStore is a blob store which spreads its blobs over its members
+type GroupBlobStore struct {
+	Name       string     `json:"name"`
+	SoftQuota  *SoftQuota `json:"softQuota,omitempty"`
+	Members    []string   `json:"members"`
+	FillPolicy FillPolicy `json:"fillPolicy"`
+}
+
+// BlobStoreQuotaStatus tells whether a blob store violates its soft quota
+type BlobStoreQuotaStatus struct {
+	BlobStoreName string `json:"blobStoreName"`
+	IsViolation   bool   `json:"isViolation"`
+	Message       string `json:"message"`
+}
+
+// GetBlobStoresContext returns the blob stores of the RM instance
+func GetBlobStoresContext(ctx context.Context, rm RM) ([]BlobStore, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get blob stores: %w", err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, restBlobStores)
+	if err != nil {
+		return nil, doError(err)
+	}
+
+	stores := make([]BlobStore, 0)
+	if err := json.Unmarshal(body, &stores); err != nil {
+		return nil, doError(err)
+	}
+
+	return stores, nil
+}
+
+// GetBlobStores returns the blob stores of the RM instance
+func GetBlobStores(rm RM) ([]BlobStore, error) {
+	return GetBlobStoresContext(context.Background(), rm)
+}
+
+// GetBlobStoreByNameContext returns the named blob store as listed by RM
+func GetBlobStoreByNameContext(ctx context.Context, rm RM, name string) (BlobStore, error) {
+	stores, err := GetBlobStoresContext(ctx, rm)
+	if err != nil {
+		return BlobStore{}, err
+	}
+
+	for _, store := range stores {
+		if store.Name == name {
+			return store, nil
+		}
+	}
+
+	return BlobStore{}, fmt.Errorf("did not find blob store '%s': %w", name, nexus.ErrNotFound)
+}
+
+// GetBlobStoreByName returns the named blob store as listed by RM
+func GetBlobStoreByName(rm RM, name string) (BlobStore, error) {
+	return GetBlobStoreByNameContext(context.Background(), rm, name)
+}
+
+func getBlobStore(ctx context.Context, rm RM, storeType BlobStoreType, name string, store interface{}) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get %s blob store '%s': %w", storeType.endpoint(), name, err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf(restBlobStoreByType, storeType.endpoint(), name))
+	if err != nil {
+		return doError(err)
+	}
+
+	if err := json.Unmarshal(body, store); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// saveBlobStore creates the blob store, or updates it if the name is given
+func saveBlobStore(ctx context.Context, rm RM, storeType BlobStoreType, name string, store interface{}) error {
+	if err := Capabilities(rm).Require(ctx, FeatureBlobStores); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf(restBlobStore, storeType.endpoint())
+	if name != "" {
+		endpoint = fmt.Sprintf(restBlobStoreByType, storeType.endpoint(), name)
+	}
+
+	buf, err := json.Marshal(store)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		_, _, err = nexus.PostContext(ctx, rm, endpoint, bytes.NewBuffer(buf))
+	} else {
+		_, _, err = nexus.PutContext(ctx, rm, endpoint, bytes.NewBuffer(buf))
+	}
+	return err
+}
+
+// GetFileBlobStoreContext returns the configuration of the named file blob store
+func GetFileBlobStoreContext(ctx context.Context, rm RM, name string) (FileBlobStore, error) {
+	var store FileBlobStore
+	if err := getBlobStore(ctx, rm, BlobStoreTypeFile, name, &store); err != nil {
+		return FileBlobStore{}, err
+	}
+	store.Name = name
+
+	return store, nil
+}
+
+// GetFileBlobStore returns the configuration of the named file blob store
+func GetFileBlobStore(rm RM, name string) (FileBlobStore, error) {
+	return GetFileBlobStoreContext(context.Background(), rm, name)
+}
+
+// CreateFileBlobStoreContext creates a file blob store
+func CreateFileBlobStoreContext(ctx context.Context, rm RM, store FileBlobStore) error {
+	if err := saveBlobStore(ctx, rm, BlobStoreTypeFile, "", store); err != nil {
+		return fmt.Errorf("could not create file blob store '%s': %w", store.Name, err)
+	}
+
+	return nil
+}
+
+// CreateFileBlobStore creates a file blob store
+func CreateFileBlobStore(rm RM, store FileBlobStore) error {
+	return CreateFileBlobStoreContext(context.Background(), rm, store)
+}
+
+// UpdateFileBlobStoreContext changes the path and soft quota of a file blob store
+func UpdateFileBlobStoreContext(ctx context.Context, rm RM, store FileBlobStore) error {
+	if err := saveBlobStore(ctx, rm, BlobStoreTypeFile, store.Name, store); err != nil {
+		return fmt.Errorf("could not update file blob store '%s': %w", store.Name, err)
+	}
+
+	return nil
+}
+
+// UpdateFileBlobStore changes the path and soft quota of a file blob store
+func UpdateFileBlobStore(rm RM, store FileBlobStore) error {
+	return UpdateFileBlobStoreContext(context.Background(), rm, store)
+}
+
+// GetS3BlobStoreContext returns the configuration of the named S3 blob store
+func GetS3BlobStoreContext(ctx context.Context, rm RM, name string) (S3BlobStore, error) {
+	var store S3BlobStore
+	if err := getBlobStore(ctx, rm, BlobStoreTypeS3, name, &store); err != nil {
+		return S3BlobStore{}, err
+	}
+	store.Name = name
+
+	return store, nil
+}
+
+// GetS3BlobStore returns the configuration of the named S3 blob store
+func GetS3BlobStore(rm RM, name string) (S3BlobStore, error) {
+	return GetS3BlobStoreContext(context.Background(), rm, name)
+}
+
+// CreateS3BlobStoreContext creates an S3 blob store
+func CreateS3BlobStoreContext(ctx context.Context, rm RM, store S3BlobStore) error {
+	if err := saveBlobStore(ctx, rm, BlobStoreTypeS3, "", store); err != nil {
+		return fmt.Errorf("could not create S3 blob store '%s': %w", store.Name, err)
+	}
+
+	return nil
+}
+
+// CreateS3BlobStore creates an S3 blob store
+func CreateS3BlobStore(rm RM, store S3BlobStore) error {
+	return CreateS3BlobStoreContext(context.Background(), rm, store)
+}
+
+// UpdateS3BlobStoreContext changes the bucket configuration and soft quota of an S3 blob store
+func UpdateS3BlobStoreContext(ctx context.Context, rm RM, store S3BlobStore) error {
+	if err := saveBlobStore(ctx, rm, BlobStoreTypeS3, store.Name, store); err != nil {
+		return fmt.Errorf("could not update S3 blob store '%s': %w", store.Name, err)
+	}
+
+	return nil
+}
+
+// UpdateS3BlobStore changes the bucket configuration and soft quota of an S3 blob store
+func UpdateS3BlobStore(rm RM, store S3BlobStore) error {
+	return UpdateS3BlobStoreContext(context.Background(), rm, store)
+}
+
+// GetGroupBlobStoreContext returns the configuration of the named group blob store
+func GetGroupBlobStoreContext(ctx context.Context, rm RM, name string) (GroupBlobStore, error) {
+	var store GroupBlobStore
+	if err := getBlobStore(ctx, rm, BlobStoreTypeGroup, name, &store); err != nil {
+		return GroupBlobStore{}, err
+	}
+	store.Name = name
+
+	return store, nil
+}
+
+// GetGroupBlobStore returns the configuration of the named group blob store
+func GetGroupBlobStore(rm RM, name string) (GroupBlobStore, error) {
+	return GetGroupBlobStoreContext(context.Background(), rm, name)
+}
+
+// CreateGroupBlobStoreContext creates a group blob store, which writes to its first member unless it has another fill policy
+func CreateGroupBlobStoreContext(ctx context.Context, rm RM, store GroupBlobStore) error {
+	if store.FillPolicy == "" {
+		store.FillPolicy = FillPolicyWriteToFirst
+	}
+
+	if err := saveBlobStore(ctx, rm, BlobStoreTypeGroup, "", store); err != nil {
+		return fmt.Errorf("could not create group blob store '%s': %w", store.Name, err)
+	}
+
+	return nil
+}
+
+// CreateGroupBlobStore creates a group blob store, which writes to its first member unless it has another fill policy
+func CreateGroupBlobStore(rm RM, store GroupBlobStore) error {
+	return CreateGroupBlobStoreContext(context.Background(), rm, store)
+}
+
+// UpdateGroupBlobStoreContext changes the members, fill policy and soft quota of a group blob store.
+// Members can be added, but only removed once they are emptied
+func UpdateGroupBlobStoreContext(ctx context.Context, rm RM, store GroupBlobStore) error {
+	if err := saveBlobStore(ctx, rm, BlobStoreTypeGroup, store.Name, store); err != nil {
+		return fmt.Errorf("could not update group blob store '%s': %w", store.Name, err)
+	}
+
+	return nil
+}
+
+// UpdateGroupBlobStore changes the members, fill policy and soft quota of a group blob store
+func UpdateGroupBlobStore(rm RM, store GroupBlobStore) error {
+	return UpdateGroupBlobStoreContext(context.Background(), rm, store)
+}
+
+// DeleteBlobStoreContext deletes the named blob store, which must not be used by any repository
+func DeleteBlobStoreContext(ctx context.Context, rm RM, name string) error {
+	if _, err := nexus.DelContext(ctx, rm, fmt.Sprintf(restBlobStore, name)); err != nil {
+		return fmt.Errorf("could not delete blob store '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteBlobStore deletes the named blob store, which must not be used by any repository
+func DeleteBlobStore(rm RM, name string) error {
+	return DeleteBlobStoreContext(context.Background(), rm, name)
+}
+
+// GetBlobStoreQuotaStatusContext checks whether the named blob store violates its soft quota
+func GetBlobStoreQuotaStatusContext(ctx context.Context, rm RM, name string) (BlobStoreQuotaStatus, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get quota status of blob store '%s': %w", name, err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf(restBlobStoreQuotaStatus, name))
+	if err != nil {
+		return BlobStoreQuotaStatus{}, doError(err)
+	}
+
+	var status BlobStoreQuotaStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return BlobStoreQuotaStatus{}, doError(err)
+	}
+
+	return status, nil
+}
+
+// GetBlobStoreQuotaStatus checks whether the named blob store violates its soft quota
+func GetBlobStoreQuotaStatus(rm RM, name string) (BlobStoreQuotaStatus, error) {
+	return GetBlobStoreQuotaStatusContext(context.Background(), rm, name)
+}
+
+// GetBlobStoreQuotaViolationsContext checks the blob stores which have a soft quota, and returns those in violation
+func GetBlobStoreQuotaViolationsContext(ctx context.Context, rm RM) ([]BlobStoreQuotaStatus, error) {
+	stores, err := GetBlobStoresContext(ctx, rm)
+	if err != nil {
+		return nil, fmt.Errorf("could not check quotas: %w", err)
+	}
+
+	quotas := make([]BlobStore, 0, len(stores))
+	for _, store := range stores {
+		if store.SoftQuota != nil {
+			quotas = append(quotas, store)
+		}
+	}
+
+	statuses := make([]BlobStoreQuotaStatus, len(quotas))
+	err = nexus.FanOut(ctx, rm, len(quotas), 1, func(ctx context.Context, i int) (err error) {
+		statuses[i], err = GetBlobStoreQuotaStatusContext(ctx, rm, quotas[i].Name)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not check quotas: %w", err)
+	}
+
+	violations := make([]BlobStoreQuotaStatus, 0)
+	for _, status := range statuses {
+		if status.IsViolation {
+			violations = append(violations, status)
+		}
+	}
+
+	return violations, nil
+}
+
+// GetBlobStoreQuotaViolations checks the blob stores which have a soft quota, and returns those in violation
+func GetBlobStoreQuotaViolations(rm RM) ([]BlobStoreQuotaStatus, error) {
+	return GetBlobStoreQuotaViolationsContext(context.Background(), rm)
+}
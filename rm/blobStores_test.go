@@ -0,0 +1,172 @@
+package nexusrm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	"github.com/sonatype-nexus-community/gonexus/rm/nexusrmtest"
+)
+
+func TestFileBlobStore(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	store := FileBlobStore{Name: "testname", Path: "testpath"}
+	if err := CreateFileBlobStore(rm, store); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateFileBlobStore(rm, store); err == nil {
+		t.Error("Expected an error creating an existing blob store")
+	}
+
+	store.SoftQuota = &SoftQuota{Type: SoftQuotaSpaceUsed, Limit: 1024}
+	if err := UpdateFileBlobStore(rm, store); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetFileBlobStore(rm, "testname")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, store) {
+		t.Errorf("Unexpected blob store\ngot:  %+v\nwant: %+v", got, store)
+	}
+
+	listed, err := GetBlobStoreByName(rm, "testname")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listed.Type != BlobStoreTypeFile || !reflect.DeepEqual(listed.SoftQuota, store.SoftQuota) {
+		t.Errorf("Unexpected blob store %+v", listed)
+	}
+
+	if _, err = GetS3BlobStore(rm, "testname"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found getting a file blob store as S3 but got %v", err)
+	}
+}
+
+func TestS3BlobStore(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	store := S3BlobStore{
+		Name: "s3",
+		BucketConfiguration: S3BucketConfiguration{
+			Bucket:                   S3Bucket{Region: "us-east-1", Name: "nexus", Prefix: "blobs", Expiration: 3},
+			Encryption:               &S3Encryption{EncryptionType: "s3ManagedEncryption"},
+			BucketSecurity:           &S3BucketSecurity{AccessKeyID: "key", SecretAccessKey: "secret"},
+			AdvancedBucketConnection: &S3AdvancedBucketConnection{Endpoint: "http://minio:9000", ForcePathStyle: true},
+		},
+	}
+	if err := CreateS3BlobStore(rm, store); err != nil {
+		t.Fatal(err)
+	}
+
+	store.BucketConfiguration.Bucket.Expiration = -1
+	if err := UpdateS3BlobStore(rm, store); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetS3BlobStore(rm, "s3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, store) {
+		t.Errorf("Unexpected blob store\ngot:  %+v\nwant: %+v", got, store)
+	}
+}
+
+func TestGroupBlobStore(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	for _, name := range []string{"f1", "f2", "f3"} {
+		if err := CreateFileBlobStore(rm, FileBlobStore{Name: name, Path: "path" + name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := CreateGroupBlobStore(rm, GroupBlobStore{Name: "grpname", Members: []string{"f1", "f2"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := GetGroupBlobStore(rm, "grpname")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := GroupBlobStore{Name: "grpname", Members: []string{"f1", "f2"}, FillPolicy: FillPolicyWriteToFirst}
+	if !reflect.DeepEqual(store, want) {
+		t.Errorf("Unexpected blob store\ngot:  %+v\nwant: %+v", store, want)
+	}
+
+	store.Members = append(store.Members, "f3")
+	store.FillPolicy = FillPolicyRoundRobin
+	if err = UpdateGroupBlobStore(rm, store); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetGroupBlobStore(rm, "grpname"); !reflect.DeepEqual(got, store) {
+		t.Errorf("Unexpected blob store\ngot:  %+v\nwant: %+v", got, store)
+	}
+
+	if err = CreateGroupBlobStore(rm, GroupBlobStore{Name: "bad", Members: []string{"nope"}}); err == nil {
+		t.Error("Expected an error creating a group of a missing blob store")
+	}
+}
+
+func TestDeleteBlobStore(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	CreateFileBlobStore(rm, FileBlobStore{Name: "used", Path: "used"})
+	CreateFileBlobStore(rm, FileBlobStore{Name: "unused", Path: "unused"})
+	if err := CreateHostedRepository(rm, Raw, RepositoryConfig{Name: "raw", Storage: StorageAttributes{BlobStoreName: "used"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteBlobStore(rm, "used"); err == nil {
+		t.Error("Expected an error deleting a blob store used by a repository")
+	}
+
+	if err := DeleteBlobStore(rm, "unused"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetBlobStoreByName(rm, "unused"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected the blob store to be deleted but got %v", err)
+	}
+	if err := DeleteBlobStore(rm, "unused"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found deleting a missing blob store but got %v", err)
+	}
+}
+
+func TestBlobStoreQuotaViolations(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	server.AddBlobStore(nexusrmtest.BlobStore{Name: "default", TotalSizeInBytes: 2048})
+	server.AddBlobStore(nexusrmtest.BlobStore{
+		Name: "full", TotalSizeInBytes: 2048,
+		SoftQuota: &nexusrmtest.SoftQuota{Type: string(SoftQuotaSpaceUsed), Limit: 1024},
+	})
+	server.AddBlobStore(nexusrmtest.BlobStore{
+		Name: "roomy", AvailableSpaceInBytes: 4096,
+		SoftQuota: &nexusrmtest.SoftQuota{Type: string(SoftQuotaSpaceRemaining), Limit: 1024},
+	})
+
+	status, err := GetBlobStoreQuotaStatus(rm, "roomy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.IsViolation || status.BlobStoreName != "roomy" {
+		t.Errorf("Unexpected quota status %+v", status)
+	}
+
+	violations, err := GetBlobStoreQuotaViolations(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].BlobStoreName != "full" || violations[0].Message == "" {
+		t.Errorf("Expected the full blob store to violate its quota but got %+v", violations)
+	}
+}
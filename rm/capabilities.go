@@ -0,0 +1,73 @@
+package nexusrm
+
+import (
+	"context"
+	"strings"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+// serverHeaderPrefix starts the Server header of Repository Manager responses, e.g. "Nexus/3.29.2-02 (PRO)"
+const serverHeaderPrefix = "Nexus/"
+
+// Features of Repository Manager which are only available from a given release
+var (
+	FeatureTagging = nexus.Feature{Name: "tagging", Since: nexus.Version{Major: 3, Minor: 13}}
+	FeatureStaging = nexus.Feature{Name: "staging", Since: nexus.Version{Major: 3, Minor: 13}}
+
+	FeatureRepositoryManagement = nexus.Feature{Name: "repository management", Since: nexus.Version{Major: 3, Minor: 21}}
+	FeatureBlobStores           = nexus.Feature{Name: "blob stores", Since: nexus.Version{Major: 3, Minor: 19}}
+	FeatureSecurityManagement   = nexus.Feature{Name: "security management", Since: nexus.Version{Major: 3, Minor: 19}}
+	FeatureContentSelectors     = nexus.Feature{Name: "content selectors", Since: nexus.Version{Major: 3, Minor: 19}}
+)
+
+func detectVersion(ctx context.Context, client nexus.Client) (nexus.Version, error) {
+	_, resp, err := nexus.GetContext(ctx, client, restStatusReadable)
+	if resp == nil {
+		return nexus.Version{}, err
+	}
+
+	server := resp.Header.Get("Server")
+	if !strings.HasPrefix(server, serverHeaderPrefix) {
+		return nexus.Version{}, nil
+	}
+
+	version, err := nexus.ParseVersion(strings.TrimPrefix(server, serverHeaderPrefix))
+	if err != nil {
+		return nexus.Version{}, nil
+	}
+
+	return version, nil
+}
+
+// Capabilities returns the capabilities of the Repository Manager. Those of clients created with New are cached,
+// so the version of the server is only detected once, including through wrappers such as nexus.DryRun
+func Capabilities(rm RM) *nexus.Capabilities {
+	if c, ok := rm.(*rmClient); ok {
+		return c.capabilities
+	}
+	if c, ok := rm.(interface{ Unwrap() nexus.Client }); ok {
+		return Capabilities(c.Unwrap())
+	}
+	return nexus.NewCapabilities(rm, detectVersion)
+}
+
+// ServerVersionContext returns the version of the Repository Manager
+func ServerVersionContext(ctx context.Context, rm RM) (nexus.Version, error) {
+	return Capabilities(rm).Version(ctx)
+}
+
+// ServerVersion returns the version of the Repository Manager
+func ServerVersion(rm RM) (nexus.Version, error) {
+	return ServerVersionContext(context.Background(), rm)
+}
+
+// SupportsContext determines if the Repository Manager has the given feature
+func SupportsContext(ctx context.Context, rm RM, feature nexus.Feature) (bool, error) {
+	return Capabilities(rm).Supports(ctx, feature)
+}
+
+// Supports determines if the Repository Manager has the given feature
+func Supports(rm RM, feature nexus.Feature) (bool, error) {
+	return SupportsContext(context.Background(), rm, feature)
+}
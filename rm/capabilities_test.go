@@ -0,0 +1,40 @@
+package nexusrm
+
+import (
+	"errors"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+func TestServerVersion(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	version, err := ServerVersion(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := nexus.MustParseVersion(server.Version); version != want {
+		t.Errorf("got %v, want %v", version, want)
+	}
+}
+
+func TestUnsupportedFeature(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+	server.Version = "3.12.1-01"
+
+	if ok, err := Supports(rm, FeatureTagging); err != nil || ok {
+		t.Errorf("Did not expect tagging to be supported: %v", err)
+	}
+
+	if _, err := AddTag(rm, "tag", nil); !errors.Is(err, nexus.ErrUnsupported) {
+		t.Errorf("Expected unsupported error but got: %v", err)
+	}
+
+	if len(server.Tags()) != 0 {
+		t.Error("Did not expect tag to be created")
+	}
+}
@@ -2,12 +2,14 @@ package nexusrm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
-	"net/http"
 	"strings"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -110,7 +112,7 @@ func (a UploadComponentMaven) write(w *multipart.Writer) error {
 			w.WriteField(fieldName+".extension", a.Extension)
 
 			if err := writeMultipartAsset(w, fieldName, a.File); err != nil {
-				return fmt.Errorf("could not add asset: %v", err)
+				return fmt.Errorf("could not add asset: %w", err)
 			}
 		}
 	}
@@ -168,7 +170,7 @@ func (a UploadComponentRaw) write(w *multipart.Writer) error {
 			w.WriteField(fieldName+".filename", a.Filename)
 
 			if err := writeMultipartAsset(w, fieldName, a.File); err != nil {
-				return fmt.Errorf("could not add asset: %v", err)
+				return fmt.Errorf("could not add asset: %w", err)
 			}
 		}
 	}
@@ -199,7 +201,7 @@ func (a UploadComponentYum) write(w *multipart.Writer) error {
 			w.WriteField(fieldName+".filename", a.Filename)
 
 			if err := writeMultipartAsset(w, fieldName, a.File); err != nil {
-				return fmt.Errorf("could not add asset: %v", err)
+				return fmt.Errorf("could not add asset: %w", err)
 			}
 		}
 	}
@@ -217,7 +219,7 @@ func (a UploadComponentNpm) write(w *multipart.Writer) error {
 	w.WriteField("npm.tag", a.Tag)
 
 	if err := writeMultipartAsset(w, "npm.asset", a.File); err != nil {
-		return fmt.Errorf("could not add asset: %v", err)
+		return fmt.Errorf("could not add asset: %w", err)
 	}
 
 	return nil
@@ -233,7 +235,7 @@ func (a UploadComponentPyPi) write(w *multipart.Writer) error {
 	w.WriteField("pypi.tag", a.Tag)
 
 	if err := writeMultipartAsset(w, "pypi.asset", a.File); err != nil {
-		return fmt.Errorf("could not add asset: %v", err)
+		return fmt.Errorf("could not add asset: %w", err)
 	}
 
 	return nil
@@ -249,7 +251,7 @@ func (a UploadComponentNuget) write(w *multipart.Writer) error {
 	w.WriteField("nuget.tag", a.Tag)
 
 	if err := writeMultipartAsset(w, "nuget.asset", a.File); err != nil {
-		return fmt.Errorf("could not add asset: %v", err)
+		return fmt.Errorf("could not add asset: %w", err)
 	}
 
 	return nil
@@ -265,7 +267,7 @@ func (a UploadComponentRubyGems) write(w *multipart.Writer) error {
 	w.WriteField("rubygems.tag", a.Tag)
 
 	if err := writeMultipartAsset(w, "rubygems.asset", a.File); err != nil {
-		return fmt.Errorf("could not add asset: %v", err)
+		return fmt.Errorf("could not add asset: %w", err)
 	}
 
 	return nil
@@ -281,63 +283,48 @@ func (a UploadComponentApt) write(w *multipart.Writer) error {
 	w.WriteField("apt.tag", a.Tag)
 
 	if err := writeMultipartAsset(w, "apt.asset", a.File); err != nil {
-		return fmt.Errorf("could not add asset: %v", err)
+		return fmt.Errorf("could not add asset: %w", err)
 	}
 
 	return nil
 }
 
-// GetComponents returns a list of components in the indicated repository
-func GetComponents(rm RM, repo string) ([]RepositoryItem, error) {
-	continuation := ""
-
-	getComponents := func() (listResp listComponentsResponse, err error) {
-		url := fmt.Sprintf(restListComponentsByRepo, repo)
-
-		if continuation != "" {
-			url += "&continuationToken=" + continuation
-		}
-
-		body, resp, err := rm.Get(url)
-		if err != nil || resp.StatusCode != http.StatusOK {
-			return
-		}
-
-		err = json.Unmarshal(body, &listResp)
-
-		return
-	}
-
+// GetComponentsContext returns a list of components in the indicated repository
+func GetComponentsContext(ctx context.Context, rm RM, repo string) ([]RepositoryItem, error) {
 	items := make([]RepositoryItem, 0)
-	for {
-		resp, err := getComponents()
-		if err != nil {
-			return items, fmt.Errorf("could not get components: %v", err)
-		}
 
-		items = append(items, resp.Items...)
-
-		if resp.ContinuationToken == "" {
-			break
+	p := ComponentsPaginator(rm, repo)
+	for p.Next(ctx) {
+		var page []RepositoryItem
+		if err := p.Decode(&page); err != nil {
+			return items, fmt.Errorf("could not get components: %w", err)
 		}
+		items = append(items, page...)
+	}
 
-		continuation = resp.ContinuationToken
+	if err := p.Err(); err != nil {
+		return items, fmt.Errorf("could not get components: %w", err)
 	}
 
 	return items, nil
 }
 
-// GetComponentByID returns a component by ID
-func GetComponentByID(rm RM, id string) (RepositoryItem, error) {
+// GetComponents returns a list of components in the indicated repository
+func GetComponents(rm RM, repo string) ([]RepositoryItem, error) {
+	return GetComponentsContext(context.Background(), rm, repo)
+}
+
+// GetComponentByIDContext returns a component by ID
+func GetComponentByIDContext(ctx context.Context, rm RM, id string) (RepositoryItem, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("no component with id '%s': %v", id, err)
+		return fmt.Errorf("no component with id '%s': %w", id, err)
 	}
 
 	var item RepositoryItem
 
 	url := fmt.Sprintf("%s/%s", restComponents, id)
-	body, resp, err := rm.Get(url)
-	if err != nil || resp.StatusCode != http.StatusOK {
+	body, _, err := nexus.GetContext(ctx, rm, url)
+	if err != nil {
 		return item, doError(err)
 	}
 
@@ -348,25 +335,35 @@ func GetComponentByID(rm RM, id string) (RepositoryItem, error) {
 	return item, nil
 }
 
-// DeleteComponentByID deletes the indicated component
-func DeleteComponentByID(rm RM, id string) error {
+// GetComponentByID returns a component by ID
+func GetComponentByID(rm RM, id string) (RepositoryItem, error) {
+	return GetComponentByIDContext(context.Background(), rm, id)
+}
+
+// DeleteComponentByIDContext deletes the indicated component
+func DeleteComponentByIDContext(ctx context.Context, rm RM, id string) error {
 	url := fmt.Sprintf("%s/%s", restComponents, id)
 
-	if resp, err := rm.Del(url); err != nil && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("component not deleted '%s': %v", id, err)
+	if _, err := nexus.DelContext(ctx, rm, url); err != nil {
+		return fmt.Errorf("component not deleted '%s': %w", id, err)
 	}
 
 	return nil
 }
 
-// UploadComponent uploads a component to repository manager
-func UploadComponent(rm RM, repo string, component UploadComponentWriter) error {
-	if _, err := GetRepositoryByName(rm, repo); err != nil {
-		return fmt.Errorf("could not find repository: %v", err)
+// DeleteComponentByID deletes the indicated component
+func DeleteComponentByID(rm RM, id string) error {
+	return DeleteComponentByIDContext(context.Background(), rm, id)
+}
+
+// UploadComponentContext uploads a component to repository manager
+func UploadComponentContext(ctx context.Context, rm RM, repo string, component UploadComponentWriter) error {
+	if _, err := GetRepositoryByNameContext(ctx, rm, repo); err != nil {
+		return fmt.Errorf("could not find repository: %w", err)
 	}
 
 	doError := func(err error) error {
-		return fmt.Errorf("component not uploaded: %v", err)
+		return fmt.Errorf("component not uploaded: %w", err)
 	}
 
 	var b bytes.Buffer
@@ -379,15 +376,20 @@ func UploadComponent(rm RM, repo string, component UploadComponentWriter) error
 	}
 
 	url := fmt.Sprintf(restListComponentsByRepo, repo)
-	req, err := rm.NewRequest("POST", url, &b)
-	req.Header.Set("Content-Type", w.FormDataContentType())
+	req, err := nexus.NewRequestWithContext(ctx, rm, "POST", url, &b)
 	if err != nil {
 		return doError(err)
 	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
 
-	if _, resp, err := rm.Do(req); err != nil && resp.StatusCode != http.StatusNoContent {
+	if _, _, err := rm.Do(req); err != nil {
 		return doError(err)
 	}
 
 	return nil
 }
+
+// UploadComponent uploads a component to repository manager
+func UploadComponent(rm RM, repo string, component UploadComponentWriter) error {
+	return UploadComponentContext(context.Background(), rm, repo, component)
+}
@@ -1,13 +1,17 @@
 package nexusrm
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 var dummyComponents = map[string][]RepositoryItem{
@@ -194,6 +198,18 @@ func TestGetComponentsPaging(t *testing.T) {
 	getComponentsTester(t, "repo-maven")
 }
 
+func TestGetComponentsContextCanceled(t *testing.T) {
+	rm, mock := componentsTestRM(t)
+	defer mock.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := GetComponentsContext(ctx, rm, "repo-maven"); err == nil {
+		t.Error("Expected an error when retrieving components with a canceled context")
+	}
+}
+
 func TestGetComponentByID(t *testing.T) {
 	rm, mock := componentsTestRM(t)
 	defer mock.Close()
@@ -341,3 +357,34 @@ func ExampleGetComponents() {
 	}
 	fmt.Printf("%q\n", items)
 }
+
+func TestDeleteComponentByIDDryRun(t *testing.T) {
+	rm, mock := componentsTestRM(t)
+	defer mock.Close()
+
+	dryRun := nexus.NewDryRun(rm)
+	id := dummyComponents["repo-maven"][0].ID
+
+	if err := DeleteComponentByID(dryRun, id); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetComponentByID(dryRun, id); err != nil {
+		t.Errorf("Expected the component not to be deleted but got %v", err)
+	}
+
+	want := []nexus.PlannedRequest{{Method: http.MethodDelete, Endpoint: restComponents + "/" + id}}
+	if plan := dryRun.Plan(); !reflect.DeepEqual(plan.Requests, want) {
+		t.Errorf("Unexpected plan %+v", plan)
+	}
+}
+
+func TestGetComponentByIDNotFound(t *testing.T) {
+	rm, mock := componentsTestRM(t)
+	defer mock.Close()
+
+	_, err := GetComponentByID(rm, "doesNotExist")
+	if !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected a not found error but got: %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package nexusrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const (
+	restContentSelectors = "service/rest/v1/security/content-selectors"
+	restContentSelector  = "service/rest/v1/security/content-selectors/%s"
+)
+
+// ContentSelector selects the content of repositories with a CSEL expression, see ParseCSEL
+type ContentSelector struct {
+	Name string `json:"name"`
+	// Type is the language of the expression, which is csel for the selectors created through the REST API
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description"`
+	Expression  string `json:"expression"`
+}
+
+// contentSelectorRequest leaves the type out of a content selector, which RM does not accept
+type contentSelectorRequest struct {
+	*ContentSelector
+	Type string `json:"type,omitempty"`
+}
+
+// Matches returns the assets selected by the expression of the content selector
+func (s ContentSelector) Matches(assets []RepositoryItemAsset) ([]RepositoryItemAsset, error) {
+	expression, err := ParseCSEL(s.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("could not match content selector '%s': %w", s.Name, err)
+	}
+
+	matches := make([]RepositoryItemAsset, 0)
+	for _, asset := range assets {
+		if expression.MatchesAsset(asset) {
+			matches = append(matches, asset)
+		}
+	}
+
+	return matches, nil
+}
+
+// GetContentSelectorsContext returns every content selector of RM
+func GetContentSelectorsContext(ctx context.Context, rm RM) ([]ContentSelector, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get content selectors: %w", err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, restContentSelectors)
+	if err != nil {
+		return nil, doError(err)
+	}
+
+	selectors := make([]ContentSelector, 0)
+	if err := json.Unmarshal(body, &selectors); err != nil {
+		return nil, doError(err)
+	}
+
+	return selectors, nil
+}
+
+// GetContentSelectors returns every content selector of RM
+func GetContentSelectors(rm RM) ([]ContentSelector, error) {
+	return GetContentSelectorsContext(context.Background(), rm)
+}
+
+// GetContentSelectorByNameContext returns the named content selector
+func GetContentSelectorByNameContext(ctx context.Context, rm RM, name string) (ContentSelector, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get content selector '%s': %w", name, err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf(restContentSelector, url.PathEscape(name)))
+	if err != nil {
+		return ContentSelector{}, doError(err)
+	}
+
+	var selector ContentSelector
+	if err := json.Unmarshal(body, &selector); err != nil {
+		return ContentSelector{}, doError(err)
+	}
+
+	return selector, nil
+}
+
+// GetContentSelectorByName returns the named content selector
+func GetContentSelectorByName(rm RM, name string) (ContentSelector, error) {
+	return GetContentSelectorByNameContext(context.Background(), rm, name)
+}
+
+// CreateContentSelectorContext creates a content selector. Its expression is validated by RM, see ParseCSEL to validate it beforehand
+func CreateContentSelectorContext(ctx context.Context, rm RM, selector ContentSelector) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not create content selector '%s': %w", selector.Name, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureContentSelectors); err != nil {
+		return doError(err)
+	}
+
+	buf, err := json.Marshal(contentSelectorRequest{ContentSelector: &selector})
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PostContext(ctx, rm, restContentSelectors, bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// CreateContentSelector creates a content selector. Its expression is validated by RM, see ParseCSEL to validate it beforehand
+func CreateContentSelector(rm RM, selector ContentSelector) error {
+	return CreateContentSelectorContext(context.Background(), rm, selector)
+}
+
+// UpdateContentSelectorContext replaces the description and expression of the named content selector
+func UpdateContentSelectorContext(ctx context.Context, rm RM, selector ContentSelector) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not update content selector '%s': %w", selector.Name, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureContentSelectors); err != nil {
+		return doError(err)
+	}
+
+	buf, err := json.Marshal(map[string]string{"description": selector.Description, "expression": selector.Expression})
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PutContext(ctx, rm, fmt.Sprintf(restContentSelector, url.PathEscape(selector.Name)), bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// UpdateContentSelector replaces the description and expression of the named content selector
+func UpdateContentSelector(rm RM, selector ContentSelector) error {
+	return UpdateContentSelectorContext(context.Background(), rm, selector)
+}
+
+// DeleteContentSelectorContext deletes the named content selector
+func DeleteContentSelectorContext(ctx context.Context, rm RM, name string) error {
+	if _, err := nexus.DelContext(ctx, rm, fmt.Sprintf(restContentSelector, url.PathEscape(name))); err != nil {
+		return fmt.Errorf("could not delete content selector '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteContentSelector deletes the named content selector
+func DeleteContentSelector(rm RM, name string) error {
+	return DeleteContentSelectorContext(context.Background(), rm, name)
+}
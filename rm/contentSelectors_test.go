@@ -0,0 +1,93 @@
+package nexusrm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	"github.com/sonatype-nexus-community/gonexus/rm/nexusrmtest"
+)
+
+func TestContentSelectors(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	selector := ContentSelector{Name: "acme", Description: "ACME artifacts", Expression: `path =^ "/com/acme/"`}
+	if err := CreateContentSelector(rm, selector); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateContentSelector(rm, selector); err == nil {
+		t.Error("Expected an error creating an existing content selector")
+	}
+
+	selector.Expression = `format == "maven2" and path =^ "/com/acme/"`
+	if err := UpdateContentSelector(rm, selector); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetContentSelectorByName(rm, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := selector
+	want.Type = "csel"
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected content selector\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	selectors, err := GetContentSelectors(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selectors) != 1 {
+		t.Errorf("Expected 1 content selector but got %+v", selectors)
+	}
+
+	err = CreatePrivilege(rm, Privilege{
+		Type: PrivilegeRepositoryContentSelector, Name: "acme-read",
+		Format: "*", Repository: "*", ContentSelector: "acme", Actions: []string{ActionRead},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteContentSelector(rm, "acme"); err == nil {
+		t.Error("Expected an error deleting a content selector used by a privilege")
+	}
+
+	if err := DeletePrivilege(rm, "acme-read"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteContentSelector(rm, "acme"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetContentSelectorByName(rm, "acme"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected the content selector to be deleted but got %v", err)
+	}
+}
+
+func TestContentSelectorMatches(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	server.AddComponent(nexusrmtest.Component{Repository: "maven-releases", Format: "maven2", Group: "com.acme", Name: "app", Version: "1.0", Assets: []nexusrmtest.Asset{{}}})
+	server.AddComponent(nexusrmtest.Component{Repository: "maven-releases", Format: "maven2", Group: "org.other", Name: "lib", Version: "2.0", Assets: []nexusrmtest.Asset{{}}})
+
+	assets, err := GetAssets(rm, "maven-releases")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	selector := ContentSelector{Name: "acme", Expression: `format == "maven2" and path =^ "/com/acme/"`}
+	matches, err := selector.Matches(assets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Path != "com/acme/app/1.0/app-1.0.jar" {
+		t.Errorf("Expected only the ACME asset to match but got %+v", matches)
+	}
+
+	if _, err := (ContentSelector{Name: "bad", Expression: `format ==`}).Matches(assets); err == nil {
+		t.Error("Expected an error matching an invalid expression")
+	}
+}
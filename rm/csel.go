@@ -0,0 +1,351 @@
+package nexusrm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CSELExpression is a parsed expression of the content selector expression language (CSEL), e.g.
+//
+//	format == "maven2" and path =^ "/com/acme/"
+//
+// Comparisons test a variable against a string literal with == (equals), != (differs), =~ (matches
+// the whole regular expression) or =^ (starts with), and are combined with and, or (or && and ||)
+// and parentheses. The variables are format, path and the coordinate.* variables of components,
+// such as coordinate.groupId.
+type CSELExpression struct {
+	source string
+	root   cselNode
+}
+
+// ParseCSEL parses a CSEL expression, which can be used to validate it before creating a content selector
+func ParseCSEL(expression string) (*CSELExpression, error) {
+	tokens, err := lexCSEL(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSEL expression: %w", err)
+	}
+
+	p := cselParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.peek().kind != cselEOF {
+		err = p.unexpected()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSEL expression: %w", err)
+	}
+
+	return &CSELExpression{source: expression, root: root}, nil
+}
+
+// String returns the expression as it was parsed
+func (e *CSELExpression) String() string {
+	return e.source
+}
+
+// Evaluate returns whether the expression is true for the given variables. Comparisons of a variable
+// which is not given are false, except for != which is true
+func (e *CSELExpression) Evaluate(variables map[string]string) bool {
+	return e.root.eval(variables)
+}
+
+// MatchesAsset returns whether the expression selects the asset. The coordinate variables are not known from an asset and are not given
+func (e *CSELExpression) MatchesAsset(asset RepositoryItemAsset) bool {
+	return e.Evaluate(AssetVariables(asset))
+}
+
+// AssetVariables returns the CSEL variables of an asset. RM lists asset paths without the leading slash they have in CSEL
+func AssetVariables(asset RepositoryItemAsset) map[string]string {
+	return map[string]string{
+		"format": asset.Format,
+		"path":   "/" + strings.TrimPrefix(asset.Path, "/"),
+	}
+}
+
+type cselNode interface {
+	eval(variables map[string]string) bool
+}
+
+type cselAnd struct{ left, right cselNode }
+
+func (n cselAnd) eval(variables map[string]string) bool {
+	return n.left.eval(variables) && n.right.eval(variables)
+}
+
+type cselOr struct{ left, right cselNode }
+
+func (n cselOr) eval(variables map[string]string) bool {
+	return n.left.eval(variables) || n.right.eval(variables)
+}
+
+type cselComparison struct {
+	op       string
+	variable string
+	value    string
+	pattern  *regexp.Regexp
+}
+
+func (n cselComparison) eval(variables map[string]string) bool {
+	v, ok := variables[n.variable]
+	if !ok {
+		return n.op == "!="
+	}
+
+	switch n.op {
+	case "==":
+		return v == n.value
+	case "!=":
+		return v != n.value
+	case "=~":
+		return n.pattern.MatchString(v)
+	case "=^":
+		return strings.HasPrefix(v, n.value)
+	}
+
+	return false
+}
+
+type cselTokenKind int
+
+const (
+	cselEOF cselTokenKind = iota
+	cselIdentifier
+	cselString
+	cselOperator
+	cselAndOp
+	cselOrOp
+	cselOpen
+	cselClose
+)
+
+type cselToken struct {
+	kind  cselTokenKind
+	text  string
+	value string
+	pos   int
+}
+
+func (t cselToken) String() string {
+	if t.kind == cselEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("'%s' at offset %d", t.text, t.pos)
+}
+
+func lexCSEL(expression string) ([]cselToken, error) {
+	var tokens []cselToken
+
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, cselToken{kind: cselOpen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, cselToken{kind: cselClose, text: ")", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			value, end, err := lexCSELString(expression, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, cselToken{kind: cselString, text: expression[i:end], value: value, pos: i})
+			i = end
+		case isCSELIdentifierStart(c):
+			end := i + 1
+			for end < len(expression) && (isCSELIdentifierStart(expression[end]) || isCSELDigit(expression[end]) || expression[end] == '.') {
+				end++
+			}
+
+			word := expression[i:end]
+			switch word {
+			case "and":
+				tokens = append(tokens, cselToken{kind: cselAndOp, text: word, pos: i})
+			case "or":
+				tokens = append(tokens, cselToken{kind: cselOrOp, text: word, pos: i})
+			default:
+				tokens = append(tokens, cselToken{kind: cselIdentifier, text: word, pos: i})
+			}
+			i = end
+		default:
+			op := expression[i:]
+			if len(op) > 2 {
+				op = op[:2]
+			}
+			switch op {
+			case "==", "!=", "=~", "=^":
+				tokens = append(tokens, cselToken{kind: cselOperator, text: op, pos: i})
+			case "&&":
+				tokens = append(tokens, cselToken{kind: cselAndOp, text: op, pos: i})
+			case "||":
+				tokens = append(tokens, cselToken{kind: cselOrOp, text: op, pos: i})
+			default:
+				return nil, fmt.Errorf("unexpected character '%c' at offset %d", c, i)
+			}
+			i += 2
+		}
+	}
+
+	return append(tokens, cselToken{kind: cselEOF, pos: len(expression)}), nil
+}
+
+// lexCSELString returns the value of the string literal starting at the given offset, and the offset after it
+func lexCSELString(expression string, start int) (string, int, error) {
+	quote := expression[start]
+
+	var value strings.Builder
+	for i := start + 1; i < len(expression); i++ {
+		switch c := expression[i]; {
+		case c == quote:
+			return value.String(), i + 1, nil
+		case c == '\\' && i+1 < len(expression):
+			i++
+			value.WriteByte(expression[i])
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string at offset %d", start)
+}
+
+func isCSELIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isCSELDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isCSELVariable returns whether the identifier is one of the variables of content selectors
+func isCSELVariable(identifier string) bool {
+	if identifier == "format" || identifier == "path" {
+		return true
+	}
+
+	name := strings.TrimPrefix(identifier, "coordinate.")
+	return name != identifier && name != "" && !strings.Contains(name, ".")
+}
+
+type cselParser struct {
+	tokens []cselToken
+	pos    int
+}
+
+func (p *cselParser) peek() cselToken {
+	return p.tokens[p.pos]
+}
+
+func (p *cselParser) next() cselToken {
+	t := p.tokens[p.pos]
+	if t.kind != cselEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *cselParser) unexpected() error {
+	return fmt.Errorf("unexpected %s", p.peek())
+}
+
+func (p *cselParser) parseOr() (cselNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == cselOrOp {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = cselOr{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *cselParser) parseAnd() (cselNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == cselAndOp {
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = cselAnd{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *cselParser) parsePrimary() (cselNode, error) {
+	if p.peek().kind != cselOpen {
+		return p.parseComparison()
+	}
+
+	p.next()
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != cselClose {
+		return nil, p.unexpected()
+	}
+	p.next()
+
+	return node, nil
+}
+
+func (p *cselParser) parseComparison() (cselNode, error) {
+	left := p.peek()
+	if left.kind != cselIdentifier && left.kind != cselString {
+		return nil, p.unexpected()
+	}
+	p.next()
+
+	op := p.peek()
+	if op.kind != cselOperator {
+		return nil, p.unexpected()
+	}
+	p.next()
+
+	right := p.peek()
+	if right.kind != cselIdentifier && right.kind != cselString {
+		return nil, p.unexpected()
+	}
+	p.next()
+
+	variable, literal := left, right
+	if left.kind == cselString && (op.text == "==" || op.text == "!=") {
+		// Equality is symmetric, so the literal can come first
+		variable, literal = right, left
+	}
+
+	switch {
+	case variable.kind != cselIdentifier:
+		return nil, fmt.Errorf("expected a variable but got %s", variable)
+	case literal.kind != cselString:
+		return nil, fmt.Errorf("expected a string but got %s", literal)
+	case !isCSELVariable(variable.text):
+		return nil, fmt.Errorf("unknown variable %s", variable)
+	}
+
+	node := cselComparison{op: op.text, variable: variable.text, value: literal.value}
+	if op.text == "=~" {
+		pattern, err := regexp.Compile("^(?:" + literal.value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %s: %w", literal, err)
+		}
+		node.pattern = pattern
+	}
+
+	return node, nil
+}
@@ -0,0 +1,101 @@
+package nexusrm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCSEL(t *testing.T) {
+	tests := []struct {
+		expression string
+		valid      bool
+	}{
+		{`format == "maven2"`, true},
+		{`format == "maven2" and path =^ "/com/acme/"`, true},
+		{`format == 'npm' || (path =~ ".*\\.tgz" && path != "/bad.tgz")`, true},
+		{`"maven2" == format`, true},
+		{`coordinate.groupId == "com.acme"`, true},
+		{`(((format == "raw")))`, true},
+		{``, false},
+		{`format`, false},
+		{`format ==`, false},
+		{`format = "maven2"`, false},
+		{`format == maven2`, false},
+		{`"a" == "b"`, false},
+		{`"/com" =^ path`, false},
+		{`fromat == "maven2"`, false},
+		{`coordinate. == "x"`, false},
+		{`format == "maven2" and`, false},
+		{`(format == "maven2"`, false},
+		{`format == "maven2")`, false},
+		{`format == "maven2`, false},
+		{`path =~ "["`, false},
+		{`!(format == "maven2")`, false},
+	}
+
+	for _, test := range tests {
+		_, err := ParseCSEL(test.expression)
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.expression, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", test.expression)
+		}
+	}
+}
+
+func TestParseCSELErrorOffset(t *testing.T) {
+	_, err := ParseCSEL(`format == "maven2" and and`)
+	if err == nil || !strings.Contains(err.Error(), "offset 23") {
+		t.Errorf("Expected the error to point at offset 23 but got %v", err)
+	}
+}
+
+func TestCSELEvaluate(t *testing.T) {
+	variables := map[string]string{"format": "maven2", "path": "/com/acme/app/1.0/app-1.0.jar"}
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{`format == "maven2"`, true},
+		{`format != "maven2"`, false},
+		{`path =^ "/com/acme/"`, true},
+		{`path =^ "/org/"`, false},
+		{`path =~ ".*\\.jar"`, true},
+		{`path =~ "app"`, false},
+		{`format == "npm" or path =^ "/com/"`, true},
+		{`format == "npm" or format == "maven2" and path =^ "/org/"`, false},
+		{`(format == "npm" or format == "maven2") and path =^ "/com/"`, true},
+		{`format == 'maven2' && path =~ '/com/acme/.*'`, true},
+		{`coordinate.groupId == "com.acme"`, false},
+		{`coordinate.groupId != "com.acme"`, true},
+		{`path == "/com/acme/app/1.0/app-1.0.jar"`, true},
+		{`path == '/it\'s'`, false},
+	}
+
+	for _, test := range tests {
+		expression, err := ParseCSEL(test.expression)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.expression, err)
+			continue
+		}
+		if got := expression.Evaluate(variables); got != test.want {
+			t.Errorf("%s: expected %v but got %v", test.expression, test.want, got)
+		}
+	}
+}
+
+func TestCSELMatchesAsset(t *testing.T) {
+	expression, err := ParseCSEL(`format == "maven2" and path =^ "/com/acme/"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !expression.MatchesAsset(RepositoryItemAsset{Format: "maven2", Path: "com/acme/app/1.0/app-1.0.jar"}) {
+		t.Error("Expected the asset to match, its listed path lacks the leading slash")
+	}
+	if expression.MatchesAsset(RepositoryItemAsset{Format: "npm", Path: "com/acme/app/-/app-1.0.tgz"}) {
+		t.Error("Expected an asset of another format not to match")
+	}
+}
@@ -0,0 +1,134 @@
+package nexusrm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+// DownloadProgress is called as content is written with the number of bytes written so far
+// and the total size of the content, or -1 if the size is unknown
+type DownloadProgress func(written, total int64)
+
+// DownloadOptions configures how content is streamed to a writer
+type DownloadOptions struct {
+	// Offset resumes an interrupted download by only requesting the content after the given number of bytes
+	Offset int64
+
+	// Progress, if set, is called as the content is written
+	Progress DownloadProgress
+}
+
+type progressWriter struct {
+	w              io.Writer
+	written, total int64
+	progress       DownloadProgress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if p.progress != nil {
+		p.progress(p.written, p.total)
+	}
+	return n, err
+}
+
+// endpointOf returns the given URL relative to the host of the RM instance
+func endpointOf(rm RM, downloadURL string) (string, error) {
+	host := strings.TrimSuffix(rm.Info().Host, "/")
+	if strings.HasPrefix(downloadURL, host+"/") {
+		return strings.TrimPrefix(downloadURL, host+"/"), nil
+	}
+
+	u, err := url.Parse(downloadURL)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(u.RequestURI(), "/"), nil
+}
+
+// contentRangeTotal parses the complete length from a header such as "bytes 100-199/200"
+func contentRangeTotal(contentRange string) int64 {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return -1
+	}
+
+	total, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+
+	return total
+}
+
+// DownloadContext streams the content at the given URL of the RM instance to the writer and returns the number of bytes written
+func DownloadContext(ctx context.Context, rm RM, downloadURL string, w io.Writer, options DownloadOptions) (int64, error) {
+	doError := func(err error) (int64, error) {
+		return 0, fmt.Errorf("could not download %s: %w", downloadURL, err)
+	}
+
+	endpoint, err := endpointOf(rm, downloadURL)
+	if err != nil {
+		return doError(err)
+	}
+
+	req, err := nexus.NewRequestWithContext(ctx, rm, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return doError(err)
+	}
+	if options.Offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", options.Offset))
+	}
+
+	body, resp, err := nexus.Stream(rm, req)
+	if err != nil {
+		return doError(err)
+	}
+	defer body.Close()
+
+	pw := &progressWriter{w: w, total: resp.ContentLength, progress: options.Progress}
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		pw.written = options.Offset
+		pw.total = contentRangeTotal(resp.Header.Get("Content-Range"))
+	case options.Offset > 0:
+		// The server ignored the range so skip the content which was already downloaded
+		if _, err := io.CopyN(ioutil.Discard, body, options.Offset); err != nil {
+			return doError(err)
+		}
+		pw.written = options.Offset
+	}
+
+	n, err := io.Copy(pw, body)
+	if err != nil {
+		return n, fmt.Errorf("could not download %s: %w", downloadURL, err)
+	}
+
+	return n, nil
+}
+
+// Download streams the content at the given URL of the RM instance to the writer and returns the number of bytes written
+func Download(rm RM, downloadURL string, w io.Writer, options DownloadOptions) (int64, error) {
+	return DownloadContext(context.Background(), rm, downloadURL, w, options)
+}
+
+// DownloadAssetContext streams the content of the asset to the writer and returns the number of bytes written
+func DownloadAssetContext(ctx context.Context, rm RM, asset RepositoryItemAsset, w io.Writer, options DownloadOptions) (int64, error) {
+	return DownloadContext(ctx, rm, asset.DownloadURL, w, options)
+}
+
+// DownloadAsset streams the content of the asset to the writer and returns the number of bytes written
+func DownloadAsset(rm RM, asset RepositoryItemAsset, w io.Writer, options DownloadOptions) (int64, error) {
+	return DownloadAssetContext(context.Background(), rm, asset, w, options)
+}
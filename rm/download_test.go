@@ -0,0 +1,112 @@
+package nexusrm
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const dummyAssetContent = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+func downloadTestRM(t *testing.T, honorRange bool) (RM, RepositoryItemAsset, func()) {
+	rm, mock := newTestRM(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repository/repo-maven/org/dummy/1.0.0/dummy-1.0.0.jar" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if !honorRange {
+			r.Header.Del("Range")
+		}
+		http.ServeContent(w, r, "dummy.jar", time.Time{}, strings.NewReader(dummyAssetContent))
+	})
+
+	asset := RepositoryItemAsset{
+		DownloadURL: mock.URL + "/repository/repo-maven/org/dummy/1.0.0/dummy-1.0.0.jar",
+		Path:        "org/dummy/1.0.0/dummy-1.0.0.jar",
+		Repository:  "repo-maven",
+	}
+
+	return rm, asset, mock.Close
+}
+
+func TestDownloadAsset(t *testing.T) {
+	rm, asset, done := downloadTestRM(t, true)
+	defer done()
+
+	var progress [][2]int64
+	var buf bytes.Buffer
+	n, err := DownloadAsset(rm, asset, &buf, DownloadOptions{
+		Progress: func(written, total int64) {
+			progress = append(progress, [2]int64{written, total})
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != int64(len(dummyAssetContent)) || buf.String() != dummyAssetContent {
+		t.Errorf("Did not receive expected content: %d %q", n, buf.String())
+	}
+
+	if len(progress) == 0 {
+		t.Fatal("Progress was not reported")
+	}
+
+	if last := progress[len(progress)-1]; last[0] != int64(len(dummyAssetContent)) || last[1] != int64(len(dummyAssetContent)) {
+		t.Errorf("Unexpected final progress: %v", last)
+	}
+}
+
+func TestDownloadAssetResume(t *testing.T) {
+	for _, honorRange := range []bool{true, false} {
+		rm, asset, done := downloadTestRM(t, honorRange)
+
+		const offset = 10
+
+		var last [2]int64
+		var buf bytes.Buffer
+		buf.WriteString(dummyAssetContent[:offset])
+
+		n, err := DownloadAsset(rm, asset, &buf, DownloadOptions{
+			Offset: offset,
+			Progress: func(written, total int64) {
+				last = [2]int64{written, total}
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != int64(len(dummyAssetContent)-offset) || buf.String() != dummyAssetContent {
+			t.Errorf("Range honored %v: did not resume download: %d %q", honorRange, n, buf.String())
+		}
+
+		if last[0] != int64(len(dummyAssetContent)) || last[1] != int64(len(dummyAssetContent)) {
+			t.Errorf("Range honored %v: unexpected final progress: %v", honorRange, last)
+		}
+
+		done()
+	}
+}
+
+func TestDownloadAssetNotFound(t *testing.T) {
+	rm, asset, done := downloadTestRM(t, true)
+	defer done()
+
+	asset.DownloadURL += ".missing"
+
+	var buf bytes.Buffer
+	if _, err := DownloadAsset(rm, asset, &buf, DownloadOptions{}); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected a not found error but got: %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("Did not expect any content to be written: %q", buf.String())
+	}
+}
@@ -1,9 +1,11 @@
 package nexusrm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restMaintenanceDBCheck = "service/rest/v1/maintenance/%s/check"
@@ -22,17 +24,17 @@ type DatabaseState struct {
 	IndexErrors    int  `json:"indexErrors"`
 }
 
-// CheckDatabase returns the state of the named database
-func CheckDatabase(rm RM, dbName string) (DatabaseState, error) {
+// CheckDatabaseContext returns the state of the named database
+func CheckDatabaseContext(ctx context.Context, rm RM, dbName string) (DatabaseState, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("error checking status of database '%s': %v", dbName, err)
+		return fmt.Errorf("error checking status of database '%s': %w", dbName, err)
 	}
 
 	var state DatabaseState
 
 	url := fmt.Sprintf(restMaintenanceDBCheck, dbName)
-	body, resp, err := rm.Put(url, nil)
-	if err != nil || resp.StatusCode != http.StatusOK {
+	body, _, err := nexus.PutContext(ctx, rm, url, nil)
+	if err != nil {
 		return state, doError(err)
 	}
 
@@ -43,8 +45,13 @@ func CheckDatabase(rm RM, dbName string) (DatabaseState, error) {
 	return state, nil
 }
 
-// CheckAllDatabases returns state on all of the databases
-func CheckAllDatabases(rm RM) (states map[string]DatabaseState, err error) {
+// CheckDatabase returns the state of the named database
+func CheckDatabase(rm RM, dbName string) (DatabaseState, error) {
+	return CheckDatabaseContext(context.Background(), rm, dbName)
+}
+
+// CheckAllDatabasesContext returns state on all of the databases
+func CheckAllDatabasesContext(ctx context.Context, rm RM) (states map[string]DatabaseState, err error) {
 	states = make(map[string]DatabaseState)
 
 	check := func(dbName string) {
@@ -52,8 +59,8 @@ func CheckAllDatabases(rm RM) (states map[string]DatabaseState, err error) {
 			return
 		}
 
-		if state, er := CheckDatabase(rm, dbName); er != nil {
-			err = fmt.Errorf("error with '%s' database when all states: %v", dbName, er)
+		if state, er := CheckDatabaseContext(ctx, rm, dbName); er != nil {
+			err = fmt.Errorf("error with '%s' database when all states: %w", dbName, er)
 		} else {
 			states[dbName] = state
 		}
@@ -66,3 +73,8 @@ func CheckAllDatabases(rm RM) (states map[string]DatabaseState, err error) {
 
 	return
 }
+
+// CheckAllDatabases returns state on all of the databases
+func CheckAllDatabases(rm RM) (states map[string]DatabaseState, err error) {
+	return CheckAllDatabasesContext(context.Background(), rm)
+}
@@ -0,0 +1,227 @@
+package nexusrmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// blobStoreTypes maps the types of the blob store endpoints to those of the blob stores
+var blobStoreTypes = map[string]string{"file": "File", "s3": "S3", "group": "Group"}
+
+// AddBlobStore adds a blob store to the fake, or replaces the one with the same name. File is assumed if no type is given
+func (s *Server) AddBlobStore(store BlobStore) BlobStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addBlobStore(store)
+}
+
+func (s *Server) addBlobStore(store BlobStore) BlobStore {
+	if store.Type == "" {
+		store.Type = "File"
+	}
+
+	if i := s.blobStoreIndex(store.Name); i >= 0 {
+		s.blobStores[i] = store
+	} else {
+		s.blobStores = append(s.blobStores, store)
+	}
+
+	return store
+}
+
+// BlobStores returns the blob stores of the fake
+func (s *Server) BlobStores() []BlobStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]BlobStore(nil), s.blobStores...)
+}
+
+func (s *Server) blobStoreIndex(name string) int {
+	for i, b := range s.blobStores {
+		if b.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) serveBlobStores(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, append([]BlobStore{}, s.blobStores...))
+	case len(path) == 1 && r.Method == http.MethodPost:
+		s.saveBlobStore(w, r, path[0], "")
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		s.deleteBlobStore(w, path[0])
+	case len(path) == 2 && r.Method == http.MethodGet && path[1] == "quota-status":
+		s.serveQuotaStatus(w, path[0])
+	case len(path) == 2 && r.Method == http.MethodGet:
+		i := s.blobStoreIndex(path[1])
+		if i < 0 || s.blobStores[i].Type != blobStoreTypes[path[0]] {
+			writeError(w, http.StatusNotFound, "blob store not found")
+			return
+		}
+
+		config := make(map[string]interface{})
+		for k, v := range s.blobStores[i].Config {
+			config[k] = v
+		}
+		if s.blobStores[i].SoftQuota != nil {
+			config["softQuota"] = s.blobStores[i].SoftQuota
+		}
+		writeJSON(w, http.StatusOK, config)
+	case len(path) == 2 && r.Method == http.MethodPut:
+		s.saveBlobStore(w, r, path[0], path[1])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// saveBlobStore creates a blob store, or updates the named one
+func (s *Server) saveBlobStore(w http.ResponseWriter, r *http.Request, endpoint, name string) {
+	storeType, ok := blobStoreTypes[endpoint]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid blob store")
+		return
+	}
+
+	var (
+		store  BlobStore
+		config map[string]interface{}
+	)
+	if json.Unmarshal(body, &store) != nil || json.Unmarshal(body, &config) != nil {
+		writeError(w, http.StatusBadRequest, "invalid blob store")
+		return
+	}
+	if name != "" {
+		store.Name = name
+	}
+
+	i := s.blobStoreIndex(store.Name)
+	switch {
+	case store.Name == "":
+		writeError(w, http.StatusBadRequest, "invalid blob store")
+		return
+	case name == "" && i >= 0:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("blob store %s already exists", store.Name))
+		return
+	case name != "" && (i < 0 || s.blobStores[i].Type != storeType):
+		writeError(w, http.StatusNotFound, "blob store not found")
+		return
+	}
+
+	if storeType == "Group" {
+		if message := s.checkGroup(store.Name, config); message != "" {
+			writeError(w, http.StatusBadRequest, message)
+			return
+		}
+	}
+
+	delete(config, "name")
+	delete(config, "softQuota")
+	store.Type, store.Config = storeType, config
+	if i >= 0 {
+		store.BlobCount, store.TotalSizeInBytes, store.AvailableSpaceInBytes = s.blobStores[i].BlobCount, s.blobStores[i].TotalSizeInBytes, s.blobStores[i].AvailableSpaceInBytes
+	}
+
+	s.addBlobStore(store)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkGroup returns why the members and fill policy of a group blob store are invalid, if they are
+func (s *Server) checkGroup(name string, config map[string]interface{}) string {
+	switch config["fillPolicy"] {
+	case "writeToFirst", "roundRobin":
+	default:
+		return fmt.Sprintf("unknown fill policy %v", config["fillPolicy"])
+	}
+
+	members, _ := config["members"].([]interface{})
+	for _, m := range members {
+		member, _ := m.(string)
+		i := s.blobStoreIndex(member)
+		switch {
+		case member == name:
+			return "a group blob store cannot be a member of itself"
+		case i < 0:
+			return fmt.Sprintf("blob store %s does not exist", member)
+		case s.blobStores[i].Type == "Group":
+			return fmt.Sprintf("blob store %s is a group", member)
+		}
+	}
+
+	return ""
+}
+
+// usedBy returns what uses the named blob store, if anything does
+func (s *Server) usedBy(name string) string {
+	for _, repo := range s.repositories {
+		if storage, ok := repo.Config["storage"].(map[string]interface{}); ok && storage["blobStoreName"] == name {
+			return fmt.Sprintf("repository %s", repo.Name)
+		}
+	}
+
+	for _, store := range s.blobStores {
+		members, _ := store.Config["members"].([]interface{})
+		for _, m := range members {
+			if m == name {
+				return fmt.Sprintf("blob store group %s", store.Name)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (s *Server) deleteBlobStore(w http.ResponseWriter, name string) {
+	i := s.blobStoreIndex(name)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "blob store not found")
+		return
+	}
+
+	if user := s.usedBy(name); user != "" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("blob store %s is in use by %s", name, user))
+		return
+	}
+
+	s.blobStores = append(s.blobStores[:i], s.blobStores[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serveQuotaStatus(w http.ResponseWriter, name string) {
+	i := s.blobStoreIndex(name)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "blob store not found")
+		return
+	}
+
+	store := s.blobStores[i]
+	status := map[string]interface{}{"blobStoreName": name, "isViolation": false, "message": ""}
+	if quota := store.SoftQuota; quota != nil {
+		switch quota.Type {
+		case "spaceUsedQuota":
+			if store.TotalSizeInBytes > quota.Limit {
+				status["isViolation"] = true
+				status["message"] = fmt.Sprintf("Blob store %s is using %d bytes, which exceeds the soft quota of %d bytes", name, store.TotalSizeInBytes, quota.Limit)
+			}
+		case "spaceRemainingQuota":
+			if store.AvailableSpaceInBytes < quota.Limit {
+				status["isViolation"] = true
+				status["message"] = fmt.Sprintf("Blob store %s has %d bytes available, which is less than the soft quota of %d bytes", name, store.AvailableSpaceInBytes, quota.Limit)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, status)
+}
@@ -0,0 +1,598 @@
+package nexusrmtest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AddRepository adds a repository to the fake. Hosted is assumed if no type is given
+func (s *Server) AddRepository(repo Repository) Repository {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addRepository(repo)
+}
+
+func (s *Server) addRepository(repo Repository) Repository {
+	if repo.Type == "" {
+		repo.Type = "hosted"
+	}
+
+	for i, r := range s.repositories {
+		if r.Name == repo.Name {
+			s.repositories[i] = repo
+			return repo
+		}
+	}
+
+	s.repositories = append(s.repositories, repo)
+	return repo
+}
+
+func (s *Server) repository(name string) (Repository, bool) {
+	for _, r := range s.repositories {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Repository{}, false
+}
+
+// Repositories returns the repositories of the fake
+func (s *Server) Repositories() []Repository {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Repository(nil), s.repositories...)
+}
+
+// AddComponent adds a component, and its assets, to the fake and returns it as stored.
+// IDs, paths and checksums are filled in where missing and the repository is created if needed
+func (s *Server) AddComponent(c Component) Component {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addComponent(c)
+}
+
+func (s *Server) addComponent(c Component) Component {
+	if _, ok := s.repository(c.Repository); !ok {
+		s.addRepository(Repository{Name: c.Repository, Format: c.Format})
+	}
+
+	if c.ID == "" {
+		c.ID = s.newID("component")
+	}
+
+	assets := make([]Asset, len(c.Assets))
+	for i, a := range c.Assets {
+		if a.ID == "" {
+			a.ID = s.newID("asset")
+		}
+		if a.Path == "" {
+			a.Path = defaultAssetPath(c, "", "jar")
+		}
+		a.Repository = c.Repository
+		a.Format = c.Format
+		if a.Checksum == nil {
+			a.Checksum = checksums(a.Content)
+		}
+		assets[i] = a
+	}
+	c.Assets = assets
+
+	s.components = append(s.components, c)
+
+	return c
+}
+
+func defaultAssetPath(c Component, classifier, extension string) string {
+	switch c.Format {
+	case "maven2":
+		name := c.Name + "-" + c.Version
+		if classifier != "" {
+			name += "-" + classifier
+		}
+		return path.Join(strings.Replace(c.Group, ".", "/", -1), c.Name, c.Version, name+"."+extension)
+	default:
+		return path.Join(strings.TrimPrefix(c.Group, "/"), c.Name, c.Version)
+	}
+}
+
+func checksums(content []byte) map[string]string {
+	sum := func(h hash.Hash) string {
+		h.Write(content)
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	return map[string]string{
+		"sha1":   sum(sha1.New()),
+		"md5":    sum(md5.New()),
+		"sha256": sum(sha256.New()),
+		"sha512": sum(sha512.New()),
+	}
+}
+
+// Components returns the components stored in the fake
+func (s *Server) Components() []Component {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Component(nil), s.components...)
+}
+
+// Component returns the stored component with the given ID
+func (s *Server) Component(id string) (Component, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.componentIndex(id)
+	if i < 0 {
+		return Component{}, false
+	}
+	return s.components[i], true
+}
+
+func (s *Server) componentIndex(id string) int {
+	for i, c := range s.components {
+		if c.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) removeComponent(i int) {
+	s.components = append(s.components[:i], s.components[i+1:]...)
+}
+
+// render fills in the download URLs relative to the host the request was sent to
+func render(r *http.Request, c Component) Component {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	assets := make([]Asset, len(c.Assets))
+	for i, a := range c.Assets {
+		a.DownloadURL = fmt.Sprintf("%s://%s%s%s/%s", scheme, r.Host, repositoryPrefix, a.Repository, a.Path)
+		assets[i] = a
+	}
+	c.Assets = assets
+
+	if c.Tags == nil {
+		c.Tags = []string{}
+	}
+
+	return c
+}
+
+func (s *Server) writable(w http.ResponseWriter) bool {
+	if s.readOnly.Frozen {
+		writeError(w, http.StatusServiceUnavailable, "repository manager is read-only")
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveComponents(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		repo := r.URL.Query().Get("repository")
+		if _, ok := s.repository(repo); !ok {
+			writeError(w, http.StatusNotFound, "repository not found")
+			return
+		}
+
+		var components []Component
+		for _, c := range s.components {
+			if c.Repository == repo {
+				components = append(components, render(r, c))
+			}
+		}
+
+		s.writePage(w, r, components)
+	case len(path) == 0 && r.Method == http.MethodPost:
+		if s.writable(w) {
+			s.uploadComponent(w, r)
+		}
+	case len(path) == 1 && r.Method == http.MethodGet:
+		i := s.componentIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "component not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, render(r, s.components[i]))
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		i := s.componentIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "component not found")
+			return
+		}
+		if s.writable(w) {
+			s.removeComponent(i)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, items interface{}) {
+	var total int
+	switch v := items.(type) {
+	case []Component:
+		total = len(v)
+	case []Asset:
+		total = len(v)
+	case []Tag:
+		total = len(v)
+	}
+
+	start, end, next, ok := s.page(r, total)
+	if !ok {
+		writeError(w, http.StatusNotAcceptable, "invalid continuation token")
+		return
+	}
+
+	resp := pagedResponse{ContinuationToken: next}
+	switch v := items.(type) {
+	case []Component:
+		resp.Items = append([]Component{}, v[start:end]...)
+	case []Asset:
+		resp.Items = append([]Asset{}, v[start:end]...)
+	case []Tag:
+		resp.Items = append([]Tag{}, v[start:end]...)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) findAsset(id string) (ci, ai int) {
+	for ci, c := range s.components {
+		for ai, a := range c.Assets {
+			if a.ID == id {
+				return ci, ai
+			}
+		}
+	}
+	return -1, -1
+}
+
+func (s *Server) serveAssets(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		repo := r.URL.Query().Get("repository")
+		if _, ok := s.repository(repo); !ok {
+			writeError(w, http.StatusNotFound, "repository not found")
+			return
+		}
+
+		var assets []Asset
+		for _, c := range s.components {
+			if c.Repository == repo {
+				assets = append(assets, render(r, c).Assets...)
+			}
+		}
+
+		s.writePage(w, r, assets)
+	case len(path) == 1 && r.Method == http.MethodGet:
+		ci, ai := s.findAsset(path[0])
+		if ci < 0 {
+			writeError(w, http.StatusNotFound, "asset not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, render(r, s.components[ci]).Assets[ai])
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		ci, ai := s.findAsset(path[0])
+		if ci < 0 {
+			writeError(w, http.StatusNotFound, "asset not found")
+			return
+		}
+		if !s.writable(w) {
+			return
+		}
+
+		c := &s.components[ci]
+		c.Assets = append(c.Assets[:ai:ai], c.Assets[ai+1:]...)
+		if len(c.Assets) == 0 {
+			s.removeComponent(ci)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// matches determines if the component satisfies the search criteria of the query.
+// Criteria which the fake does not support are ignored
+func matches(c Component, query map[string][]string) bool {
+	match := func(pattern, value string) bool {
+		if ok, err := path.Match(pattern, value); err == nil && ok {
+			return true
+		}
+		return pattern == value
+	}
+
+	hasAsset := func(f func(Asset) bool) bool {
+		for _, a := range c.Assets {
+			if f(a) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for key, values := range query {
+		v := values[0]
+		var ok bool
+		switch key {
+		case "repository":
+			ok = c.Repository == v
+		case "format":
+			ok = c.Format == v
+		case "group", "maven.groupId":
+			ok = match(v, c.Group)
+		case "name", "maven.artifactId":
+			ok = match(v, c.Name)
+		case "version", "maven.baseVersion":
+			ok = match(v, c.Version)
+		case "q":
+			ok = strings.Contains(c.Group, v) || strings.Contains(c.Name, v) || strings.Contains(c.Version, v)
+		case "tag":
+			for _, t := range c.Tags {
+				ok = ok || t == v
+			}
+		case "md5", "sha1", "sha256", "sha512":
+			ok = hasAsset(func(a Asset) bool { return a.Checksum[key] == v })
+		case "maven.extension":
+			ok = hasAsset(func(a Asset) bool { return path.Ext(a.Path) == "."+v })
+		default:
+			ok = true
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// search returns the components which match the search query of the request
+func (s *Server) search(r *http.Request) []Component {
+	query := r.URL.Query()
+	sortBy, direction := query.Get("sort"), query.Get("direction")
+	for _, control := range []string{"sort", "direction", "continuationToken"} {
+		delete(query, control)
+	}
+
+	var found []Component
+	for _, c := range s.components {
+		if matches(c, query) {
+			found = append(found, render(r, c))
+		}
+	}
+
+	sortComponents(found, sortBy, direction)
+
+	return found
+}
+
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request, path []string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	switch {
+	case len(path) == 0:
+		s.writePage(w, r, s.search(r))
+	case len(path) == 1 && path[0] == "assets":
+		var assets []Asset
+		for _, c := range s.search(r) {
+			assets = append(assets, c.Assets...)
+		}
+		s.writePage(w, r, assets)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+type uploadedAsset struct {
+	content               []byte
+	filename              string
+	classifier, extension string
+}
+
+func (s *Server) uploadComponent(w http.ResponseWriter, r *http.Request) {
+	repoName := r.URL.Query().Get("repository")
+	repo, ok := s.repository(repoName)
+	if !ok {
+		writeError(w, http.StatusNotFound, "repository not found")
+		return
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var format string
+	fields := make(map[string]string)
+	for k, v := range r.MultipartForm.Value {
+		fields[k] = v[0]
+		format = strings.SplitN(k, ".", 2)[0]
+	}
+	for k := range r.MultipartForm.File {
+		format = strings.SplitN(k, ".", 2)[0]
+	}
+
+	if format == "" {
+		writeError(w, http.StatusBadRequest, "no component given")
+		return
+	}
+	if repo.Format != "" && repo.Format != format {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("repository %s does not accept %s components", repo.Name, format))
+		return
+	}
+
+	tag := fields[format+".tag"]
+	if tag != "" && s.tagIndex(tag) < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("tag %s not found", tag))
+		return
+	}
+
+	// Assets sent without a filename are parsed as values rather than files
+	contents := make(map[string][]byte)
+	for k, v := range fields {
+		if isAssetField(format, k) {
+			contents[k] = []byte(v)
+		}
+	}
+	filenames := make(map[string]string)
+	for k, headers := range r.MultipartForm.File {
+		f, err := headers[0].Open()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		content, err := ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		contents[k] = content
+		filenames[k] = headers[0].Filename
+	}
+
+	var keys []string
+	for k := range contents {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var assets []uploadedAsset
+	for _, k := range keys {
+		filename := fields[k+".filename"]
+		if filename == "" {
+			filename = filenames[k]
+		}
+
+		assets = append(assets, uploadedAsset{
+			content:    contents[k],
+			filename:   filename,
+			classifier: fields[k+".classifier"],
+			extension:  fields[k+".extension"],
+		})
+	}
+
+	if len(assets) == 0 {
+		writeError(w, http.StatusBadRequest, "no assets given")
+		return
+	}
+
+	var components []Component
+	switch format {
+	case "maven2":
+		c := Component{
+			Repository: repo.Name,
+			Format:     format,
+			Group:      fields["maven2.groupId"],
+			Name:       fields["maven2.artifactId"],
+			Version:    fields["maven2.version"],
+		}
+		if c.Group == "" || c.Name == "" || c.Version == "" {
+			writeError(w, http.StatusBadRequest, "groupId, artifactId and version are required")
+			return
+		}
+
+		for _, a := range assets {
+			ext := a.extension
+			if ext == "" {
+				ext = "jar"
+			}
+			c.Assets = append(c.Assets, Asset{Path: defaultAssetPath(c, a.classifier, ext), Content: a.content})
+		}
+		if fields["maven2.generate-pom"] == "true" {
+			pom := fmt.Sprintf("<project><modelVersion>4.0.0</modelVersion><groupId>%s</groupId><artifactId>%s</artifactId><version>%s</version></project>", c.Group, c.Name, c.Version)
+			c.Assets = append(c.Assets, Asset{Path: defaultAssetPath(c, "", "pom"), Content: []byte(pom)})
+		}
+
+		components = append(components, c)
+	default:
+		// Without parsing the packages, each asset becomes a component named after its path
+		dir := strings.Trim(fields[format+".directory"], "/")
+		for _, a := range assets {
+			name := a.filename
+			if name == "" {
+				name = s.newID(format + "-asset")
+			}
+			p := path.Join(dir, path.Base(name))
+			components = append(components, Component{
+				Repository: repo.Name,
+				Format:     format,
+				Group:      "/" + dir,
+				Name:       p,
+				Assets:     []Asset{{Path: p, Content: a.content}},
+			})
+		}
+	}
+
+	for _, c := range components {
+		if tag != "" {
+			c.Tags = []string{tag}
+		}
+		s.addComponent(c)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isAssetField determines if the multipart field holds the content of an asset, e.g. maven2.asset1
+func isAssetField(format, field string) bool {
+	name := strings.TrimPrefix(field, format+".")
+	return name != field && strings.HasPrefix(name, "asset") && !strings.Contains(name, ".")
+}
+
+// serveContent serves the content of the assets as the repositories of RM do
+func (s *Server) serveContent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, repositoryPrefix), "/", 2)
+	if len(parts) != 2 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	for _, c := range s.components {
+		if c.Repository != parts[0] {
+			continue
+		}
+		for _, a := range c.Assets {
+			if a.Path == parts[1] {
+				http.ServeContent(w, r, path.Base(a.Path), time.Time{}, bytes.NewReader(a.Content))
+				return
+			}
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "not found")
+}
@@ -0,0 +1,116 @@
+package nexusrmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// AddContentSelector adds a content selector to the fake, or replaces the one with the same name. The csel type is assumed if none is given
+func (s *Server) AddContentSelector(selector ContentSelector) ContentSelector {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if selector.Type == "" {
+		selector.Type = "csel"
+	}
+
+	if i := s.contentSelectorIndex(selector.Name); i >= 0 {
+		s.selectors[i] = selector
+	} else {
+		s.selectors = append(s.selectors, selector)
+	}
+
+	return selector
+}
+
+// ContentSelectors returns the content selectors of the fake
+func (s *Server) ContentSelectors() []ContentSelector {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ContentSelector(nil), s.selectors...)
+}
+
+func (s *Server) contentSelectorIndex(name string) int {
+	for i, c := range s.selectors {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) serveContentSelectors(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, append([]ContentSelector{}, s.selectors...))
+	case len(path) == 0 && r.Method == http.MethodPost:
+		s.saveContentSelector(w, r, "")
+	case len(path) == 1 && r.Method == http.MethodGet:
+		i := s.contentSelectorIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "content selector not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.selectors[i])
+	case len(path) == 1 && r.Method == http.MethodPut:
+		s.saveContentSelector(w, r, path[0])
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		s.deleteContentSelector(w, path[0])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// saveContentSelector creates a content selector, or updates the named one
+func (s *Server) saveContentSelector(w http.ResponseWriter, r *http.Request, name string) {
+	var selector ContentSelector
+	if err := json.NewDecoder(r.Body).Decode(&selector); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid content selector")
+		return
+	}
+	if name != "" {
+		selector.Name = name
+	}
+
+	i := s.contentSelectorIndex(selector.Name)
+	switch {
+	case selector.Name == "" || selector.Expression == "":
+		writeError(w, http.StatusBadRequest, "invalid content selector")
+		return
+	case name == "" && i >= 0:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("content selector %s already exists", selector.Name))
+		return
+	case name != "" && i < 0:
+		writeError(w, http.StatusNotFound, "content selector not found")
+		return
+	}
+
+	selector.Type = "csel"
+	if i >= 0 {
+		s.selectors[i] = selector
+	} else {
+		s.selectors = append(s.selectors, selector)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteContentSelector(w http.ResponseWriter, name string) {
+	i := s.contentSelectorIndex(name)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "content selector not found")
+		return
+	}
+
+	for _, p := range s.privileges {
+		if p.ContentSelector == name {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("content selector %s is in use by privilege %s", name, p.Name))
+			return
+		}
+	}
+
+	s.selectors = append(s.selectors[:i], s.selectors[i+1:]...)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,193 @@
+package nexusrmtest
+
+// Repository describes a repository of the fake
+type Repository struct {
+	Name       string                 `json:"name"`
+	Format     string                 `json:"format"`
+	Type       string                 `json:"type"`
+	URL        string                 `json:"url"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// Config holds the settings given when the repository was created or updated through the REST API,
+	// such as online, storage or proxy
+	Config map[string]interface{} `json:"-"`
+}
+
+// SoftQuota is the soft quota of a blob store of the fake
+type SoftQuota struct {
+	Type  string `json:"type"`
+	Limit int64  `json:"limit"`
+}
+
+// BlobStore describes a blob store of the fake. Its sizes are not computed, but can be set to test quotas
+type BlobStore struct {
+	Name                  string     `json:"name"`
+	Type                  string     `json:"type"`
+	SoftQuota             *SoftQuota `json:"softQuota,omitempty"`
+	BlobCount             int64      `json:"blobCount"`
+	TotalSizeInBytes      int64      `json:"totalSizeInBytes"`
+	AvailableSpaceInBytes int64      `json:"availableSpaceInBytes"`
+
+	// Config holds the settings given when the blob store was created or updated through the REST API,
+	// such as path, bucketConfiguration or members
+	Config map[string]interface{} `json:"-"`
+}
+
+// User describes a user account of the fake
+type User struct {
+	ID            string   `json:"userId"`
+	FirstName     string   `json:"firstName"`
+	LastName      string   `json:"lastName"`
+	EmailAddress  string   `json:"emailAddress"`
+	Source        string   `json:"source"`
+	Status        string   `json:"status"`
+	ReadOnly      bool     `json:"readOnly"`
+	Roles         []string `json:"roles"`
+	ExternalRoles []string `json:"externalRoles"`
+
+	// Password is never returned by the REST API
+	Password string `json:"-"`
+}
+
+// Role describes a role of the fake
+type Role struct {
+	ID          string   `json:"id"`
+	Source      string   `json:"source"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Privileges  []string `json:"privileges"`
+	Roles       []string `json:"roles"`
+}
+
+// Privilege describes a privilege of the fake. Only the attributes of its type are set
+type Privilege struct {
+	Type            string   `json:"type"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	ReadOnly        bool     `json:"readOnly"`
+	Actions         []string `json:"actions,omitempty"`
+	Format          string   `json:"format,omitempty"`
+	Repository      string   `json:"repository,omitempty"`
+	ContentSelector string   `json:"contentSelector,omitempty"`
+	ScriptName      string   `json:"scriptName,omitempty"`
+	Pattern         string   `json:"pattern,omitempty"`
+	Domain          string   `json:"domain,omitempty"`
+}
+
+// ContentSelector describes a content selector of the fake. Its expression is not evaluated
+type ContentSelector struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Expression  string `json:"expression"`
+}
+
+// Realm describes a security realm which can be activated in the fake
+type Realm struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AnonymousAccess describes the anonymous access settings of the fake
+type AnonymousAccess struct {
+	Enabled   bool   `json:"enabled"`
+	UserID    string `json:"userId"`
+	RealmName string `json:"realmName"`
+}
+
+// Component describes a component stored in the fake
+type Component struct {
+	ID         string   `json:"id"`
+	Repository string   `json:"repository"`
+	Format     string   `json:"format"`
+	Group      string   `json:"group"`
+	Name       string   `json:"name"`
+	Version    string   `json:"version"`
+	Assets     []Asset  `json:"assets"`
+	Tags       []string `json:"tags"`
+}
+
+// Asset describes an asset stored in the fake
+type Asset struct {
+	DownloadURL string            `json:"downloadUrl"`
+	Path        string            `json:"path"`
+	ID          string            `json:"id"`
+	Repository  string            `json:"repository"`
+	Format      string            `json:"format"`
+	Checksum    map[string]string `json:"checksum"`
+
+	// Content is served at the DownloadURL of the asset
+	Content []byte `json:"-"`
+}
+
+// Tag describes a component tag
+type Tag struct {
+	Name         string                 `json:"name"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	FirstCreated string                 `json:"firstCreated,omitempty"`
+	LastUpdated  string                 `json:"lastUpdated,omitempty"`
+}
+
+// Script describes an uploaded script
+type Script struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	Type    string `json:"type"`
+}
+
+// ScriptRun records the execution of a script
+type ScriptRun struct {
+	Script    Script
+	Arguments string
+}
+
+// ReadOnlyState describes whether the instance is read-only
+type ReadOnlyState struct {
+	SystemInitiated bool   `json:"systemInitiated"`
+	SummaryReason   string `json:"summaryReason"`
+	Frozen          bool   `json:"frozen"`
+}
+
+// DatabaseState is the result of checking a database
+type DatabaseState struct {
+	PageCorruption bool `json:"pageCorruption"`
+	IndexErrors    int  `json:"indexErrors"`
+}
+
+type componentSummary struct {
+	Name    string `json:"name"`
+	Group   string `json:"group"`
+	Version string `json:"version"`
+}
+
+type componentsMoved struct {
+	Status  int64  `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		Destination     string             `json:"destination"`
+		ComponentsMoved []componentSummary `json:"components moved"`
+	} `json:"data"`
+}
+
+type componentsDeleted struct {
+	Status  int64  `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		ComponentsDeleted []componentDeleted `json:"components deleted"`
+	} `json:"data"`
+}
+
+type componentDeleted struct {
+	Repository string `json:"repository"`
+	Group      string `json:"group"`
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+}
+
+type componentsAssociated struct {
+	Status  int64  `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		ComponentsAssociated []componentSummary `json:"components associated"`
+	} `json:"data"`
+}
@@ -0,0 +1,132 @@
+package nexusrmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// formats maps the formats of the repository management endpoints to those of the repositories
+var formats = map[string]string{"maven": "maven2"}
+
+func repositoryFormat(endpoint string) string {
+	if format, ok := formats[endpoint]; ok {
+		return format
+	}
+	return endpoint
+}
+
+func (s *Server) repositoryURL(r *http.Request, repo Repository) string {
+	if repo.URL != "" {
+		return repo.URL
+	}
+	return fmt.Sprintf("http://%s%s%s", r.Host, repositoryPrefix, repo.Name)
+}
+
+func (s *Server) serveRepositories(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		repos := make([]Repository, len(s.repositories))
+		for i, repo := range s.repositories {
+			repo.URL = s.repositoryURL(r, repo)
+			repos[i] = repo
+		}
+		writeJSON(w, http.StatusOK, repos)
+	case len(path) == 1 && r.Method == http.MethodGet:
+		repo, ok := s.repository(path[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, "repository not found")
+			return
+		}
+		online, ok := repo.Config["online"]
+		if !ok {
+			online = true
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"name": repo.Name, "format": repo.Format, "type": repo.Type, "url": s.repositoryURL(r, repo), "online": online,
+		})
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		s.deleteRepository(w, path[0])
+	case len(path) == 2 && r.Method == http.MethodPost:
+		s.saveRepository(w, r, repositoryFormat(path[0]), path[1], "")
+	case len(path) == 3 && r.Method == http.MethodGet:
+		repo, ok := s.repository(path[2])
+		if !ok || repo.Format != repositoryFormat(path[0]) || repo.Type != path[1] {
+			writeError(w, http.StatusNotFound, "repository not found")
+			return
+		}
+
+		config := map[string]interface{}{"online": true}
+		for k, v := range repo.Config {
+			config[k] = v
+		}
+		config["name"], config["format"], config["type"], config["url"] = repo.Name, repo.Format, repo.Type, s.repositoryURL(r, repo)
+		writeJSON(w, http.StatusOK, config)
+	case len(path) == 3 && r.Method == http.MethodPut:
+		s.saveRepository(w, r, repositoryFormat(path[0]), path[1], path[2])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// saveRepository creates a repository, or updates the named one
+func (s *Server) saveRepository(w http.ResponseWriter, r *http.Request, format, repoType, name string) {
+	var config map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid repository")
+		return
+	}
+
+	configName, _ := config["name"].(string)
+	existing, exists := s.repository(configName)
+	switch {
+	case configName == "":
+		writeError(w, http.StatusBadRequest, "invalid repository")
+		return
+	case name == "" && exists:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("repository %s already exists", configName))
+		return
+	case name != "" && (name != configName || !exists || existing.Format != format || existing.Type != repoType):
+		writeError(w, http.StatusNotFound, "repository not found")
+		return
+	}
+
+	repo := Repository{Name: configName, Format: format, Type: repoType, Config: config}
+	if proxy, ok := config["proxy"].(map[string]interface{}); ok {
+		repo.Attributes = map[string]interface{}{"proxy": map[string]interface{}{"remoteUrl": proxy["remoteUrl"]}}
+	}
+	delete(config, "name")
+
+	s.addRepository(repo)
+	if name == "" {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// deleteRepository deletes the named repository along with its components
+func (s *Server) deleteRepository(w http.ResponseWriter, name string) {
+	if _, ok := s.repository(name); !ok {
+		writeError(w, http.StatusNotFound, "repository not found")
+		return
+	}
+
+	repos := s.repositories[:0]
+	for _, repo := range s.repositories {
+		if repo.Name != name {
+			repos = append(repos, repo)
+		}
+	}
+	s.repositories = repos
+
+	components := s.components[:0]
+	for _, c := range s.components {
+		if c.Repository != name {
+			components = append(components, c)
+		}
+	}
+	s.components = components
+
+	w.WriteHeader(http.StatusNoContent)
+}
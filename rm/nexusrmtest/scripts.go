@@ -0,0 +1,121 @@
+package nexusrmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+type runResponse struct {
+	Name   string `json:"name"`
+	Result string `json:"result"`
+}
+
+// AddScript uploads a script to the fake
+func (s *Server) AddScript(script Script) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.scriptIndex(script.Name); i >= 0 {
+		s.scripts[i] = script
+		return
+	}
+	s.scripts = append(s.scripts, script)
+}
+
+// Scripts returns the scripts currently uploaded to the fake
+func (s *Server) Scripts() []Script {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Script(nil), s.scripts...)
+}
+
+// ScriptRuns returns every script execution the fake received, in order
+func (s *Server) ScriptRuns() []ScriptRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ScriptRun(nil), s.scriptRuns...)
+}
+
+func (s *Server) scriptIndex(name string) int {
+	for i, script := range s.scripts {
+		if script.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) serveScripts(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, append([]Script{}, s.scripts...))
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var script Script
+		if err := json.NewDecoder(r.Body).Decode(&script); err != nil || script.Name == "" {
+			writeError(w, http.StatusBadRequest, "invalid script")
+			return
+		}
+		if s.scriptIndex(script.Name) >= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("script %s already exists", script.Name))
+			return
+		}
+		s.scripts = append(s.scripts, script)
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 1 && r.Method == http.MethodGet:
+		i := s.scriptIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "script not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.scripts[i])
+	case len(path) == 1 && r.Method == http.MethodPut:
+		i := s.scriptIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "script not found")
+			return
+		}
+		var script Script
+		if err := json.NewDecoder(r.Body).Decode(&script); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid script")
+			return
+		}
+		script.Name = path[0]
+		s.scripts[i] = script
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		i := s.scriptIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "script not found")
+			return
+		}
+		s.scripts = append(s.scripts[:i], s.scripts[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 2 && path[1] == "run" && r.Method == http.MethodPost:
+		i := s.scriptIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "script not found")
+			return
+		}
+
+		arguments, _ := ioutil.ReadAll(r.Body)
+		run := ScriptRun{Script: s.scripts[i], Arguments: string(arguments)}
+		s.scriptRuns = append(s.scriptRuns, run)
+
+		var result string
+		if s.ScriptHandler != nil {
+			var err error
+			if result, err = s.ScriptHandler(run.Script, run.Arguments); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		writeJSON(w, http.StatusOK, runResponse{Name: run.Script.Name, Result: result})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
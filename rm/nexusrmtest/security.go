@@ -0,0 +1,586 @@
+package nexusrmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const defaultSource = "default"
+
+// AvailableRealms are the realms which can be activated in the fake
+var AvailableRealms = []Realm{
+	{ID: "NexusAuthenticatingRealm", Name: "Local Authenticating Realm"},
+	{ID: "NexusAuthorizingRealm", Name: "Local Authorizing Realm"},
+	{ID: "ConanToken", Name: "Conan Bearer Token Realm"},
+	{ID: "DefaultRole", Name: "Default Role Realm"},
+	{ID: "DockerToken", Name: "Docker Bearer Token Realm"},
+	{ID: "LdapRealm", Name: "LDAP Realm"},
+	{ID: "NpmToken", Name: "npm Bearer Token Realm"},
+	{ID: "NuGetApiKey", Name: "NuGet API-Key Realm"},
+	{ID: "rutauth-realm", Name: "Rut Auth Realm"},
+}
+
+// defaultActiveRealms are the realms active until told otherwise
+var defaultActiveRealms = []string{"NexusAuthenticatingRealm", "NexusAuthorizingRealm"}
+
+// privilegeTypes are the types of privileges the fake accepts
+var privilegeTypes = map[string]bool{
+	"application": true, "wildcard": true, "repository-view": true,
+	"repository-admin": true, "repository-content-selector": true, "script": true,
+}
+
+// AddUser adds a user to the fake, or replaces the one with the same ID. The default source and the active status are assumed if none are given
+func (s *Server) AddUser(user User) User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user.Source == "" {
+		user.Source = defaultSource
+	}
+	if user.Status == "" {
+		user.Status = "active"
+	}
+
+	if i := s.userIndex(user.ID); i >= 0 {
+		s.users[i] = user
+	} else {
+		s.users = append(s.users, user)
+	}
+
+	return user
+}
+
+// Users returns the users of the fake
+func (s *Server) Users() []User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]User(nil), s.users...)
+}
+
+// AddRole adds a role to the fake, or replaces the one with the same ID. The default source is assumed if none is given
+func (s *Server) AddRole(role Role) Role {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if role.Source == "" {
+		role.Source = defaultSource
+	}
+
+	if i := s.roleIndex(role.ID); i >= 0 {
+		s.roles[i] = role
+	} else {
+		s.roles = append(s.roles, role)
+	}
+
+	return role
+}
+
+// Roles returns the roles of the fake
+func (s *Server) Roles() []Role {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Role(nil), s.roles...)
+}
+
+// AddPrivilege adds a privilege to the fake, or replaces the one with the same name, e.g. to add a read-only one
+func (s *Server) AddPrivilege(privilege Privilege) Privilege {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.privilegeIndex(privilege.Name); i >= 0 {
+		s.privileges[i] = privilege
+	} else {
+		s.privileges = append(s.privileges, privilege)
+	}
+
+	return privilege
+}
+
+// Privileges returns the privileges of the fake
+func (s *Server) Privileges() []Privilege {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Privilege(nil), s.privileges...)
+}
+
+// ActiveRealms returns the IDs of the active realms of the fake, in order
+func (s *Server) ActiveRealms() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.activeRealms()...)
+}
+
+// Anonymous returns the anonymous access settings of the fake
+func (s *Server) Anonymous() AnonymousAccess {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.anonymousAccess()
+}
+
+func (s *Server) activeRealms() []string {
+	if s.realms == nil {
+		return defaultActiveRealms
+	}
+	return s.realms
+}
+
+func (s *Server) anonymousAccess() AnonymousAccess {
+	if s.anonymous == nil {
+		return AnonymousAccess{UserID: "anonymous", RealmName: "NexusAuthorizingRealm"}
+	}
+	return *s.anonymous
+}
+
+func (s *Server) userIndex(id string) int {
+	for i, u := range s.users {
+		if u.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) roleIndex(id string) int {
+	for i, r := range s.roles {
+		if r.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) privilegeIndex(name string) int {
+	for i, p := range s.privileges {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) serveSecurity(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch path[0] {
+	case "users":
+		s.serveUsers(w, r, path[1:])
+	case "user-sources":
+		if len(path) != 1 || r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		writeJSON(w, http.StatusOK, []map[string]string{{"id": defaultSource, "name": "Local"}})
+	case "roles":
+		s.serveRoles(w, r, path[1:])
+	case "privileges":
+		s.servePrivileges(w, r, path[1:])
+	case "content-selectors":
+		s.serveContentSelectors(w, r, path[1:])
+	case "realms":
+		s.serveRealms(w, r, path[1:])
+	case "anonymous":
+		s.serveAnonymous(w, r, path[1:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) serveUsers(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		id, source := r.URL.Query().Get("userId"), r.URL.Query().Get("source")
+		users := make([]User, 0)
+		for _, u := range s.users {
+			if strings.HasPrefix(u.ID, id) && (source == "" || u.Source == source) {
+				users = append(users, u)
+			}
+		}
+		writeJSON(w, http.StatusOK, users)
+	case len(path) == 0 && r.Method == http.MethodPost:
+		s.saveUser(w, r, "")
+	case len(path) == 1 && r.Method == http.MethodPut:
+		s.saveUser(w, r, path[0])
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		i := s.userIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "user not found")
+			return
+		}
+		s.users = append(s.users[:i], s.users[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 2 && r.Method == http.MethodPut && path[1] == "change-password":
+		i := s.userIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "user not found")
+			return
+		}
+
+		password, err := ioutil.ReadAll(r.Body)
+		if err != nil || len(password) == 0 {
+			writeError(w, http.StatusBadRequest, "password is required")
+			return
+		}
+		s.users[i].Password = string(password)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// saveUser creates a user, or updates the one with the given ID
+func (s *Server) saveUser(w http.ResponseWriter, r *http.Request, id string) {
+	var req struct {
+		User
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		writeError(w, http.StatusBadRequest, "invalid user")
+		return
+	}
+
+	user := req.User
+	i := s.userIndex(user.ID)
+	switch {
+	case id == "" && i >= 0:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("user %s already exists", user.ID))
+		return
+	case id == "" && req.Password == "":
+		writeError(w, http.StatusBadRequest, "password is required")
+		return
+	case id != "" && id != user.ID:
+		writeError(w, http.StatusBadRequest, "the user ID does not match the path")
+		return
+	case id != "" && i < 0:
+		writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	switch user.Status {
+	case "active", "locked", "disabled", "changepassword":
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown status %s", user.Status))
+		return
+	}
+
+	for _, role := range user.Roles {
+		if s.roleIndex(role) < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("role %s does not exist", role))
+			return
+		}
+	}
+
+	if user.Roles == nil {
+		user.Roles = []string{}
+	}
+	if user.ExternalRoles == nil {
+		user.ExternalRoles = []string{}
+	}
+
+	if i >= 0 {
+		user.Source, user.ReadOnly, user.Password = s.users[i].Source, s.users[i].ReadOnly, s.users[i].Password
+		s.users[i] = user
+	} else {
+		user.Source, user.ReadOnly, user.Password = defaultSource, false, req.Password
+		s.users = append(s.users, user)
+	}
+
+	if id == "" {
+		writeJSON(w, http.StatusOK, user)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serveRoles(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		source := r.URL.Query().Get("source")
+		roles := make([]Role, 0)
+		for _, role := range s.roles {
+			if source == "" || role.Source == source {
+				roles = append(roles, role)
+			}
+		}
+		writeJSON(w, http.StatusOK, roles)
+	case len(path) == 0 && r.Method == http.MethodPost:
+		s.saveRole(w, r, "")
+	case len(path) == 1 && r.Method == http.MethodGet:
+		i := s.roleIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "role not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.roles[i])
+	case len(path) == 1 && r.Method == http.MethodPut:
+		s.saveRole(w, r, path[0])
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		s.deleteRole(w, path[0])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// saveRole creates a role, or updates the one with the given ID
+func (s *Server) saveRole(w http.ResponseWriter, r *http.Request, id string) {
+	var role Role
+	if err := json.NewDecoder(r.Body).Decode(&role); err != nil || role.ID == "" || role.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid role")
+		return
+	}
+
+	i := s.roleIndex(role.ID)
+	switch {
+	case id == "" && i >= 0:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("role %s already exists", role.ID))
+		return
+	case id != "" && id != role.ID:
+		writeError(w, http.StatusBadRequest, "the role ID does not match the path")
+		return
+	case id != "" && i < 0:
+		writeError(w, http.StatusNotFound, "role not found")
+		return
+	}
+
+	for _, p := range role.Privileges {
+		if s.privilegeIndex(p) < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("privilege %s does not exist", p))
+			return
+		}
+	}
+	for _, nested := range role.Roles {
+		if nested == role.ID || s.roleIndex(nested) < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("role %s cannot be contained", nested))
+			return
+		}
+	}
+
+	if role.Privileges == nil {
+		role.Privileges = []string{}
+	}
+	if role.Roles == nil {
+		role.Roles = []string{}
+	}
+
+	role.Source = defaultSource
+	if i >= 0 {
+		s.roles[i] = role
+	} else {
+		s.roles = append(s.roles, role)
+	}
+
+	if id == "" {
+		writeJSON(w, http.StatusOK, role)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteRole(w http.ResponseWriter, id string) {
+	i := s.roleIndex(id)
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "role not found")
+		return
+	}
+
+	s.roles = append(s.roles[:i], s.roles[i+1:]...)
+	for j := range s.users {
+		s.users[j].Roles = without(s.users[j].Roles, id)
+	}
+	for j := range s.roles {
+		s.roles[j].Roles = without(s.roles[j].Roles, id)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) servePrivileges(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, append([]Privilege{}, s.privileges...))
+	case len(path) == 1 && r.Method == http.MethodGet:
+		i := s.privilegeIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "privilege not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.privileges[i])
+	case len(path) == 1 && r.Method == http.MethodPost:
+		s.savePrivilege(w, r, path[0], "")
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		i := s.privilegeIndex(path[0])
+		switch {
+		case i < 0:
+			writeError(w, http.StatusNotFound, "privilege not found")
+			return
+		case s.privileges[i].ReadOnly:
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("privilege %s is read-only", path[0]))
+			return
+		}
+
+		s.privileges = append(s.privileges[:i], s.privileges[i+1:]...)
+		for j := range s.roles {
+			s.roles[j].Privileges = without(s.roles[j].Privileges, path[0])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 2 && r.Method == http.MethodPut:
+		s.savePrivilege(w, r, path[0], path[1])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// savePrivilege creates a privilege of the given type, or updates the named one
+func (s *Server) savePrivilege(w http.ResponseWriter, r *http.Request, privilegeType, name string) {
+	if !privilegeTypes[privilegeType] {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var privilege Privilege
+	if err := json.NewDecoder(r.Body).Decode(&privilege); err != nil || privilege.Name == "" {
+		writeError(w, http.StatusBadRequest, "invalid privilege")
+		return
+	}
+	privilege.Type, privilege.ReadOnly = privilegeType, false
+
+	i := s.privilegeIndex(privilege.Name)
+	switch {
+	case name == "" && i >= 0:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("privilege %s already exists", privilege.Name))
+		return
+	case name != "" && name != privilege.Name:
+		writeError(w, http.StatusBadRequest, "the privilege name does not match the path")
+		return
+	case name != "" && (i < 0 || s.privileges[i].Type != privilegeType):
+		writeError(w, http.StatusNotFound, "privilege not found")
+		return
+	case name != "" && s.privileges[i].ReadOnly:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("privilege %s is read-only", name))
+		return
+	}
+
+	if missing := missingPrivilegeAttribute(privilege); missing != "" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("%s is required by %s privileges", missing, privilegeType))
+		return
+	}
+
+	if i >= 0 {
+		s.privileges[i] = privilege
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.privileges = append(s.privileges, privilege)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// missingPrivilegeAttribute returns the attribute required by the type of the privilege which it lacks, if any
+func missingPrivilegeAttribute(privilege Privilege) string {
+	if privilege.Type != "wildcard" && len(privilege.Actions) == 0 {
+		return "actions"
+	}
+
+	switch privilege.Type {
+	case "application":
+		if privilege.Domain == "" {
+			return "domain"
+		}
+	case "wildcard":
+		if privilege.Pattern == "" {
+			return "pattern"
+		}
+	case "script":
+		if privilege.ScriptName == "" {
+			return "scriptName"
+		}
+	default:
+		switch {
+		case privilege.Format == "":
+			return "format"
+		case privilege.Repository == "":
+			return "repository"
+		case privilege.Type == "repository-content-selector" && privilege.ContentSelector == "":
+			return "contentSelector"
+		}
+	}
+
+	return ""
+}
+
+func (s *Server) serveRealms(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 1 && path[0] == "available" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, AvailableRealms)
+	case len(path) == 1 && path[0] == "active" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.activeRealms())
+	case len(path) == 1 && path[0] == "active" && r.Method == http.MethodPut:
+		var ids []string
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid realms")
+			return
+		}
+
+		seen := make(map[string]bool)
+		for _, id := range ids {
+			if seen[id] || !availableRealm(id) {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("realm %s cannot be activated", id))
+				return
+			}
+			seen[id] = true
+		}
+
+		s.realms = append([]string{}, ids...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func availableRealm(id string) bool {
+	for _, realm := range AvailableRealms {
+		if realm.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) serveAnonymous(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.anonymousAccess())
+	case len(path) == 0 && r.Method == http.MethodPut:
+		var access AnonymousAccess
+		if err := json.NewDecoder(r.Body).Decode(&access); err != nil || access.UserID == "" || !availableRealm(access.RealmName) {
+			writeError(w, http.StatusBadRequest, "invalid anonymous access")
+			return
+		}
+
+		s.anonymous = &access
+		writeJSON(w, http.StatusOK, access)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// without returns the values but the given one
+func without(values []string, value string) []string {
+	kept := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != value {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
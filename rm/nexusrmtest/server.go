@@ -0,0 +1,214 @@
+// Package nexusrmtest provides an in-memory fake of the Nexus Repository Manager REST API for tests.
+//
+// The fake is stateful: repositories, blob stores, users, roles, privileges and content selectors can be
+// created, updated and deleted, components uploaded to it can be listed, searched, tagged, staged,
+// downloaded and deleted again, and it works offline.
+//
+//	server := nexusrmtest.NewServer()
+//	defer server.Close()
+//
+//	server.AddRepository(nexusrmtest.Repository{Name: "maven-releases", Format: "maven2", Type: "hosted"})
+//	rm, _ := nexusrm.New(server.URL, "admin", "admin123")
+package nexusrmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	restPrefix       = "/service/rest/v1/"
+	repositoryPrefix = "/repository/"
+
+	// DefaultPageSize is the number of items returned per page by the paged endpoints
+	DefaultPageSize = 10
+
+	// DefaultVersion is the version of Repository Manager the fake reports unless told otherwise
+	DefaultVersion = "3.29.2-02"
+)
+
+// Server is an in-memory fake of a Repository Manager instance
+type Server struct {
+	*httptest.Server
+
+	// Username and Password, if set, are required as basic auth credentials of every request
+	Username, Password string
+
+	// Version is the version of Repository Manager reported by the fake in the Server header of its responses
+	Version string
+
+	// PageSize is the number of items returned per page by the paged endpoints
+	PageSize int
+
+	// ScriptHandler, if set, is called to produce the result of running a script.
+	// The fake cannot execute Groovy, so by default running a script returns an empty result
+	ScriptHandler func(script Script, arguments string) (string, error)
+
+	mu           sync.Mutex
+	repositories []Repository
+	blobStores   []BlobStore
+	components   []Component
+	tags         []Tag
+	scripts      []Script
+	scriptRuns   []ScriptRun
+	users        []User
+	roles        []Role
+	privileges   []Privilege
+	selectors    []ContentSelector
+	realms       []string
+	anonymous    *AnonymousAccess
+	readOnly     ReadOnlyState
+	unavailable  bool
+	nextID       int
+}
+
+// NewServer starts a new fake Repository Manager. The caller should call Close when finished
+func NewServer() *Server {
+	s := &Server{PageSize: DefaultPageSize, Version: DefaultVersion}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewUnstartedServer creates a new fake Repository Manager without starting it, e.g. to serve TLS
+func NewUnstartedServer() *Server {
+	s := &Server{PageSize: DefaultPageSize, Version: DefaultVersion}
+	s.Server = httptest.NewUnstartedServer(s)
+	return s
+}
+
+// SetAvailable toggles whether the status endpoints report the instance as available
+func (s *Server) SetAvailable(available bool) {
+	s.mu.Lock()
+	s.unavailable = !available
+	s.mu.Unlock()
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%d", prefix, s.nextID)
+}
+
+// ServeHTTP implements the REST API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Server", fmt.Sprintf("Nexus/%s (PRO)", s.Version))
+
+	if s.Username != "" || s.Password != "" {
+		if user, pass, ok := r.BasicAuth(); !ok || user != s.Username || pass != s.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, repositoryPrefix):
+		s.serveContent(w, r)
+		return
+	case !strings.HasPrefix(r.URL.Path, restPrefix):
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	path := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, restPrefix), "/"), "/")
+	switch path[0] {
+	case "repositories":
+		s.serveRepositories(w, r, path[1:])
+	case "blobstores":
+		s.serveBlobStores(w, r, path[1:])
+	case "components":
+		s.serveComponents(w, r, path[1:])
+	case "assets":
+		s.serveAssets(w, r, path[1:])
+	case "search":
+		s.serveSearch(w, r, path[1:])
+	case "tags":
+		s.serveTags(w, r, path[1:])
+	case "staging":
+		s.serveStaging(w, r, path[1:])
+	case "security":
+		s.serveSecurity(w, r, path[1:])
+	case "script":
+		s.serveScripts(w, r, path[1:])
+	case "read-only":
+		s.serveReadOnly(w, r, path[1:])
+	case "status":
+		s.serveStatus(w, r, path[1:])
+	case "maintenance":
+		s.serveMaintenance(w, r, path[1:])
+	case "support":
+		s.serveSupport(w, r, path[1:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+type pagedResponse struct {
+	Items             interface{} `json:"items"`
+	ContinuationToken *string     `json:"continuationToken"`
+}
+
+// page returns the bounds of the page indicated by the continuation token of the request
+func (s *Server) page(r *http.Request, total int) (start, end int, next *string, ok bool) {
+	if token := r.URL.Query().Get("continuationToken"); token != "" {
+		var err error
+		if start, err = strconv.Atoi(token); err != nil || start < 0 || start > total {
+			return 0, 0, nil, false
+		}
+	}
+
+	size := s.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+
+	end = start + size
+	if end < total {
+		token := strconv.Itoa(end)
+		next = &token
+	} else {
+		end = total
+	}
+
+	return start, end, next, true
+}
+
+func sortComponents(components []Component, by, direction string) {
+	var key func(c Component) string
+	switch by {
+	case "group":
+		key = func(c Component) string { return c.Group }
+	case "name":
+		key = func(c Component) string { return c.Name }
+	case "version":
+		key = func(c Component) string { return c.Version }
+	case "repository":
+		key = func(c Component) string { return c.Repository }
+	default:
+		return
+	}
+
+	sort.SliceStable(components, func(i, j int) bool {
+		if direction == "desc" {
+			return key(components[i]) > key(components[j])
+		}
+		return key(components[i]) < key(components[j])
+	})
+}
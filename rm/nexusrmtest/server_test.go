@@ -0,0 +1,541 @@
+package nexusrmtest_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	nexusrm "github.com/sonatype-nexus-community/gonexus/rm"
+	"github.com/sonatype-nexus-community/gonexus/rm/nexusrmtest"
+)
+
+func newFake(t *testing.T) (nexusrm.RM, *nexusrmtest.Server) {
+	server := nexusrmtest.NewServer()
+	server.Username, server.Password = "admin", "admin123"
+
+	server.AddRepository(nexusrmtest.Repository{Name: "maven-releases", Format: "maven2"})
+	server.AddRepository(nexusrmtest.Repository{Name: "maven-staging", Format: "maven2"})
+	server.AddRepository(nexusrmtest.Repository{Name: "raw-hosted", Format: "raw"})
+
+	rm, err := nexusrm.New(server.URL, server.Username, server.Password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rm, server
+}
+
+func uploadMaven(t *testing.T, rm nexusrm.RM, repo, coordinate, content string) {
+	component, err := nexusrm.NewUploadComponentMaven(coordinate, strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := nexusrm.UploadComponent(rm, repo, component); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	_, server := newFake(t)
+	defer server.Close()
+
+	rm, err := nexusrm.New(server.URL, "admin", "wrong")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := nexusrm.GetRepositories(rm); !errors.Is(err, nexus.ErrUnauthorized) {
+		t.Errorf("Expected unauthorized error but got %v", err)
+	}
+}
+
+func TestRepositories(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	repos, err := nexusrm.GetRepositories(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repos) != 3 {
+		t.Fatalf("Expected 3 repositories but got %d", len(repos))
+	}
+
+	if repos[0].Type != "hosted" || repos[0].URL != server.URL+"/repository/maven-releases" {
+		t.Errorf("Unexpected repository %v", repos[0])
+	}
+}
+
+func TestRepositoryManagement(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	config := nexusrm.RepositoryConfig{Name: "maven-central", Proxy: &nexusrm.ProxyAttributes{RemoteURL: "https://repo1.maven.org/maven2/"}}
+	if err := nexusrm.CreateProxyRepository(rm, nexusrm.Maven, config); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := nexusrm.GetRepositoryByName(rm, "maven-central")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Format != nexusrm.Maven || repo.Type != nexusrm.RepositoryProxy || repo.Proxy.RemoteURL != config.Proxy.RemoteURL || !repo.Online {
+		t.Errorf("Unexpected repository %+v", repo)
+	}
+
+	// Deleting a repository deletes its components
+	uploadMaven(t, rm, "maven-staging", "org.example:app:1.0.0", "app jar")
+	if err := nexusrm.DeleteRepository(rm, "maven-staging"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(server.Components()); n != 0 {
+		t.Errorf("Expected the components of the repository to be deleted but %d remain", n)
+	}
+	if n := len(server.Repositories()); n != 3 {
+		t.Errorf("Expected 3 repositories but got %d", n)
+	}
+}
+
+func TestSecurity(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	server.AddPrivilege(nexusrmtest.Privilege{Type: "wildcard", Name: "nx-all", Pattern: "nexus:*", ReadOnly: true})
+	if err := nexusrm.CreateRole(rm, nexusrm.Role{ID: "admins", Name: "admins", Privileges: []string{"nx-all"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nexusrm.CreateUser(rm, nexusrm.User{ID: "jdoe", Password: "secret", Roles: []string{"admins"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nexusrm.ChangeUserPassword(rm, "jdoe", "changed"); err != nil {
+		t.Fatal(err)
+	}
+	if users := server.Users(); len(users) != 1 || users[0].Password != "changed" || users[0].Source != "default" {
+		t.Errorf("Unexpected users %+v", users)
+	}
+
+	if err := nexusrm.DeletePrivilege(rm, "nx-all"); err == nil {
+		t.Error("Expected an error deleting a read-only privilege")
+	}
+
+	if err := nexusrm.DeleteRole(rm, "admins"); err != nil {
+		t.Fatal(err)
+	}
+	if users := server.Users(); len(users[0].Roles) != 0 {
+		t.Errorf("Expected the deleted role to be removed from the user but got %v", users[0].Roles)
+	}
+
+	if err := nexusrm.SetActiveRealms(rm, []string{"NexusAuthenticatingRealm", "nope"}); err == nil {
+		t.Error("Expected an error activating an unknown realm")
+	}
+	if realms := server.ActiveRealms(); len(realms) != 2 {
+		t.Errorf("Expected the default realms to be active but got %v", realms)
+	}
+}
+
+func TestUploadAndList(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	uploadMaven(t, rm, "maven-releases", "org.example:app:1.0.0", "app jar")
+
+	components, err := nexusrm.GetComponents(rm, "maven-releases")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(components) != 1 {
+		t.Fatalf("Expected 1 component but got %d", len(components))
+	}
+
+	c := components[0]
+	if c.Group != "org.example" || c.Name != "app" || c.Version != "1.0.0" {
+		t.Errorf("Unexpected component %v", c)
+	}
+
+	// The jar and the generated pom
+	if len(c.Assets) != 2 {
+		t.Fatalf("Expected 2 assets but got %d", len(c.Assets))
+	}
+
+	if c.Assets[0].Path != "org/example/app/1.0.0/app-1.0.0.jar" {
+		t.Errorf("Unexpected asset path %s", c.Assets[0].Path)
+	}
+
+	got, err := nexusrm.GetComponentByID(rm, c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != c.ID {
+		t.Errorf("Retrieved component %s instead of %s", got.ID, c.ID)
+	}
+}
+
+func TestUploadErrors(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	component, _ := nexusrm.NewUploadComponentMaven("org.example:app:1.0.0", strings.NewReader("jar"))
+
+	if err := nexusrm.UploadComponent(rm, "nope", component); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error for unknown repository but got %v", err)
+	}
+
+	if err := nexusrm.UploadComponent(rm, "raw-hosted", component); !errors.Is(err, nexus.ErrBadRequest) {
+		t.Errorf("Expected bad request for mismatched format but got %v", err)
+	}
+
+	component.Tag = "unknown"
+	if err := nexusrm.UploadComponent(rm, "maven-releases", component); !errors.Is(err, nexus.ErrBadRequest) {
+		t.Errorf("Expected bad request for unknown tag but got %v", err)
+	}
+}
+
+func TestUploadRaw(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	component := nexusrm.UploadComponentRaw{
+		Directory: "docs",
+		Assets: []nexusrm.UploadAssetRaw{
+			{File: strings.NewReader("one"), Filename: "one.txt"},
+			{File: strings.NewReader("two"), Filename: "two.txt"},
+		},
+	}
+	if err := nexusrm.UploadComponent(rm, "raw-hosted", component); err != nil {
+		t.Fatal(err)
+	}
+
+	assets, err := nexusrm.GetAssets(rm, "raw-hosted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(assets) != 2 || assets[0].Path != "docs/one.txt" || assets[1].Path != "docs/two.txt" {
+		t.Errorf("Unexpected assets %v", assets)
+	}
+}
+
+func TestPaging(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+	server.PageSize = 2
+
+	for i := 0; i < 5; i++ {
+		server.AddComponent(nexusrmtest.Component{
+			Repository: "maven-releases",
+			Format:     "maven2",
+			Group:      "org.example",
+			Name:       "lib",
+			Version:    fmt.Sprintf("1.%d", i),
+			Assets:     []nexusrmtest.Asset{{Content: []byte("jar")}},
+		})
+	}
+
+	p := nexusrm.ComponentsPaginator(rm, "maven-releases")
+
+	var pages, total int
+	for p.Next(context.Background()) {
+		var items []nexusrm.RepositoryItem
+		if err := p.Decode(&items); err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		total += len(items)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if pages != 3 || total != 5 {
+		t.Errorf("Expected 5 components over 3 pages but got %d over %d", total, pages)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	uploadMaven(t, rm, "maven-releases", "org.example:app:1.0.0", "app")
+	uploadMaven(t, rm, "maven-releases", "org.example:lib:2.0.0", "lib")
+	uploadMaven(t, rm, "maven-staging", "org.example:app:1.1.0", "app")
+
+	tests := []struct {
+		query nexus.SearchQueryBuilder
+		want  int
+	}{
+		{nexusrm.NewSearchQueryBuilder(), 3},
+		{nexusrm.NewSearchQueryBuilder().Repository("maven-releases"), 2},
+		{nexusrm.NewSearchQueryBuilder().Name("app"), 2},
+		{nexusrm.NewSearchQueryBuilder().Version("1.*"), 2},
+		{nexusrm.NewSearchQueryBuilder().Q("lib"), 1},
+		{nexusrm.NewSearchQueryBuilder().Name("nope"), 0},
+	}
+
+	for _, test := range tests {
+		components, err := nexusrm.SearchComponents(rm, test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(components) != test.want {
+			t.Errorf("Search %q found %d components instead of %d", test.query.Build(), len(components), test.want)
+		}
+	}
+
+	assets, err := nexusrm.SearchAssets(rm, nexusrm.NewSearchQueryBuilder().Repository("maven-staging"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assets) != 2 {
+		t.Errorf("Expected 2 assets but found %d", len(assets))
+	}
+}
+
+func TestDownload(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	uploadMaven(t, rm, "maven-releases", "org.example:app:1.0.0", "0123456789")
+
+	assets, err := nexusrm.GetAssets(rm, "maven-releases")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := nexusrm.DownloadAsset(rm, assets[0], &buf, nexusrm.DownloadOptions{Offset: 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "456789" {
+		t.Errorf("Downloaded %q", buf.String())
+	}
+}
+
+func TestDelete(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	uploadMaven(t, rm, "maven-releases", "org.example:app:1.0.0", "app")
+	c := server.Components()[0]
+
+	if err := nexusrm.DeleteAssetByID(rm, c.Assets[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ = server.Component(c.ID); len(c.Assets) != 1 {
+		t.Errorf("Expected 1 remaining asset but found %d", len(c.Assets))
+	}
+
+	if err := nexusrm.DeleteComponentByID(rm, c.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nexusrm.GetComponentByID(rm, c.ID); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestTags(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	if _, err := nexusrm.AddTag(rm, "release", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nexusrm.AddTag(rm, "release", nil); !errors.Is(err, nexus.ErrBadRequest) {
+		t.Errorf("Expected bad request for duplicate tag but got %v", err)
+	}
+
+	tag, err := nexusrm.GetTag(rm, "release")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != "release" || tag.FirstCreated == "" {
+		t.Errorf("Unexpected tag %v", tag)
+	}
+
+	uploadMaven(t, rm, "maven-releases", "org.example:app:1.0.0", "app")
+	uploadMaven(t, rm, "maven-releases", "org.example:lib:1.0.0", "lib")
+
+	if _, _, err := rm.Post("service/rest/v1/tags/associate/release?name=app", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tagged, err := nexusrm.SearchComponents(rm, nexusrm.NewSearchQueryBuilder().Tag("release"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tagged) != 1 || tagged[0].Name != "app" {
+		t.Errorf("Unexpected tagged components %v", tagged)
+	}
+}
+
+func TestStaging(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	uploadMaven(t, rm, "maven-staging", "org.example:app:1.0.0", "app")
+	uploadMaven(t, rm, "maven-staging", "org.example:lib:1.0.0", "lib")
+
+	if _, _, err := rm.Post("service/rest/v1/staging/move/maven-releases?repository=maven-staging&name=app", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	released, _ := nexusrm.GetComponents(rm, "maven-releases")
+	if len(released) != 1 || released[0].Name != "app" {
+		t.Errorf("Unexpected moved components %v", released)
+	}
+
+	if err := nexusrm.StagingDelete(rm, *nexusrm.NewSearchQueryBuilder().Repository("maven-staging")); err != nil {
+		t.Fatal(err)
+	}
+
+	if staged, _ := nexusrm.GetComponents(rm, "maven-staging"); len(staged) != 0 {
+		t.Errorf("Expected staging repository to be empty but found %v", staged)
+	}
+}
+
+func TestScripts(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	server.ScriptHandler = func(script nexusrmtest.Script, arguments string) (string, error) {
+		return script.Name + ":" + arguments, nil
+	}
+
+	script := nexusrm.Script{Name: "hello", Content: "return 'hi'", Type: "groovy"}
+	if err := nexusrm.ScriptUpload(rm, script); err != nil {
+		t.Fatal(err)
+	}
+
+	scripts, err := nexusrm.ScriptList(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scripts) != 1 || scripts[0] != script {
+		t.Errorf("Unexpected scripts %v", scripts)
+	}
+
+	result, err := nexusrm.ScriptRun(rm, "hello", []byte("world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "hello:world" {
+		t.Errorf("Unexpected result %q", result)
+	}
+
+	if err := nexusrm.ScriptDelete(rm, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nexusrm.ScriptGet(rm, "hello"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+
+	if runs := server.ScriptRuns(); len(runs) != 1 || runs[0].Arguments != "world" {
+		t.Errorf("Unexpected script runs %v", runs)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	state, err := nexusrm.ReadOnlyEnable(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Frozen {
+		t.Error("Expected instance to be frozen")
+	}
+
+	if nexusrm.StatusWritable(rm) {
+		t.Error("Expected frozen instance to not be writable")
+	}
+
+	component, _ := nexusrm.NewUploadComponentMaven("org.example:app:1.0.0", strings.NewReader("jar"))
+	if err := nexusrm.UploadComponent(rm, "maven-releases", component); !errors.Is(err, nexus.ErrServer) {
+		t.Errorf("Expected upload to a frozen instance to fail but got %v", err)
+	}
+
+	if state, err = nexusrm.ReadOnlyRelease(rm, false); err != nil || state.Frozen {
+		t.Errorf("Expected instance to be released: %v %v", state, err)
+	}
+
+	server.SetReadOnly(nexusrmtest.ReadOnlyState{Frozen: true, SystemInitiated: true})
+	if _, err = nexusrm.ReadOnlyRelease(rm, false); !errors.Is(err, nexus.ErrForbidden) {
+		t.Errorf("Expected forbidden error but got %v", err)
+	}
+	if state, err = nexusrm.ReadOnlyRelease(rm, true); err != nil || state.Frozen {
+		t.Errorf("Expected instance to be force released: %v %v", state, err)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	if !nexusrm.StatusReadable(rm) || !nexusrm.StatusWritable(rm) {
+		t.Error("Expected instance to be available")
+	}
+
+	server.SetAvailable(false)
+	if nexusrm.StatusReadable(rm) {
+		t.Error("Expected instance to be unavailable")
+	}
+}
+
+func TestCheckDatabase(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	if _, err := nexusrm.CheckDatabase(rm, nexusrm.ComponentDB); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := nexusrm.CheckDatabase(rm, "nope"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found error but got %v", err)
+	}
+}
+
+func TestSupportZip(t *testing.T) {
+	rm, server := newFake(t)
+	defer server.Close()
+
+	zipped, name, err := nexusrm.GetSupportZip(rm, nexusrm.NewSupportZipOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(name, "support-") {
+		t.Errorf("Unexpected support zip name %s", name)
+	}
+
+	if _, err := zip.NewReader(bytes.NewReader(zipped), int64(len(zipped))); err != nil {
+		t.Errorf("Did not receive a valid zip: %v", err)
+	}
+}
+
+func TestUnknownEndpoint(t *testing.T) {
+	_, server := newFake(t)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/service/rest/v1/nope", nil)
+	req.SetBasicAuth(server.Username, server.Password)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 but got %d", resp.StatusCode)
+	}
+}
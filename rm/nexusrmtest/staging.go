@@ -0,0 +1,67 @@
+package nexusrmtest
+
+import (
+	"net/http"
+)
+
+func (s *Server) serveStaging(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 2 && path[0] == "move" && r.Method == http.MethodPost:
+		dest, ok := s.repository(path[1])
+		if !ok {
+			writeError(w, http.StatusNotFound, "destination repository not found")
+			return
+		}
+		if !s.writable(w) {
+			return
+		}
+
+		var resp componentsMoved
+		resp.Data.Destination = dest.Name
+		resp.Data.ComponentsMoved = []componentSummary{}
+		for ci, c := range s.components {
+			if c.Repository == dest.Name || !matches(c, r.URL.Query()) {
+				continue
+			}
+
+			c.Repository = dest.Name
+			for ai := range c.Assets {
+				c.Assets[ai].Repository = dest.Name
+			}
+			s.components[ci] = c
+
+			resp.Data.ComponentsMoved = append(resp.Data.ComponentsMoved, summarize(c))
+		}
+
+		resp.Status = http.StatusOK
+		resp.Message = "Move Successful"
+		writeJSON(w, http.StatusOK, resp)
+	case len(path) == 1 && path[0] == "delete" && (r.Method == http.MethodPost || r.Method == http.MethodDelete):
+		if !s.writable(w) {
+			return
+		}
+
+		var resp componentsDeleted
+		resp.Data.ComponentsDeleted = []componentDeleted{}
+		kept := s.components[:0]
+		for _, c := range s.components {
+			if !matches(c, r.URL.Query()) {
+				kept = append(kept, c)
+				continue
+			}
+			resp.Data.ComponentsDeleted = append(resp.Data.ComponentsDeleted, componentDeleted{
+				Repository: c.Repository,
+				Group:      c.Group,
+				Name:       c.Name,
+				Version:    c.Version,
+			})
+		}
+		s.components = kept
+
+		resp.Status = http.StatusOK
+		resp.Message = "Delete Successful"
+		writeJSON(w, http.StatusOK, resp)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
@@ -0,0 +1,109 @@
+package nexusrmtest
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SetReadOnly sets the read-only state of the fake. Frozen instances refuse writes with a 503
+func (s *Server) SetReadOnly(state ReadOnlyState) {
+	s.mu.Lock()
+	s.readOnly = state
+	s.mu.Unlock()
+}
+
+// ReadOnly returns the read-only state of the fake
+func (s *Server) ReadOnly() ReadOnlyState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.readOnly
+}
+
+func (s *Server) serveReadOnly(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.readOnly)
+	case len(path) == 1 && path[0] == "freeze" && r.Method == http.MethodPost:
+		if s.readOnly.Frozen {
+			writeError(w, http.StatusNotFound, "already frozen")
+			return
+		}
+		s.readOnly = ReadOnlyState{Frozen: true, SummaryReason: "Activated by an administrator"}
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 1 && path[0] == "release" && r.Method == http.MethodPost:
+		switch {
+		case !s.readOnly.Frozen:
+			writeError(w, http.StatusNotFound, "not frozen")
+		case s.readOnly.SystemInitiated:
+			writeError(w, http.StatusForbidden, "frozen by the system, use force-release")
+		default:
+			s.readOnly = ReadOnlyState{}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case len(path) == 1 && path[0] == "force-release" && r.Method == http.MethodPost:
+		if !s.readOnly.Frozen {
+			writeError(w, http.StatusNotFound, "not frozen")
+			return
+		}
+		s.readOnly = ReadOnlyState{}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) serveStatus(w http.ResponseWriter, r *http.Request, path []string) {
+	if r.Method != http.MethodGet || len(path) > 1 || (len(path) == 1 && path[0] != "writable") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if s.unavailable || (len(path) == 1 && s.readOnly.Frozen) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) serveMaintenance(w http.ResponseWriter, r *http.Request, path []string) {
+	if r.Method != http.MethodPut || len(path) != 2 || path[1] != "check" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	switch path[0] {
+	case "accesslog", "component", "config", "security":
+		writeJSON(w, http.StatusOK, DatabaseState{})
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("database %s not found", path[0]))
+	}
+}
+
+func (s *Server) serveSupport(w http.ResponseWriter, r *http.Request, path []string) {
+	if r.Method != http.MethodPost || len(path) != 1 || path[0] != "supportzip" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	var options map[string]bool
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid support zip options")
+		return
+	}
+
+	name := fmt.Sprintf("support-%s.zip", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+
+	archive := zip.NewWriter(w)
+	if f, err := archive.Create("info/options.json"); err == nil {
+		json.NewEncoder(f).Encode(options)
+	}
+	archive.Close()
+}
@@ -0,0 +1,148 @@
+package nexusrmtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AddTag adds a tag to the fake
+func (s *Server) AddTag(tag Tag) Tag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addTag(tag)
+}
+
+func (s *Server) addTag(tag Tag) Tag {
+	now := time.Now().UTC().Format(time.RFC3339)
+	if tag.FirstCreated == "" {
+		tag.FirstCreated = now
+	}
+	tag.LastUpdated = now
+
+	if i := s.tagIndex(tag.Name); i >= 0 {
+		s.tags[i] = tag
+	} else {
+		s.tags = append(s.tags, tag)
+	}
+
+	return tag
+}
+
+// Tags returns the tags of the fake
+func (s *Server) Tags() []Tag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Tag(nil), s.tags...)
+}
+
+func (s *Server) tagIndex(name string) int {
+	for i, t := range s.tags {
+		if t.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func summarize(c Component) componentSummary {
+	return componentSummary{Name: c.Name, Group: c.Group, Version: c.Version}
+}
+
+func (s *Server) serveTags(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		s.writePage(w, r, append([]Tag{}, s.tags...))
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var tag Tag
+		if err := json.NewDecoder(r.Body).Decode(&tag); err != nil || tag.Name == "" {
+			writeError(w, http.StatusBadRequest, "invalid tag")
+			return
+		}
+		if s.tagIndex(tag.Name) >= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("tag %s already exists", tag.Name))
+			return
+		}
+		writeJSON(w, http.StatusOK, s.addTag(Tag{Name: tag.Name, Attributes: tag.Attributes}))
+	case len(path) == 1 && r.Method == http.MethodGet:
+		i := s.tagIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "tag not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.tags[i])
+	case len(path) == 1 && r.Method == http.MethodPut:
+		i := s.tagIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "tag not found")
+			return
+		}
+		var update Tag
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid tag")
+			return
+		}
+		tag := s.tags[i]
+		tag.Attributes = update.Attributes
+		writeJSON(w, http.StatusOK, s.addTag(tag))
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		i := s.tagIndex(path[0])
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "tag not found")
+			return
+		}
+		s.tags = append(s.tags[:i], s.tags[i+1:]...)
+		for ci := range s.components {
+			s.components[ci].Tags = removeString(s.components[ci].Tags, path[0])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(path) == 2 && path[0] == "associate" && (r.Method == http.MethodPost || r.Method == http.MethodDelete):
+		if s.tagIndex(path[1]) < 0 {
+			writeError(w, http.StatusNotFound, "tag not found")
+			return
+		}
+		if !s.writable(w) {
+			return
+		}
+
+		var resp componentsAssociated
+		resp.Data.ComponentsAssociated = []componentSummary{}
+		for ci, c := range s.components {
+			if !matches(c, r.URL.Query()) {
+				continue
+			}
+
+			tags := removeString(c.Tags, path[1])
+			if r.Method == http.MethodPost {
+				tags = append(tags, path[1])
+			}
+			s.components[ci].Tags = tags
+
+			resp.Data.ComponentsAssociated = append(resp.Data.ComponentsAssociated, summarize(c))
+		}
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		resp.Status = http.StatusOK
+		resp.Message = "Association successful"
+		writeJSON(w, http.StatusOK, resp)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func removeString(values []string, v string) []string {
+	var kept []string
+	for _, s := range values {
+		if s != v {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
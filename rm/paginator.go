@@ -0,0 +1,259 @@
+package nexusrm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+type paginatedResponse struct {
+	Items             json.RawMessage `json:"items"`
+	ContinuationToken string          `json:"continuationToken"`
+}
+
+// Paginator lazily retrieves the pages of an RM listing which is paged with continuation tokens.
+// Only the current page is held in memory and iteration can be stopped at any time.
+//
+//	p := nexusrm.ComponentsPaginator(rm, "maven-releases")
+//	for p.Next(ctx) {
+//		var page []nexusrm.RepositoryItem
+//		if err := p.Decode(&page); err != nil {
+//			return err
+//		}
+//		// process the page and save p.Token() to resume later
+//	}
+//	if err := p.Err(); err != nil {
+//		return err
+//	}
+type Paginator struct {
+	rm       RM
+	endpoint string
+	token    string
+	done     bool
+	items    json.RawMessage
+	err      error
+}
+
+// NewPaginator creates a Paginator for the given endpoint, which may include a query
+func NewPaginator(rm RM, endpoint string) *Paginator {
+	return &Paginator{rm: rm, endpoint: endpoint}
+}
+
+// ComponentsPaginator creates a Paginator over the components of the indicated repository
+func ComponentsPaginator(rm RM, repo string) *Paginator {
+	return NewPaginator(rm, fmt.Sprintf(restListComponentsByRepo, url.QueryEscape(repo)))
+}
+
+// AssetsPaginator creates a Paginator over the assets of the indicated repository
+func AssetsPaginator(rm RM, repo string) *Paginator {
+	return NewPaginator(rm, fmt.Sprintf(restListAssetsByRepo, url.QueryEscape(repo)))
+}
+
+// TagsPaginator creates a Paginator over the tags of the RM instance
+func TagsPaginator(rm RM) *Paginator {
+	return NewPaginator(rm, restTagging)
+}
+
+// SearchComponentsPaginator creates a Paginator over the components which match the query
+func SearchComponentsPaginator(rm RM, query nexus.SearchQueryBuilder) *Paginator {
+	return NewPaginator(rm, fmt.Sprintf("%s?%s", restSearchComponents, query.Build()))
+}
+
+// SearchAssetsPaginator creates a Paginator over the assets which match the query
+func SearchAssetsPaginator(rm RM, query nexus.SearchQueryBuilder) *Paginator {
+	return NewPaginator(rm, fmt.Sprintf("%s?%s", restSearchAssets, query.Build()))
+}
+
+// Resume starts the iteration at the page of the given continuation token, as previously returned by Token
+func (p *Paginator) Resume(token string) *Paginator {
+	p.token = token
+	return p
+}
+
+// Next retrieves the next page. It returns false once all pages were retrieved or when an error occurred
+func (p *Paginator) Next(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	endpoint := p.endpoint
+	if p.token != "" {
+		sep := "?"
+		if strings.Contains(endpoint, "?") {
+			sep = "&"
+		}
+		endpoint += sep + "continuationToken=" + url.QueryEscape(p.token)
+	}
+
+	body, _, err := nexus.GetContext(ctx, p.rm, endpoint)
+	if err != nil {
+		p.err = fmt.Errorf("could not get page: %w", err)
+		return false
+	}
+
+	var resp paginatedResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		p.err = fmt.Errorf("could not read page: %w", err)
+		return false
+	}
+
+	p.items = resp.Items
+	p.token = resp.ContinuationToken
+	p.done = resp.ContinuationToken == ""
+
+	return true
+}
+
+// Decode unmarshals the items of the current page into v, which should be a pointer to a slice
+func (p *Paginator) Decode(v interface{}) error {
+	if len(p.items) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(p.items, v); err != nil {
+		return fmt.Errorf("could not read page items: %w", err)
+	}
+	return nil
+}
+
+// Token returns the continuation token of the next page, or an empty string if the current page is the last one
+func (p *Paginator) Token() string {
+	return p.token
+}
+
+// Err returns the error, if any, which stopped the iteration
+func (p *Paginator) Err() error {
+	return p.err
+}
+
+// iterator lazily retrieves the items of a Paginator one at a time, only holding the current page in memory
+type iterator struct {
+	p     *Paginator
+	items []json.RawMessage
+	item  json.RawMessage
+	err   error
+}
+
+func newIterator(p *Paginator) iterator {
+	return iterator{p: p}
+}
+
+// next moves to the next item, retrieving the next page once the items of the current one were used
+func (it *iterator) next(ctx context.Context) bool {
+	for len(it.items) == 0 {
+		if it.err != nil || !it.p.Next(ctx) {
+			return false
+		}
+		if err := it.p.Decode(&it.items); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// decode unmarshals the current item into v
+func (it *iterator) decode(v interface{}, kind string) bool {
+	if err := json.Unmarshal(it.item, v); err != nil {
+		it.err = fmt.Errorf("could not read %s: %w", kind, err)
+		return false
+	}
+	return true
+}
+
+// Err returns the error, if any, which stopped the iteration
+func (it *iterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.p.Err()
+}
+
+// ComponentIterator lazily retrieves components one at a time, requesting the next page only once needed.
+//
+//	it := nexusrm.ComponentsIterator(rm, "maven-releases")
+//	for it.Next(ctx) {
+//		fmt.Println(it.Item().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ComponentIterator struct {
+	iterator
+	current RepositoryItem
+}
+
+// ComponentsIterator creates a ComponentIterator over the components of the indicated repository
+func ComponentsIterator(rm RM, repo string) *ComponentIterator {
+	return &ComponentIterator{iterator: newIterator(ComponentsPaginator(rm, repo))}
+}
+
+// SearchComponentsIterator creates a ComponentIterator over the components which match the query
+func SearchComponentsIterator(rm RM, query nexus.SearchQueryBuilder) *ComponentIterator {
+	return &ComponentIterator{iterator: newIterator(SearchComponentsPaginator(rm, query))}
+}
+
+// Next moves to the next component. It returns false once all components were retrieved or when an error occurred
+func (it *ComponentIterator) Next(ctx context.Context) bool {
+	it.current = RepositoryItem{}
+	return it.next(ctx) && it.decode(&it.current, "component")
+}
+
+// Item returns the current component
+func (it *ComponentIterator) Item() RepositoryItem {
+	return it.current
+}
+
+// AssetIterator lazily retrieves assets one at a time, requesting the next page only once needed
+type AssetIterator struct {
+	iterator
+	current RepositoryItemAsset
+}
+
+// AssetsIterator creates an AssetIterator over the assets of the indicated repository
+func AssetsIterator(rm RM, repo string) *AssetIterator {
+	return &AssetIterator{iterator: newIterator(AssetsPaginator(rm, repo))}
+}
+
+// SearchAssetsIterator creates an AssetIterator over the assets which match the query
+func SearchAssetsIterator(rm RM, query nexus.SearchQueryBuilder) *AssetIterator {
+	return &AssetIterator{iterator: newIterator(SearchAssetsPaginator(rm, query))}
+}
+
+// Next moves to the next asset. It returns false once all assets were retrieved or when an error occurred
+func (it *AssetIterator) Next(ctx context.Context) bool {
+	it.current = RepositoryItemAsset{}
+	return it.next(ctx) && it.decode(&it.current, "asset")
+}
+
+// Item returns the current asset
+func (it *AssetIterator) Item() RepositoryItemAsset {
+	return it.current
+}
+
+// TagIterator lazily retrieves tags one at a time, requesting the next page only once needed
+type TagIterator struct {
+	iterator
+	current Tag
+}
+
+// TagsIterator creates a TagIterator over the tags of the RM instance
+func TagsIterator(rm RM) *TagIterator {
+	return &TagIterator{iterator: newIterator(TagsPaginator(rm))}
+}
+
+// Next moves to the next tag. It returns false once all tags were retrieved or when an error occurred
+func (it *TagIterator) Next(ctx context.Context) bool {
+	it.current = Tag{}
+	return it.next(ctx) && it.decode(&it.current, "tag")
+}
+
+// Item returns the current tag
+func (it *TagIterator) Item() Tag {
+	return it.current
+}
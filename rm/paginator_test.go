@@ -0,0 +1,187 @@
+package nexusrm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const dummyPages = 3
+
+func paginatorTestRM(t *testing.T) (RM, *int, func()) {
+	var requests int
+
+	rm, mock := newTestRM(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Query().Get("repository") != "repo-maven" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		page := 0
+		if token := r.URL.Query().Get("continuationToken"); token != "" {
+			var err error
+			if page, err = strconv.Atoi(token); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		var resp listComponentsResponse
+		resp.Items = []RepositoryItem{{ID: fmt.Sprintf("page%d-a", page)}, {ID: fmt.Sprintf("page%d-b", page)}}
+		if page < dummyPages-1 {
+			resp.ContinuationToken = strconv.Itoa(page + 1)
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	})
+
+	return rm, &requests, mock.Close
+}
+
+func TestPaginator(t *testing.T) {
+	rm, requests, done := paginatorTestRM(t)
+	defer done()
+
+	var ids []string
+	p := ComponentsPaginator(rm, "repo-maven")
+	for p.Next(context.Background()) {
+		if *requests != len(ids)/2+1 {
+			t.Fatalf("Expected pages to be retrieved lazily but made %d requests", *requests)
+		}
+
+		var page []RepositoryItem
+		if err := p.Decode(&page); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range page {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 2*dummyPages || ids[0] != "page0-a" || ids[len(ids)-1] != "page2-b" {
+		t.Errorf("Did not receive expected items: %v", ids)
+	}
+
+	if p.Token() != "" {
+		t.Errorf("Expected no token after the last page but got %q", p.Token())
+	}
+
+	if p.Next(context.Background()) || *requests != dummyPages {
+		t.Error("Paginator continued after the last page")
+	}
+}
+
+func TestPaginatorEarlyStopAndResume(t *testing.T) {
+	rm, requests, done := paginatorTestRM(t)
+	defer done()
+
+	p := ComponentsPaginator(rm, "repo-maven")
+	if !p.Next(context.Background()) {
+		t.Fatal(p.Err())
+	}
+
+	token := p.Token()
+	if token == "" || *requests != 1 {
+		t.Fatalf("Unexpected state after the first page: %q %d", token, *requests)
+	}
+
+	p = ComponentsPaginator(rm, "repo-maven").Resume(token)
+	if !p.Next(context.Background()) {
+		t.Fatal(p.Err())
+	}
+
+	var page []RepositoryItem
+	if err := p.Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page) == 0 || page[0].ID != "page1-a" {
+		t.Errorf("Did not resume at the expected page: %v", page)
+	}
+}
+
+func TestPaginatorError(t *testing.T) {
+	rm, _, done := paginatorTestRM(t)
+	defer done()
+
+	p := ComponentsPaginator(rm, "unknown")
+	if p.Next(context.Background()) {
+		t.Error("Expected no pages")
+	}
+
+	if !errors.Is(p.Err(), nexus.ErrNotFound) {
+		t.Errorf("Expected a not found error but got: %v", p.Err())
+	}
+}
+
+func TestComponentIterator(t *testing.T) {
+	rm, requests, done := paginatorTestRM(t)
+	defer done()
+
+	var ids []string
+	it := ComponentsIterator(rm, "repo-maven")
+	for it.Next(context.Background()) {
+		if *requests != len(ids)/2+1 {
+			t.Fatalf("Expected pages to be retrieved lazily but made %d requests for %d items", *requests, len(ids)+1)
+		}
+		ids = append(ids, it.Item().ID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 2*dummyPages || ids[0] != "page0-a" || ids[len(ids)-1] != "page2-b" {
+		t.Errorf("Did not receive expected items: %v", ids)
+	}
+
+	if it.Next(context.Background()) || it.Item().ID != "" || *requests != dummyPages {
+		t.Error("Iterator continued after the last item")
+	}
+}
+
+func TestSearchAssetsIterator(t *testing.T) {
+	rm, requests, done := paginatorTestRM(t)
+	defer done()
+
+	it := SearchAssetsIterator(rm, NewSearchQueryBuilder().Repository("repo-maven"))
+	for i := 0; i < 3; i++ {
+		if !it.Next(context.Background()) {
+			t.Fatal(it.Err())
+		}
+	}
+
+	if id := it.Item().ID; id != "page1-a" {
+		t.Errorf("Expected the first item of the second page but got %q", id)
+	}
+	if *requests != 2 {
+		t.Errorf("Expected the iteration to stop without retrieving the remaining pages but made %d requests", *requests)
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	rm, _, done := paginatorTestRM(t)
+	defer done()
+
+	it := TagsIterator(rm)
+	if it.Next(context.Background()) {
+		t.Error("Expected no tags")
+	}
+
+	if !errors.Is(it.Err(), nexus.ErrNotFound) {
+		t.Errorf("Expected a not found error but got: %v", it.Err())
+	}
+}
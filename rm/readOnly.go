@@ -2,9 +2,12 @@ package nexusrm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -39,15 +42,11 @@ func (s ReadOnlyState) String() string {
 	return buf.String()
 }
 
-// GetReadOnlyState returns the read-only state of the RM instance
-func GetReadOnlyState(rm RM) (state ReadOnlyState, err error) {
-	body, resp, err := rm.Get(restReadOnly)
+// GetReadOnlyStateContext returns the read-only state of the RM instance
+func GetReadOnlyStateContext(ctx context.Context, rm RM) (state ReadOnlyState, err error) {
+	body, _, err := nexus.GetContext(ctx, rm, restReadOnly)
 	if err != nil {
-		return state, fmt.Errorf("could not get read-only state: %v", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return state, fmt.Errorf("could not get read-only state: %v", resp.Status)
+		return state, fmt.Errorf("could not get read-only state: %w", err)
 	}
 
 	err = json.Unmarshal(body, &state)
@@ -55,31 +54,42 @@ func GetReadOnlyState(rm RM) (state ReadOnlyState, err error) {
 	return
 }
 
-// ReadOnlyEnable enables read-only mode for the RM instance
-func ReadOnlyEnable(rm RM) (state ReadOnlyState, err error) {
-	body, resp, err := rm.Post(restReadOnlyFreeze, nil)
-	if err != nil && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		return
+// GetReadOnlyState returns the read-only state of the RM instance
+func GetReadOnlyState(rm RM) (state ReadOnlyState, err error) {
+	return GetReadOnlyStateContext(context.Background(), rm)
+}
+
+// ReadOnlyEnableContext enables read-only mode for the RM instance
+func ReadOnlyEnableContext(ctx context.Context, rm RM) (state ReadOnlyState, err error) {
+	// A 404 indicates that the instance was already read-only
+	if _, _, err = nexus.PostContext(ctx, rm, restReadOnlyFreeze, nil); err != nil && !errors.Is(err, nexus.ErrNotFound) {
+		return state, fmt.Errorf("could not enable read-only mode: %w", err)
 	}
 
-	err = json.Unmarshal(body, &state)
+	return GetReadOnlyStateContext(ctx, rm)
+}
 
-	return
+// ReadOnlyEnable enables read-only mode for the RM instance
+func ReadOnlyEnable(rm RM) (state ReadOnlyState, err error) {
+	return ReadOnlyEnableContext(context.Background(), rm)
 }
 
-// ReadOnlyRelease disables read-only mode for the RM instance
-func ReadOnlyRelease(rm RM, force bool) (state ReadOnlyState, err error) {
+// ReadOnlyReleaseContext disables read-only mode for the RM instance
+func ReadOnlyReleaseContext(ctx context.Context, rm RM, force bool) (state ReadOnlyState, err error) {
 	endpoint := restReadOnlyRelease
 	if force {
 		endpoint = restReadOnlyForceRelease
 	}
 
-	body, resp, err := rm.Post(endpoint, nil)
-	if err != nil && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		return
+	// A 404 indicates that the instance was not read-only
+	if _, _, err = nexus.PostContext(ctx, rm, endpoint, nil); err != nil && !errors.Is(err, nexus.ErrNotFound) {
+		return state, fmt.Errorf("could not release read-only mode: %w", err)
 	}
 
-	err = json.Unmarshal(body, &state)
+	return GetReadOnlyStateContext(ctx, rm)
+}
 
-	return
+// ReadOnlyRelease disables read-only mode for the RM instance
+func ReadOnlyRelease(rm RM, force bool) (state ReadOnlyState, err error) {
+	return ReadOnlyReleaseContext(context.Background(), rm, force)
 }
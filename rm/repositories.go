@@ -1,100 +1,634 @@
 package nexusrm
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"strings"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const (
+	restRepositories       = "service/rest/v1/repositories"
+	restRepository         = "service/rest/v1/repositories/%s"
+	restRepositoriesByType = "service/rest/v1/repositories/%s/%s"
 )
 
-const restRepositories = "service/rest/v1/repositories"
+// DefaultBlobStore is the blob store of repositories created without one
+const DefaultBlobStore = "default"
 
-/*
-// RepositoryType enumerates the types of repositories in RM
-type repositoryType int
+// RepositoryType is the type of a repository, named as in Repository Manager
+type RepositoryType string
 
+// Types of Repository Manager repositories
 const (
-	Hosted = iota
-	Proxy
-	Group
+	RepositoryHosted RepositoryType = "hosted"
+	RepositoryProxy  RepositoryType = "proxy"
+	RepositoryGroup  RepositoryType = "group"
 )
 
-func (r repositoryType) String() string {
-	switch r {
-	case proxy:
-		return "proxy"
-	case hosted:
-		return "hosted"
-	case group:
-		return "group"
+// ParseRepositoryType returns the type of repository with the given name, ignoring case
+func ParseRepositoryType(s string) (RepositoryType, error) {
+	switch t := RepositoryType(strings.ToLower(s)); t {
+	case RepositoryHosted, RepositoryProxy, RepositoryGroup:
+		return t, nil
 	default:
-		return ""
+		return "", fmt.Errorf("unknown repository type '%s'", s)
 	}
 }
-*/
 
-type repositoryFormat int
+// RepositoryFormat is the format of a repository, named as in Repository Manager
+type RepositoryFormat string
 
-// Enumerates the formats which can be created as Repository Manager repositories
+// Formats of Repository Manager repositories
 const (
-	Unknown repositoryFormat = iota
-	Maven
-	Npm
-	Nuget
-	Apt
-	Docker
-	Golang
-	Raw
-	Rubygems
-	Bower
-	Pypi
-	Yum
-	GitLfs
+	Unknown   RepositoryFormat = ""
+	Maven     RepositoryFormat = "maven2"
+	Npm       RepositoryFormat = "npm"
+	Nuget     RepositoryFormat = "nuget"
+	Apt       RepositoryFormat = "apt"
+	Docker    RepositoryFormat = "docker"
+	Golang    RepositoryFormat = "go"
+	Raw       RepositoryFormat = "raw"
+	Rubygems  RepositoryFormat = "rubygems"
+	Bower     RepositoryFormat = "bower"
+	Pypi      RepositoryFormat = "pypi"
+	Yum       RepositoryFormat = "yum"
+	GitLfs    RepositoryFormat = "gitlfs"
+	Helm      RepositoryFormat = "helm"
+	Conan     RepositoryFormat = "conan"
+	Conda     RepositoryFormat = "conda"
+	Cocoapods RepositoryFormat = "cocoapods"
+	P2        RepositoryFormat = "p2"
+	R         RepositoryFormat = "r"
 )
 
-// Repository collects the information returned by RM about a repository
-type Repository struct {
-	Name       string `json:"name"`
-	Format     string `json:"format"`
+// RepositoryFormats lists every repository format which Repository Manager supports
+var RepositoryFormats = []RepositoryFormat{
+	Maven, Npm, Nuget, Apt, Docker, Golang, Raw, Rubygems, Bower, Pypi, Yum, GitLfs, Helm, Conan, Conda, Cocoapods, P2, R,
+}
+
+// ParseRepositoryFormat returns the format with the given name, ignoring case. The names used
+// by the repository management endpoints are accepted as well, such as maven for maven2
+func ParseRepositoryFormat(s string) (RepositoryFormat, error) {
+	name := strings.ToLower(s)
+	for _, f := range RepositoryFormats {
+		if name == string(f) || name == f.endpoint() {
+			return f, nil
+		}
+	}
+	return Unknown, fmt.Errorf("unknown repository format '%s'", s)
+}
+
+// endpoint returns how the format is named in the repository management endpoints
+func (f RepositoryFormat) endpoint() string {
+	if f == Maven {
+		return "maven"
+	}
+	return string(f)
+}
+
+// WritePolicy controls whether the assets of a hosted repository can be deployed and redeployed
+type WritePolicy string
+
+// Write policies of hosted repositories
+const (
+	WritePolicyAllow     WritePolicy = "ALLOW"
+	WritePolicyAllowOnce WritePolicy = "ALLOW_ONCE"
+	WritePolicyDeny      WritePolicy = "DENY"
+)
+
+// VersionPolicy controls which versions a Maven repository accepts
+type VersionPolicy string
+
+// Version policies of Maven repositories
+const (
+	VersionPolicyRelease  VersionPolicy = "RELEASE"
+	VersionPolicySnapshot VersionPolicy = "SNAPSHOT"
+	VersionPolicyMixed    VersionPolicy = "MIXED"
+)
+
+// LayoutPolicy controls whether the paths of a Maven repository are validated against the Maven layout
+type LayoutPolicy string
+
+// Layout policies of Maven repositories
+const (
+	LayoutPolicyStrict     LayoutPolicy = "STRICT"
+	LayoutPolicyPermissive LayoutPolicy = "PERMISSIVE"
+)
+
+// StorageAttributes describes where a repository stores its assets
+type StorageAttributes struct {
+	BlobStoreName               string `json:"blobStoreName"`
+	StrictContentTypeValidation bool   `json:"strictContentTypeValidation"`
+	// WritePolicy only applies to hosted repositories
+	WritePolicy WritePolicy `json:"writePolicy,omitempty"`
+}
+
+// CleanupAttributes lists the cleanup policies applied to a repository
+type CleanupAttributes struct {
+	PolicyNames []string `json:"policyNames"`
+}
+
+// ProxyAttributes describes the remote repository of a proxy. Ages are in minutes, -1 caches forever
+type ProxyAttributes struct {
+	RemoteURL      string `json:"remoteUrl"`
+	ContentMaxAge  int    `json:"contentMaxAge"`
+	MetadataMaxAge int    `json:"metadataMaxAge"`
+}
+
+// NegativeCacheAttributes controls how long a proxy remembers that the remote repository did not have an asset
+type NegativeCacheAttributes struct {
+	Enabled bool `json:"enabled"`
+	// TimeToLive is in minutes
+	TimeToLive int `json:"timeToLive"`
+}
+
+// HTTPClientConnection tunes the connections of a proxy to its remote repository
+type HTTPClientConnection struct {
+	Retries                 *int   `json:"retries,omitempty"`
+	UserAgentSuffix         string `json:"userAgentSuffix,omitempty"`
+	Timeout                 *int   `json:"timeout,omitempty"`
+	EnableCircularRedirects bool   `json:"enableCircularRedirects"`
+	EnableCookies           bool   `json:"enableCookies"`
+	UseTrustStore           bool   `json:"useTrustStore"`
+}
+
+// HTTPClientAuthentication holds the credentials used by a proxy with its remote repository
+type HTTPClientAuthentication struct {
+	// Type is either username or ntlm
 	Type       string `json:"type"`
-	URL        string `json:"url"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	NTLMHost   string `json:"ntlmHost,omitempty"`
+	NTLMDomain string `json:"ntlmDomain,omitempty"`
+}
+
+// HTTPClientAttributes describes how a proxy connects to its remote repository
+type HTTPClientAttributes struct {
+	Blocked        bool                      `json:"blocked"`
+	AutoBlock      bool                      `json:"autoBlock"`
+	Connection     *HTTPClientConnection     `json:"connection,omitempty"`
+	Authentication *HTTPClientAuthentication `json:"authentication,omitempty"`
+}
+
+// GroupAttributes lists the members of a group repository
+type GroupAttributes struct {
+	MemberNames []string `json:"memberNames"`
+	// WritableMember is the member which receives the pushes to a Docker group
+	WritableMember string `json:"writableMember,omitempty"`
+}
+
+// MavenAttributes are the settings of Maven repositories
+type MavenAttributes struct {
+	VersionPolicy VersionPolicy `json:"versionPolicy"`
+	LayoutPolicy  LayoutPolicy  `json:"layoutPolicy"`
+	// ContentDisposition is either INLINE or ATTACHMENT
+	ContentDisposition string `json:"contentDisposition,omitempty"`
+}
+
+// DockerAttributes are the settings of Docker repositories, including their connector ports
+type DockerAttributes struct {
+	V1Enabled      bool   `json:"v1Enabled"`
+	ForceBasicAuth bool   `json:"forceBasicAuth"`
+	HTTPPort       *int   `json:"httpPort,omitempty"`
+	HTTPSPort      *int   `json:"httpsPort,omitempty"`
+	Subdomain      string `json:"subdomain,omitempty"`
+}
+
+// DockerProxyAttributes describe the index of a Docker proxy
+type DockerProxyAttributes struct {
+	// IndexType is either HUB, REGISTRY or CUSTOM
+	IndexType                string   `json:"indexType"`
+	IndexURL                 string   `json:"indexUrl,omitempty"`
+	CacheForeignLayers       bool     `json:"cacheForeignLayers"`
+	ForeignLayerURLWhitelist []string `json:"foreignLayerUrlWhitelist,omitempty"`
+}
+
+// AptAttributes are the settings of apt repositories. Flat only applies to proxies
+type AptAttributes struct {
+	Distribution string `json:"distribution"`
+	Flat         bool   `json:"flat"`
+}
+
+// SigningAttributes hold the GPG key which signs the metadata of hosted apt and yum repositories
+type SigningAttributes struct {
+	Keypair    string `json:"keypair"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// YumAttributes are the settings of hosted yum repositories
+type YumAttributes struct {
+	RepodataDepth int `json:"repodataDepth"`
+	// DeployPolicy is either STRICT or PERMISSIVE
+	DeployPolicy string `json:"deployPolicy,omitempty"`
+}
+
+// NugetProxyAttributes are the settings of NuGet proxies
+type NugetProxyAttributes struct {
+	QueryCacheItemMaxAge int `json:"queryCacheItemMaxAge"`
+	// NugetVersion is either V2 or V3
+	NugetVersion string `json:"nugetVersion,omitempty"`
+}
+
+// RawAttributes are the settings of raw repositories
+type RawAttributes struct {
+	// ContentDisposition is either INLINE or ATTACHMENT
+	ContentDisposition string `json:"contentDisposition,omitempty"`
+}
+
+// NpmProxyAttributes are the firewall settings of npm proxies
+type NpmProxyAttributes struct {
+	RemoveNonCataloged bool `json:"removeNonCataloged"`
+	RemoveQuarantined  bool `json:"removeQuarantined"`
+}
+
+// BowerAttributes are the settings of Bower proxies
+type BowerAttributes struct {
+	RewritePackageURLs bool `json:"rewritePackageUrls"`
+}
+
+// RepositoryConfig is the configuration of a repository of any format and type, as managed through the REST API.
+// Only the sections which apply to the format and type of the repository are used
+type RepositoryConfig struct {
+	Name string `json:"name"`
+	// Online repositories serve requests. Repositories are created online unless it is set to false
+	Online  *bool              `json:"online,omitempty"`
+	Storage StorageAttributes  `json:"storage"`
+	Cleanup *CleanupAttributes `json:"cleanup,omitempty"`
+
+	// Proxy, NegativeCache, HTTPClient and RoutingRule apply to proxies
+	Proxy         *ProxyAttributes         `json:"proxy,omitempty"`
+	NegativeCache *NegativeCacheAttributes `json:"negativeCache,omitempty"`
+	HTTPClient    *HTTPClientAttributes    `json:"httpClient,omitempty"`
+	RoutingRule   string                   `json:"routingRule,omitempty"`
+
+	// Group applies to groups
+	Group *GroupAttributes `json:"group,omitempty"`
+
+	Maven       *MavenAttributes       `json:"maven,omitempty"`
+	Docker      *DockerAttributes      `json:"docker,omitempty"`
+	DockerProxy *DockerProxyAttributes `json:"dockerProxy,omitempty"`
+	Apt         *AptAttributes         `json:"apt,omitempty"`
+	AptSigning  *SigningAttributes     `json:"aptSigning,omitempty"`
+	Yum         *YumAttributes         `json:"yum,omitempty"`
+	YumSigning  *SigningAttributes     `json:"yumSigning,omitempty"`
+	NugetProxy  *NugetProxyAttributes  `json:"nugetProxy,omitempty"`
+	Raw         *RawAttributes         `json:"raw,omitempty"`
+	Npm         *NpmProxyAttributes    `json:"npm,omitempty"`
+	Bower       *BowerAttributes       `json:"bower,omitempty"`
+}
+
+// withDefaults fills in the settings which Repository Manager requires, with the defaults of its UI
+func (c RepositoryConfig) withDefaults(format RepositoryFormat, repoType RepositoryType) RepositoryConfig {
+	if c.Online == nil {
+		online := true
+		c.Online = &online
+	}
+	if c.Storage.BlobStoreName == "" {
+		c.Storage.BlobStoreName = DefaultBlobStore
+	}
+
+	switch repoType {
+	case RepositoryHosted:
+		if c.Storage.WritePolicy == "" {
+			c.Storage.WritePolicy = WritePolicyAllowOnce
+		}
+	case RepositoryProxy:
+		if c.NegativeCache == nil {
+			c.NegativeCache = &NegativeCacheAttributes{Enabled: true, TimeToLive: 1440}
+		}
+		if c.HTTPClient == nil {
+			c.HTTPClient = &HTTPClientAttributes{AutoBlock: true}
+		}
+	}
+
+	switch format {
+	case Maven:
+		if c.Maven == nil {
+			c.Maven = &MavenAttributes{VersionPolicy: VersionPolicyRelease, LayoutPolicy: LayoutPolicyStrict}
+		}
+	case Docker:
+		if c.Docker == nil {
+			c.Docker = &DockerAttributes{ForceBasicAuth: true}
+		}
+		if repoType == RepositoryProxy && c.DockerProxy == nil {
+			c.DockerProxy = &DockerProxyAttributes{IndexType: "REGISTRY"}
+		}
+	}
+
+	return c
+}
+
+// Repository collects the information returned by RM about a repository. The settings which do not apply to its
+// format and type are nil. GetRepositories only fills in the name, format, type, URL and the remote URL of proxies
+type Repository struct {
+	Name   string           `json:"name"`
+	Format RepositoryFormat `json:"format"`
+	Type   RepositoryType   `json:"type"`
+	URL    string           `json:"url"`
+	Online bool             `json:"online"`
+
+	Storage       *StorageAttributes       `json:"storage,omitempty"`
+	Cleanup       *CleanupAttributes       `json:"cleanup,omitempty"`
+	Proxy         *ProxyAttributes         `json:"proxy,omitempty"`
+	NegativeCache *NegativeCacheAttributes `json:"negativeCache,omitempty"`
+	HTTPClient    *HTTPClientAttributes    `json:"httpClient,omitempty"`
+	RoutingRule   string                   `json:"routingRule,omitempty"`
+	Group         *GroupAttributes         `json:"group,omitempty"`
+
+	Maven       *MavenAttributes       `json:"maven,omitempty"`
+	Docker      *DockerAttributes      `json:"docker,omitempty"`
+	DockerProxy *DockerProxyAttributes `json:"dockerProxy,omitempty"`
+	Apt         *AptAttributes         `json:"apt,omitempty"`
+	AptSigning  *SigningAttributes     `json:"aptSigning,omitempty"`
+	Yum         *YumAttributes         `json:"yum,omitempty"`
+	YumSigning  *SigningAttributes     `json:"yumSigning,omitempty"`
+	NugetProxy  *NugetProxyAttributes  `json:"nugetProxy,omitempty"`
+	Raw         *RawAttributes         `json:"raw,omitempty"`
+	Npm         *NpmProxyAttributes    `json:"npm,omitempty"`
+	Bower       *BowerAttributes       `json:"bower,omitempty"`
+}
+
+// Config returns the configuration of the repository, e.g. to change it with UpdateRepository
+func (r Repository) Config() RepositoryConfig {
+	online := r.Online
+	config := RepositoryConfig{
+		Name:          r.Name,
+		Online:        &online,
+		Cleanup:       r.Cleanup,
+		Proxy:         r.Proxy,
+		NegativeCache: r.NegativeCache,
+		HTTPClient:    r.HTTPClient,
+		RoutingRule:   r.RoutingRule,
+		Group:         r.Group,
+		Maven:         r.Maven,
+		Docker:        r.Docker,
+		DockerProxy:   r.DockerProxy,
+		Apt:           r.Apt,
+		AptSigning:    r.AptSigning,
+		Yum:           r.Yum,
+		YumSigning:    r.YumSigning,
+		NugetProxy:    r.NugetProxy,
+		Raw:           r.Raw,
+		Npm:           r.Npm,
+		Bower:         r.Bower,
+	}
+	if r.Storage != nil {
+		config.Storage = *r.Storage
+	}
+	return config
+}
+
+// listedRepository is a repository as listed by RM, which only has the remote URL of proxies as attributes
+type listedRepository struct {
+	Repository
 	Attributes struct {
-		Proxy struct {
-			RemoteURL string `json:"remoteUrl"`
-		} `json:"proxy"`
-	} `json:"attributes,omitempty"`
+		Proxy *ProxyAttributes `json:"proxy"`
+	} `json:"attributes"`
 }
 
-// GetRepositories returns a list of components in the indicated repository
-func GetRepositories(rm RM) ([]Repository, error) {
+// GetRepositoriesContext returns a list of the repositories of the RM instance
+func GetRepositoriesContext(ctx context.Context, rm RM) ([]Repository, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("could not find repositories: %v", err)
+		return fmt.Errorf("could not find repositories: %w", err)
 	}
 
-	body, resp, err := rm.Get(restRepositories)
-	if err != nil || resp.StatusCode != http.StatusOK {
+	body, _, err := nexus.GetContext(ctx, rm, restRepositories)
+	if err != nil {
 		return nil, doError(err)
 	}
 
-	repos := make([]Repository, 0)
-	if err := json.Unmarshal(body, &repos); err != nil {
+	var listed []listedRepository
+	if err := json.Unmarshal(body, &listed); err != nil {
 		return nil, doError(err)
 	}
 
+	repos := make([]Repository, len(listed))
+	for i, l := range listed {
+		repos[i] = l.Repository
+		if repos[i].Proxy == nil {
+			repos[i].Proxy = l.Attributes.Proxy
+		}
+	}
+
 	return repos, nil
 }
 
-// GetRepositoryByName returns information on a named repository
-func GetRepositoryByName(rm RM, name string) (repo Repository, err error) {
-	repos, err := GetRepositories(rm)
+// GetRepositories returns a list of the repositories of the RM instance
+func GetRepositories(rm RM) ([]Repository, error) {
+	return GetRepositoriesContext(context.Background(), rm)
+}
+
+// GetRepositoryByNameContext returns information on a named repository, including all of its settings.
+// Releases of RM which cannot manage repositories through the REST API only return what they list
+func GetRepositoryByNameContext(ctx context.Context, rm RM, name string) (Repository, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get repository '%s': %w", name, err)
+	}
+
+	if supported, err := Capabilities(rm).Supports(ctx, FeatureRepositoryManagement); err == nil && !supported {
+		return findRepositoryContext(ctx, rm, name)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf(restRepository, name))
+	if err != nil {
+		return Repository{}, doError(err)
+	}
+
+	var repo Repository
+	if err := json.Unmarshal(body, &repo); err != nil {
+		return Repository{}, doError(err)
+	}
+
+	endpoint, err := repositoryEndpoint(repo.Format, repo.Type)
 	if err != nil {
-		return repo, fmt.Errorf("could not get list of repositories: %v", err)
+		return Repository{}, doError(err)
 	}
 
-	for _, repo = range repos {
+	if body, _, err = nexus.GetContext(ctx, rm, fmt.Sprintf("%s/%s", endpoint, name)); err != nil {
+		return Repository{}, doError(err)
+	}
+
+	if err := json.Unmarshal(body, &repo); err != nil {
+		return Repository{}, doError(err)
+	}
+
+	return repo, nil
+}
+
+// GetRepositoryByName returns information on a named repository, including all of its settings
+func GetRepositoryByName(rm RM, name string) (Repository, error) {
+	return GetRepositoryByNameContext(context.Background(), rm, name)
+}
+
+// findRepositoryContext looks for the named repository in the list of repositories
+func findRepositoryContext(ctx context.Context, rm RM, name string) (Repository, error) {
+	repos, err := GetRepositoriesContext(ctx, rm)
+	if err != nil {
+		return Repository{}, fmt.Errorf("could not get list of repositories: %w", err)
+	}
+
+	for _, repo := range repos {
 		if repo.Name == name {
-			return
+			return repo, nil
 		}
 	}
 
-	return repo, fmt.Errorf("did not find repository '%s': %v", name, err)
+	return Repository{}, fmt.Errorf("did not find repository '%s': %w", name, nexus.ErrNotFound)
+}
+
+func repositoryEndpoint(format RepositoryFormat, repoType RepositoryType) (string, error) {
+	if format == Unknown {
+		return "", fmt.Errorf("unknown repository format")
+	}
+	if _, err := ParseRepositoryType(string(repoType)); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(restRepositoriesByType, format.endpoint(), repoType), nil
+}
+
+// CreateRepositoryContext creates a repository of the indicated format and type. The blob store, write policy,
+// negative cache, HTTP client and Maven and Docker settings which are not given get the defaults of the UI
+func CreateRepositoryContext(ctx context.Context, rm RM, format RepositoryFormat, repoType RepositoryType, config RepositoryConfig) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not create %s repository '%s': %w", repoType, config.Name, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureRepositoryManagement); err != nil {
+		return doError(err)
+	}
+
+	endpoint, err := repositoryEndpoint(format, repoType)
+	if err != nil {
+		return doError(err)
+	}
+
+	buf, err := json.Marshal(config.withDefaults(format, repoType))
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err = nexus.PostContext(ctx, rm, endpoint, bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// CreateRepository creates a repository of the indicated format and type
+func CreateRepository(rm RM, format RepositoryFormat, repoType RepositoryType, config RepositoryConfig) error {
+	return CreateRepositoryContext(context.Background(), rm, format, repoType, config)
+}
+
+// CreateHostedRepositoryContext creates a hosted repository of the indicated format
+func CreateHostedRepositoryContext(ctx context.Context, rm RM, format RepositoryFormat, config RepositoryConfig) error {
+	return CreateRepositoryContext(ctx, rm, format, RepositoryHosted, config)
+}
+
+// CreateHostedRepository creates a hosted repository of the indicated format
+func CreateHostedRepository(rm RM, format RepositoryFormat, config RepositoryConfig) error {
+	return CreateHostedRepositoryContext(context.Background(), rm, format, config)
+}
+
+// CreateProxyRepositoryContext creates a proxy repository of the indicated format
+func CreateProxyRepositoryContext(ctx context.Context, rm RM, format RepositoryFormat, config RepositoryConfig) error {
+	return CreateRepositoryContext(ctx, rm, format, RepositoryProxy, config)
+}
+
+// CreateProxyRepository creates a proxy repository of the indicated format
+func CreateProxyRepository(rm RM, format RepositoryFormat, config RepositoryConfig) error {
+	return CreateProxyRepositoryContext(context.Background(), rm, format, config)
+}
+
+// CreateGroupRepositoryContext creates a group repository of the indicated format
+func CreateGroupRepositoryContext(ctx context.Context, rm RM, format RepositoryFormat, config RepositoryConfig) error {
+	return CreateRepositoryContext(ctx, rm, format, RepositoryGroup, config)
+}
+
+// CreateGroupRepository creates a group repository of the indicated format
+func CreateGroupRepository(rm RM, format RepositoryFormat, config RepositoryConfig) error {
+	return CreateGroupRepositoryContext(context.Background(), rm, format, config)
+}
+
+// GetRepositoryConfigContext returns the configuration of the named repository of the indicated format and type
+func GetRepositoryConfigContext(ctx context.Context, rm RM, format RepositoryFormat, repoType RepositoryType, name string) (RepositoryConfig, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get configuration of repository '%s': %w", name, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureRepositoryManagement); err != nil {
+		return RepositoryConfig{}, doError(err)
+	}
+
+	endpoint, err := repositoryEndpoint(format, repoType)
+	if err != nil {
+		return RepositoryConfig{}, doError(err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf("%s/%s", endpoint, name))
+	if err != nil {
+		return RepositoryConfig{}, doError(err)
+	}
+
+	var config RepositoryConfig
+	if err := json.Unmarshal(body, &config); err != nil {
+		return RepositoryConfig{}, doError(err)
+	}
+
+	return config, nil
+}
+
+// GetRepositoryConfig returns the configuration of the named repository of the indicated format and type
+func GetRepositoryConfig(rm RM, format RepositoryFormat, repoType RepositoryType, name string) (RepositoryConfig, error) {
+	return GetRepositoryConfigContext(context.Background(), rm, format, repoType, name)
+}
+
+// UpdateRepositoryContext replaces the configuration of the repository named by the configuration.
+// Unlike creation, no defaults are filled in, so the configuration is usually read with GetRepositoryConfig first
+func UpdateRepositoryContext(ctx context.Context, rm RM, format RepositoryFormat, repoType RepositoryType, config RepositoryConfig) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not update repository '%s': %w", config.Name, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureRepositoryManagement); err != nil {
+		return doError(err)
+	}
+
+	endpoint, err := repositoryEndpoint(format, repoType)
+	if err != nil {
+		return doError(err)
+	}
+
+	buf, err := json.Marshal(config)
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err = nexus.PutContext(ctx, rm, fmt.Sprintf("%s/%s", endpoint, config.Name), bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// UpdateRepository replaces the configuration of the repository named by the configuration
+func UpdateRepository(rm RM, format RepositoryFormat, repoType RepositoryType, config RepositoryConfig) error {
+	return UpdateRepositoryContext(context.Background(), rm, format, repoType, config)
+}
+
+// DeleteRepositoryContext deletes the named repository and its content
+func DeleteRepositoryContext(ctx context.Context, rm RM, name string) error {
+	if _, err := nexus.DelContext(ctx, rm, fmt.Sprintf(restRepository, name)); err != nil {
+		return fmt.Errorf("could not delete repository '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// DeleteRepository deletes the named repository and its content
+func DeleteRepository(rm RM, name string) error {
+	return DeleteRepositoryContext(context.Background(), rm, name)
 }
@@ -2,32 +2,100 @@ package nexusrm
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
+var dummyDockerPort = 8082
+
 var dummyRepos = []Repository{
-	{Name: "repo-maven", Format: "maven2", Type: "hosted", URL: "http://localhost:8081/blah/repo-maven"},
-	{Name: "repo-nuget", Format: "nuget", Type: "hosted", URL: "http://localhost:8081/blah/repo-nuget"},
-	{Name: "repo-pypi", Format: "pypi", Type: "group", URL: "http://localhost:8081/blah/repo-pypi"},
-	{Name: "repo-npm", Format: "npm", Type: "proxy", URL: "http://localhost:8081/blah/repo-npm"}, //, Attributes: {Proxy: {RemoteURL: "http://bestest.repo"}}},
+	{
+		Name: "repo-maven", Format: Maven, Type: RepositoryHosted, URL: "http://localhost:8081/blah/repo-maven", Online: true,
+		Storage: &StorageAttributes{BlobStoreName: "default", StrictContentTypeValidation: true, WritePolicy: WritePolicyAllowOnce},
+		Maven:   &MavenAttributes{VersionPolicy: VersionPolicyRelease, LayoutPolicy: LayoutPolicyStrict},
+	},
+	{
+		Name: "repo-nuget", Format: Nuget, Type: RepositoryHosted, URL: "http://localhost:8081/blah/repo-nuget", Online: true,
+		Storage: &StorageAttributes{BlobStoreName: "default", WritePolicy: WritePolicyAllow},
+	},
+	{
+		Name: "repo-pypi", Format: Pypi, Type: RepositoryGroup, URL: "http://localhost:8081/blah/repo-pypi", Online: true,
+		Storage: &StorageAttributes{BlobStoreName: "default"},
+		Group:   &GroupAttributes{MemberNames: []string{"repo-pypi-proxy"}},
+	},
+	{
+		Name: "repo-npm", Format: Npm, Type: RepositoryProxy, URL: "http://localhost:8081/blah/repo-npm",
+		Storage:       &StorageAttributes{BlobStoreName: "npm"},
+		Proxy:         &ProxyAttributes{RemoteURL: "http://bestest.repo", ContentMaxAge: 1440, MetadataMaxAge: 1440},
+		NegativeCache: &NegativeCacheAttributes{Enabled: true, TimeToLive: 1440},
+		HTTPClient:    &HTTPClientAttributes{Blocked: true, AutoBlock: true},
+	},
+	{
+		Name: "repo-docker", Format: Docker, Type: RepositoryHosted, URL: "http://localhost:8081/blah/repo-docker", Online: true,
+		Storage: &StorageAttributes{BlobStoreName: "docker", WritePolicy: WritePolicyAllow},
+		Docker:  &DockerAttributes{ForceBasicAuth: true, HTTPPort: &dummyDockerPort},
+	},
+}
+
+// listed returns what RM lists of the repository
+func listed(repo Repository) Repository {
+	l := Repository{Name: repo.Name, Format: repo.Format, Type: repo.Type, URL: repo.URL}
+	if repo.Proxy != nil {
+		l.Proxy = &ProxyAttributes{RemoteURL: repo.Proxy.RemoteURL}
+	}
+	return l
 }
 
 func repositoriesTestFunc(t *testing.T, w http.ResponseWriter, r *http.Request) {
-	switch {
-	case r.Method == http.MethodGet:
-		repos, err := json.Marshal(dummyRepos)
-		if err != nil {
-			t.Fatal(err)
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var resp interface{}
+	switch path := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path[1:], restRepositories), "/"), "/"); len(path) {
+	case 1:
+		if path[0] == "" {
+			repos := make([]map[string]interface{}, len(dummyRepos))
+			for i, repo := range dummyRepos {
+				repos[i] = map[string]interface{}{"name": repo.Name, "format": repo.Format, "type": repo.Type, "url": repo.URL}
+				if repo.Proxy != nil {
+					repos[i]["attributes"] = map[string]interface{}{"proxy": map[string]string{"remoteUrl": repo.Proxy.RemoteURL}}
+				}
+			}
+			resp = repos
+			break
+		}
+		for _, repo := range dummyRepos {
+			if repo.Name == path[0] {
+				resp = map[string]interface{}{"name": repo.Name, "format": repo.Format, "type": repo.Type, "url": repo.URL, "online": repo.Online}
+			}
+		}
+	case 3:
+		for _, repo := range dummyRepos {
+			if repo.Name == path[2] && repo.Format.endpoint() == path[0] && string(repo.Type) == path[1] {
+				resp = repo
+			}
 		}
+	}
 
-		fmt.Fprintln(w, string(repos))
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+	if resp == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
 	}
+
+	buf, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(w, string(buf))
 }
 
 func repositoriesTestRM(t *testing.T) (rm RM, mock *httptest.Server) {
@@ -42,10 +110,13 @@ func TestGetRepositories(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	t.Logf("%q\n", repos)
+	t.Logf("%+v\n", repos)
 
+	if len(repos) != len(dummyRepos) {
+		t.Fatalf("Expected %d repositories but got %d", len(dummyRepos), len(repos))
+	}
 	for i, repo := range repos {
-		if !reflect.DeepEqual(repo, dummyRepos[i]) {
+		if !reflect.DeepEqual(repo, listed(dummyRepos[i])) {
 			t.Error("Did not receive the expected repositories")
 		}
 	}
@@ -61,9 +132,236 @@ func TestGetRepositoryByName(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	t.Logf("%q\n", repo)
+	t.Logf("%+v\n", repo)
 
 	if !reflect.DeepEqual(repo, dummyRepos[dummyRepoIdx]) {
 		t.Error("Did not receive the expected repositories")
 	}
 }
+
+func TestGetRepositoryByNameSettings(t *testing.T) {
+	rm, mock := repositoriesTestRM(t)
+	defer mock.Close()
+
+	for _, want := range dummyRepos {
+		repo, err := GetRepositoryByName(rm, want.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(repo, want) {
+			t.Errorf("Unexpected repository\ngot:  %+v\nwant: %+v", repo, want)
+		}
+	}
+
+	if _, err := GetRepositoryByName(rm, "nope"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found but got %v", err)
+	}
+}
+
+func TestGetRepositoryByNameOldServer(t *testing.T) {
+	var requests []string
+	rm, mock := newTestRM(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path[1:])
+		w.Header().Set("Server", "Nexus/3.19.1-01 (OSS)")
+		repositoriesTestFunc(t, w, r)
+	})
+	defer mock.Close()
+
+	repo, err := GetRepositoryByName(rm, "repo-npm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(repo, listed(dummyRepos[3])) {
+		t.Errorf("Unexpected repository %+v", repo)
+	}
+	if requests[len(requests)-1] != restRepositories {
+		t.Errorf("Expected the repositories to be listed but got %v", requests)
+	}
+}
+
+func TestParseRepositoryFormat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  RepositoryFormat
+	}{
+		{"maven2", Maven},
+		{"maven", Maven},
+		{"NPM", Npm},
+		{"go", Golang},
+		{"gitlfs", GitLfs},
+		{"helm", Helm},
+	}
+
+	for _, test := range tests {
+		got, err := ParseRepositoryFormat(test.input)
+		if err != nil || got != test.want {
+			t.Errorf("%q: expected %q but got %q, %v", test.input, test.want, got, err)
+		}
+	}
+
+	if _, err := ParseRepositoryFormat("cobol"); err == nil {
+		t.Error("Expected an error parsing an unknown format")
+	}
+
+	for _, s := range []string{"hosted", "Proxy", "group"} {
+		if _, err := ParseRepositoryType(s); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := ParseRepositoryType("virtual"); err == nil {
+		t.Error("Expected an error parsing an unknown type")
+	}
+}
+
+func TestCreateHostedRepository(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	err := CreateHostedRepository(rm, Maven, RepositoryConfig{Name: "testname"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := GetRepositoryConfig(rm, Maven, RepositoryHosted, "testname")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	online := true
+	want := RepositoryConfig{
+		Name:    "testname",
+		Online:  &online,
+		Storage: StorageAttributes{BlobStoreName: DefaultBlobStore, WritePolicy: WritePolicyAllowOnce},
+		Maven:   &MavenAttributes{VersionPolicy: VersionPolicyRelease, LayoutPolicy: LayoutPolicyStrict},
+	}
+	if !reflect.DeepEqual(config, want) {
+		t.Errorf("Unexpected configuration\ngot:  %+v\nwant: %+v", config, want)
+	}
+
+	if err = CreateHostedRepository(rm, Maven, RepositoryConfig{Name: "testname"}); err == nil {
+		t.Error("Expected an error creating an existing repository")
+	}
+}
+
+func TestCreateProxyRepository(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	online := true
+	config := RepositoryConfig{
+		Name:    "testname",
+		Online:  &online,
+		Storage: StorageAttributes{BlobStoreName: "npm", StrictContentTypeValidation: true},
+		Cleanup: &CleanupAttributes{PolicyNames: []string{"weekly"}},
+		Proxy:   &ProxyAttributes{RemoteURL: "https://registry.npmjs.org", ContentMaxAge: -1, MetadataMaxAge: 60},
+		HTTPClient: &HTTPClientAttributes{
+			AutoBlock:      true,
+			Authentication: &HTTPClientAuthentication{Type: "username", Username: "user", Password: "pass"},
+		},
+		Npm: &NpmProxyAttributes{RemoveNonCataloged: true},
+	}
+	if err := CreateProxyRepository(rm, Npm, config); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetRepositoryConfig(rm, Npm, RepositoryProxy, "testname")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.NegativeCache = &NegativeCacheAttributes{Enabled: true, TimeToLive: 1440}
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("Unexpected configuration\ngot:  %+v\nwant: %+v", got, config)
+	}
+
+	repo, err := GetRepositoryByName(rm, "testname")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Format != Npm || repo.Type != RepositoryProxy || !reflect.DeepEqual(repo.Config(), config) {
+		t.Errorf("Unexpected repository %+v", repo)
+	}
+}
+
+func TestUpdateAndDeleteRepository(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	if err := CreateGroupRepository(rm, Docker, RepositoryConfig{Name: "docker-all", Group: &GroupAttributes{MemberNames: []string{"docker-hub"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := GetRepositoryConfig(rm, Docker, RepositoryGroup, "docker-all")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	port, offline := 8083, false
+	config.Online = &offline
+	config.Group.MemberNames = append(config.Group.MemberNames, "docker-hosted")
+	config.Docker.HTTPPort = &port
+	if err = UpdateRepository(rm, Docker, RepositoryGroup, config); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetRepositoryConfig(rm, Docker, RepositoryGroup, "docker-all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, config) {
+		t.Errorf("Unexpected configuration\ngot:  %+v\nwant: %+v", got, config)
+	}
+
+	if err = UpdateRepository(rm, Docker, RepositoryHosted, config); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found updating the wrong type of repository but got %v", err)
+	}
+
+	if err = DeleteRepository(rm, "docker-all"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = GetRepositoryByName(rm, "docker-all"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected the repository to be deleted but got %v", err)
+	}
+	if err = DeleteRepository(rm, "docker-all"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found deleting a missing repository but got %v", err)
+	}
+}
+
+func TestRepositoriesCacheInvalidated(t *testing.T) {
+	rm, server := newFakeRM(t, nexus.WithCache(nexus.NewCache(DefaultCachePolicy())))
+	defer server.Close()
+
+	repos, err := GetRepositories(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = CreateHostedRepository(rm, Maven, RepositoryConfig{Name: "cached"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := GetRepositories(rm); err != nil || len(got) != len(repos)+1 {
+		t.Errorf("Expected the created repository to be listed but got %d repositories: %v", len(got), err)
+	}
+
+	if _, err = GetRepositoryConfig(rm, Maven, RepositoryHosted, "cached"); err != nil {
+		t.Fatal(err)
+	}
+	if err = DeleteRepository(rm, "cached"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = GetRepositoryConfig(rm, Maven, RepositoryHosted, "cached"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected the deleted repository not to be served from the cache but got %v", err)
+	}
+}
+
+func TestCreateRepositoryUnknownFormat(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	if err := CreateHostedRepository(rm, Unknown, RepositoryConfig{Name: "testname"}); err == nil {
+		t.Error("Expected an error creating a repository of unknown format")
+	}
+	if len(server.Repositories()) != 0 {
+		t.Error("Expected no repository to be created")
+	}
+}
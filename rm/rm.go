@@ -2,6 +2,8 @@ package nexusrm
 
 import (
 	"bytes"
+	"fmt"
+	"time"
 
 	nexus "github.com/sonatype-nexus-community/gonexus"
 )
@@ -11,17 +13,74 @@ type RM interface {
 	nexus.Client
 }
 
+// endpoints are the templates of the endpoints used by the helpers, which name requests in the telemetry
+var endpoints = []string{
+	restAssets, restBlobStores, restBlobStore, restBlobStoreByType, restBlobStoreQuotaStatus,
+	restComponents, restContentSelectors, restContentSelector, restListAssetsByRepo,
+	restListComponentsByRepo, restMaintenanceDBCheck,
+	restReadOnly, restReadOnlyForceRelease, restReadOnlyFreeze, restReadOnlyRelease,
+	restRepositories, restRepository, restRepositoriesByType, restScript, restScriptRun,
+	restSearchAssets, restSearchComponents, restSecurityAnonymous, restSecurityPrivileges,
+	restSecurityPrivilege, restSecurityPrivilegesByType, restSecurityRealmsActive,
+	restSecurityRealmsAvailable, restSecurityRoles, restSecurityRole, restSecurityRolesBySource,
+	restSecurityUsers, restSecurityUser, restSecurityUserPassword, restSecurityUserSources,
+	restSecurityUsersBySource, restSecurityUsersByIDQuery, restStaging, restStagingDelete,
+	restStatusReadable, restStatusWritable, restSupportZip, restTagging,
+}
+
 type rmClient struct {
 	nexus.DefaultClient
+	capabilities *nexus.Capabilities
 }
 
-// New creates a new Repository Manager instance
-func New(host, username, password string) (RM, error) {
+// New creates a new Repository Manager instance, configured by the options
+func New(host, username, password string, opts ...nexus.Option) (RM, error) {
+	return newClient(host, username, password, opts), nil
+}
+
+func newClient(host, username, password string, opts []nexus.Option) *rmClient {
 	rm := new(rmClient)
-	rm.Host = host
-	rm.Username = username
-	rm.Password = password
-	return rm, nil
+	rm.ServerInfo = nexus.ServerInfo{Host: host, Username: username, Password: password}
+	for _, opt := range append([]nexus.Option{nexus.WithEndpoints(endpoints...)}, opts...) {
+		opt(&rm.DefaultClient)
+	}
+	rm.capabilities = nexus.NewCapabilities(rm, detectVersion)
+	return rm
+}
+
+// NewFromConfig creates a new Repository Manager instance with the authentication, certificates, timeout and retry policy of the configuration.
+// The options are applied over the configuration
+func NewFromConfig(cfg nexus.ServerConfig, opts ...nexus.Option) (RM, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("could not create Repository Manager client: %w", nexus.ErrNoHost)
+	}
+
+	cfgOpts, err := cfg.Options()
+	if err != nil {
+		return nil, fmt.Errorf("could not configure Repository Manager client: %w", err)
+	}
+
+	return newClient(cfg.Host, cfg.Auth.Username, cfg.Auth.Password, append(cfgOpts, opts...)), nil
+}
+
+// NewFromProfile creates a new Repository Manager instance from the RM section of the named profile of the configuration file at path.
+// See nexus.LoadProfile for how the profile is selected and overridden by the environment
+func NewFromProfile(path, profile string, opts ...nexus.Option) (RM, error) {
+	p, err := nexus.LoadProfile(path, profile)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConfig(p.RM, opts...)
+}
+
+// DefaultCachePolicy caches the repositories and their configuration, which are invalidated when repositories
+// are created, updated or deleted through the client
+func DefaultCachePolicy() nexus.CachePolicy {
+	return nexus.CachePolicy{
+		Classes: []nexus.CacheClass{
+			{Name: "repositories", Endpoints: []string{restRepositories, restRepositories + "/"}, TTL: 5 * time.Minute},
+		},
+	}
 }
 
 // QueryBuilder allows you to build a search query
@@ -52,6 +111,11 @@ func (b *QueryBuilder) Build() string {
 	return buf.String()
 }
 
+// Criteria allows specifying any criteria supported by the search API, e.g. "maven.extension"
+func (b *QueryBuilder) Criteria(name, v string) *QueryBuilder {
+	return b.addCriteria(name, v)
+}
+
 // Q allows specifying a keyword search
 func (b *QueryBuilder) Q(v string) *QueryBuilder {
 	return b.addCriteria("q", v)
@@ -1,10 +1,16 @@
 package nexusrm
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
+	"os"
 	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	"github.com/sonatype-nexus-community/gonexus/rm/nexusrmtest"
 )
 
 const dummyContinuationToken = "go_on..."
@@ -24,3 +30,171 @@ func newTestRM(t *testing.T, handler func(t *testing.T, w http.ResponseWriter, r
 
 	return
 }
+
+func newFakeRM(t *testing.T, opts ...nexus.Option) (RM, *nexusrmtest.Server) {
+	server := nexusrmtest.NewServer()
+	server.Username, server.Password = "dummy_user", "dummy_pass"
+
+	rm, err := New(server.URL, server.Username, server.Password, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rm, server
+}
+
+func mutatingHelpersRM() map[string]func(rm RM) error {
+	return map[string]func(rm RM) error{
+		"DeleteComponentByID": func(rm RM) error { return DeleteComponentByID(rm, "id") },
+		"DeleteAssetByID":     func(rm RM) error { return DeleteAssetByID(rm, "id") },
+		"ScriptUpload":        func(rm RM) error { return ScriptUpload(rm, Script{Name: "script"}) },
+		"ScriptUpdate":        func(rm RM) error { return ScriptUpdate(rm, Script{Name: "script"}) },
+		"ScriptDelete":        func(rm RM) error { return ScriptDelete(rm, "script") },
+		"StagingDelete":       func(rm RM) error { return StagingDelete(rm, *NewSearchQueryBuilder().Repository("repo")) },
+		"AssociateTag":        func(rm RM) error { return AssociateTag(rm, *NewSearchQueryBuilder().Repository("repo")) },
+		"DisassociateTag":     func(rm RM) error { return DisassociateTag(rm, *NewSearchQueryBuilder().Repository("repo")) },
+		"CreateRepository":    func(rm RM) error { return CreateRepository(rm, Raw, RepositoryHosted, RepositoryConfig{Name: "repo"}) },
+		"UpdateRepository":    func(rm RM) error { return UpdateRepository(rm, Raw, RepositoryHosted, RepositoryConfig{Name: "repo"}) },
+		"DeleteRepository":    func(rm RM) error { return DeleteRepository(rm, "repo") },
+		"CreateFileBlobStore": func(rm RM) error { return CreateFileBlobStore(rm, FileBlobStore{Name: "store"}) },
+		"UpdateFileBlobStore": func(rm RM) error { return UpdateFileBlobStore(rm, FileBlobStore{Name: "store"}) },
+		"DeleteBlobStore":     func(rm RM) error { return DeleteBlobStore(rm, "store") },
+		"CreateUser":          func(rm RM) error { return CreateUser(rm, User{ID: "user"}) },
+		"UpdateUser":          func(rm RM) error { return UpdateUser(rm, User{ID: "user"}) },
+		"ChangeUserPassword":  func(rm RM) error { return ChangeUserPassword(rm, "user", "password") },
+		"DeleteUser":          func(rm RM) error { return DeleteUser(rm, "user") },
+		"CreateRole":          func(rm RM) error { return CreateRole(rm, Role{ID: "role"}) },
+		"UpdateRole":          func(rm RM) error { return UpdateRole(rm, Role{ID: "role"}) },
+		"DeleteRole":          func(rm RM) error { return DeleteRole(rm, "role") },
+		"CreatePrivilege":     func(rm RM) error { return CreatePrivilege(rm, Privilege{Type: PrivilegeScript, Name: "priv"}) },
+		"UpdatePrivilege":     func(rm RM) error { return UpdatePrivilege(rm, Privilege{Type: PrivilegeScript, Name: "priv"}) },
+		"DeletePrivilege":     func(rm RM) error { return DeletePrivilege(rm, "priv") },
+		"SetActiveRealms":     func(rm RM) error { return SetActiveRealms(rm, []string{"realm"}) },
+		"SetAnonymousAccess":  func(rm RM) error { return SetAnonymousAccess(rm, AnonymousAccess{UserID: "anonymous"}) },
+		"CreateContentSelector": func(rm RM) error {
+			return CreateContentSelector(rm, ContentSelector{Name: "csel", Expression: `format == "raw"`})
+		},
+		"UpdateContentSelector": func(rm RM) error {
+			return UpdateContentSelector(rm, ContentSelector{Name: "csel", Expression: `format == "raw"`})
+		},
+		"DeleteContentSelector": func(rm RM) error { return DeleteContentSelector(rm, "csel") },
+		"ReadOnlyEnable": func(rm RM) error {
+			_, err := ReadOnlyEnable(rm)
+			return err
+		},
+		"ReadOnlyRelease": func(rm RM) error {
+			_, err := ReadOnlyRelease(rm, false)
+			return err
+		},
+	}
+}
+
+func TestMutatingHelpersNetworkFailure(t *testing.T) {
+	rm, mock := newTestRM(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {})
+	mock.Close()
+
+	for name, helper := range mutatingHelpersRM() {
+		if err := helper(rm); err == nil {
+			t.Errorf("%s: expected an error when the server is unreachable", name)
+		}
+	}
+}
+
+func TestMutatingHelpersStatuses(t *testing.T) {
+	tests := []struct {
+		status  int
+		success bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusCreated, true},
+		{http.StatusNoContent, true},
+		{http.StatusBadRequest, false},
+		{http.StatusForbidden, false},
+		{http.StatusInternalServerError, false},
+	}
+
+	for _, test := range tests {
+		rm, mock := newTestRM(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				fmt.Fprint(w, "{}")
+				return
+			}
+			w.WriteHeader(test.status)
+		})
+
+		for name, helper := range mutatingHelpersRM() {
+			if err := helper(rm); (err == nil) != test.success {
+				t.Errorf("%s with status %d: unexpected result: %v", name, test.status, err)
+			}
+		}
+
+		mock.Close()
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer mock.Close()
+
+	rm, err := NewFromConfig(nexus.ServerConfig{
+		Host: mock.URL,
+		Auth: nexus.AuthConfig{Method: nexus.AuthBearer, Token: "abc"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = rm.Get("test"); err != nil {
+		t.Errorf("Expected the configured authentication to be used: %v", err)
+	}
+
+	if _, err = NewFromConfig(nexus.ServerConfig{}); !errors.Is(err, nexus.ErrNoHost) {
+		t.Errorf("Expected ErrNoHost but got %v", err)
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer override" || r.Header.Get("User-Agent") != "tests" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer mock.Close()
+
+	rm, err := New(mock.URL, "user", "pass", nexus.WithAuthenticator(nexus.BearerAuth{Token: "override"}), nexus.WithUserAgent("tests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = rm.Get("test"); err != nil {
+		t.Errorf("Expected the options to be used: %v", err)
+	}
+
+	rm, err = NewFromConfig(nexus.ServerConfig{
+		Host: mock.URL,
+		Auth: nexus.AuthConfig{Method: nexus.AuthBearer, Token: "abc"},
+	}, nexus.WithAuthenticator(nexus.BearerAuth{Token: "override"}), nexus.WithUserAgent("tests"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = rm.Get("test"); err != nil {
+		t.Errorf("Expected the options to be applied over the configuration: %v", err)
+	}
+}
+
+func TestNewFromProfile(t *testing.T) {
+	os.Setenv("GONEXUS_RM_HOST", "http://localhost:1234")
+	defer os.Unsetenv("GONEXUS_RM_HOST")
+
+	rm, err := NewFromProfile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if host := rm.Info().Host; host != "http://localhost:1234" {
+		t.Errorf("Expected the host from the environment but got %q", host)
+	}
+}
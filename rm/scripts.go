@@ -2,9 +2,11 @@ package nexusrm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -24,13 +26,13 @@ type runResponse struct {
 	Result string `json:"result"`
 }
 
-// ScriptList lists all of the uploaded scripts in Repository Manager
-func ScriptList(rm RM) ([]Script, error) {
+// ScriptListContext lists all of the uploaded scripts in Repository Manager
+func ScriptListContext(ctx context.Context, rm RM) ([]Script, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("could not list scripts: %v", err)
+		return fmt.Errorf("could not list scripts: %w", err)
 	}
 
-	body, _, err := rm.Get(restScript)
+	body, _, err := nexus.GetContext(ctx, rm, restScript)
 	if err != nil {
 		return nil, doError(err)
 	}
@@ -43,16 +45,21 @@ func ScriptList(rm RM) ([]Script, error) {
 	return scripts, nil
 }
 
-// ScriptGet returns the named script
-func ScriptGet(rm RM, name string) (Script, error) {
+// ScriptList lists all of the uploaded scripts in Repository Manager
+func ScriptList(rm RM) ([]Script, error) {
+	return ScriptListContext(context.Background(), rm)
+}
+
+// ScriptGetContext returns the named script
+func ScriptGetContext(ctx context.Context, rm RM, name string) (Script, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("could not find script '%s': %v", name, err)
+		return fmt.Errorf("could not find script '%s': %w", name, err)
 	}
 
 	var script Script
 
 	endpoint := fmt.Sprintf("%s/%s", restScript, name)
-	body, _, err := rm.Get(endpoint)
+	body, _, err := nexus.GetContext(ctx, rm, endpoint)
 	if err != nil {
 		return script, doError(err)
 	}
@@ -64,10 +71,15 @@ func ScriptGet(rm RM, name string) (Script, error) {
 	return script, nil
 }
 
-// ScriptUpload uploads the given Script to Repository Manager
-func ScriptUpload(rm RM, script Script) error {
+// ScriptGet returns the named script
+func ScriptGet(rm RM, name string) (Script, error) {
+	return ScriptGetContext(context.Background(), rm, name)
+}
+
+// ScriptUploadContext uploads the given Script to Repository Manager
+func ScriptUploadContext(ctx context.Context, rm RM, script Script) error {
 	doError := func(err error) error {
-		return fmt.Errorf("could not upload script '%s': %v", script.Name, err)
+		return fmt.Errorf("could not upload script '%s': %w", script.Name, err)
 	}
 
 	json, err := json.Marshal(script)
@@ -75,18 +87,22 @@ func ScriptUpload(rm RM, script Script) error {
 		return doError(err)
 	}
 
-	_, resp, err := rm.Post(restScript, bytes.NewBuffer(json))
-	if err != nil && resp.StatusCode != http.StatusNoContent {
+	if _, _, err := nexus.PostContext(ctx, rm, restScript, bytes.NewBuffer(json)); err != nil {
 		return doError(err)
 	}
 
 	return nil
 }
 
-// ScriptUpdate update the contents of the given script
-func ScriptUpdate(rm RM, script Script) error {
+// ScriptUpload uploads the given Script to Repository Manager
+func ScriptUpload(rm RM, script Script) error {
+	return ScriptUploadContext(context.Background(), rm, script)
+}
+
+// ScriptUpdateContext update the contents of the given script
+func ScriptUpdateContext(ctx context.Context, rm RM, script Script) error {
 	doError := func(err error) error {
-		return fmt.Errorf("could not update script '%s': %v", script.Name, err)
+		return fmt.Errorf("could not update script '%s': %w", script.Name, err)
 	}
 
 	json, err := json.Marshal(script)
@@ -95,22 +111,26 @@ func ScriptUpdate(rm RM, script Script) error {
 	}
 
 	endpoint := fmt.Sprintf("%s/%s", restScript, script.Name)
-	_, resp, err := rm.Put(endpoint, bytes.NewBuffer(json))
-	if err != nil && resp.StatusCode != http.StatusNoContent {
+	if _, _, err := nexus.PutContext(ctx, rm, endpoint, bytes.NewBuffer(json)); err != nil {
 		return doError(err)
 	}
 
 	return nil
 }
 
-// ScriptRun executes the named Script
-func ScriptRun(rm RM, name string, arguments []byte) (string, error) {
+// ScriptUpdate update the contents of the given script
+func ScriptUpdate(rm RM, script Script) error {
+	return ScriptUpdateContext(context.Background(), rm, script)
+}
+
+// ScriptRunContext executes the named Script
+func ScriptRunContext(ctx context.Context, rm RM, name string, arguments []byte) (string, error) {
 	doError := func(err error) error {
-		return fmt.Errorf("could not run script '%s': %v", name, err)
+		return fmt.Errorf("could not run script '%s': %w", name, err)
 	}
 
 	endpoint := fmt.Sprintf(restScriptRun, name)
-	body, _, err := rm.Post(endpoint, bytes.NewBuffer(arguments)) // TODO: Better response handling
+	body, _, err := nexus.PostContext(ctx, rm, endpoint, bytes.NewBuffer(arguments)) // TODO: Better response handling
 	if err != nil {
 		return "", doError(err)
 	}
@@ -123,22 +143,36 @@ func ScriptRun(rm RM, name string, arguments []byte) (string, error) {
 	return resp.Result, nil
 }
 
-// ScriptRunOnce takes the given Script, uploads it, executes it, and deletes it
-func ScriptRunOnce(rm RM, script Script, arguments []byte) (string, error) {
-	if err := ScriptUpload(rm, script); err != nil {
+// ScriptRun executes the named Script
+func ScriptRun(rm RM, name string, arguments []byte) (string, error) {
+	return ScriptRunContext(context.Background(), rm, name, arguments)
+}
+
+// ScriptRunOnceContext takes the given Script, uploads it, executes it, and deletes it
+func ScriptRunOnceContext(ctx context.Context, rm RM, script Script, arguments []byte) (string, error) {
+	if err := ScriptUploadContext(ctx, rm, script); err != nil {
 		return "", err
 	}
-	defer ScriptDelete(rm, script.Name)
+	defer ScriptDeleteContext(ctx, rm, script.Name)
 
-	return ScriptRun(rm, script.Name, arguments)
+	return ScriptRunContext(ctx, rm, script.Name, arguments)
 }
 
-// ScriptDelete removes the name, uploaded script
-func ScriptDelete(rm RM, name string) error {
+// ScriptRunOnce takes the given Script, uploads it, executes it, and deletes it
+func ScriptRunOnce(rm RM, script Script, arguments []byte) (string, error) {
+	return ScriptRunOnceContext(context.Background(), rm, script, arguments)
+}
+
+// ScriptDeleteContext removes the name, uploaded script
+func ScriptDeleteContext(ctx context.Context, rm RM, name string) error {
 	endpoint := fmt.Sprintf("%s/%s", restScript, name)
-	resp, err := rm.Del(endpoint)
-	if err != nil && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("could not delete '%s': %v", name, err)
+	if _, err := nexus.DelContext(ctx, rm, endpoint); err != nil {
+		return fmt.Errorf("could not delete '%s': %w", name, err)
 	}
 	return nil
 }
+
+// ScriptDelete removes the name, uploaded script
+func ScriptDelete(rm RM, name string) error {
+	return ScriptDeleteContext(context.Background(), rm, name)
+}
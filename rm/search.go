@@ -2,9 +2,8 @@ package nexusrm
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
 
 	nexus "github.com/sonatype-nexus-community/gonexus"
 )
@@ -104,76 +103,62 @@ func NewSearchQueryBuilder() *SearchQueryBuilder {
 	return b
 }
 
-func search(rm RM, endpoint string, queryBuilder nexus.SearchQueryBuilder, responseHandler func([]byte) (string, error)) error {
-	continuation := ""
-	queryEndpoint := fmt.Sprintf("%s?%s", endpoint, queryBuilder.Build())
-
-	get := func() (body []byte, err error) {
-		url := queryEndpoint
-
-		if continuation != "" {
-			url += "&continuationToken=" + continuation
-		}
-
-		body, resp, err := rm.Get(url)
-		if err != nil || resp.StatusCode != http.StatusOK {
-			return
+func search(ctx context.Context, p *Paginator, pageHandler func(*Paginator) error) error {
+	for p.Next(ctx) {
+		if err := pageHandler(p); err != nil {
+			return fmt.Errorf("could not processes search items: %w", err)
 		}
-
-		return
 	}
 
-	for {
-		resp, err := get()
-		if err != nil {
-			return fmt.Errorf("could not find search items: %v", err)
-		}
-
-		continuation, err = responseHandler(resp)
-		if err != nil {
-			return fmt.Errorf("could not processes search items: %v", err)
-		}
-
-		if continuation == "" {
-			break
-		}
+	if err := p.Err(); err != nil {
+		return fmt.Errorf("could not find search items: %w", err)
 	}
 
 	return nil
 }
 
-// SearchComponents allows searching the indicated RM instance for specific components
-func SearchComponents(rm RM, query nexus.SearchQueryBuilder) ([]RepositoryItem, error) {
+// SearchComponentsContext allows searching the indicated RM instance for specific components
+func SearchComponentsContext(ctx context.Context, rm RM, query nexus.SearchQueryBuilder) ([]RepositoryItem, error) {
 	items := make([]RepositoryItem, 0)
 
-	err := search(rm, restSearchComponents, query, func(body []byte) (string, error) {
-		var resp searchComponentsResponse
-		if er := json.Unmarshal(body, &resp); er != nil {
-			return "", er
+	err := search(ctx, SearchComponentsPaginator(rm, query), func(p *Paginator) error {
+		var page []RepositoryItem
+		if er := p.Decode(&page); er != nil {
+			return er
 		}
 
-		items = append(items, resp.Items...)
+		items = append(items, page...)
 
-		return resp.ContinuationToken, nil
+		return nil
 	})
 
 	return items, err
 }
 
-// SearchAssets allows searching the indicated RM instance for specific assets
-func SearchAssets(rm RM, query nexus.SearchQueryBuilder) ([]RepositoryItemAsset, error) {
+// SearchComponents allows searching the indicated RM instance for specific components
+func SearchComponents(rm RM, query nexus.SearchQueryBuilder) ([]RepositoryItem, error) {
+	return SearchComponentsContext(context.Background(), rm, query)
+}
+
+// SearchAssetsContext allows searching the indicated RM instance for specific assets
+func SearchAssetsContext(ctx context.Context, rm RM, query nexus.SearchQueryBuilder) ([]RepositoryItemAsset, error) {
 	items := make([]RepositoryItemAsset, 0)
 
-	err := search(rm, restSearchAssets, query, func(body []byte) (string, error) {
-		var resp searchAssetsResponse
-		if er := json.Unmarshal(body, &resp); er != nil {
-			return "", er
+	err := search(ctx, SearchAssetsPaginator(rm, query), func(p *Paginator) error {
+		var page []RepositoryItemAsset
+		if er := p.Decode(&page); er != nil {
+			return er
 		}
 
-		items = append(items, resp.Items...)
+		items = append(items, page...)
 
-		return resp.ContinuationToken, nil
+		return nil
 	})
 
 	return items, err
 }
+
+// SearchAssets allows searching the indicated RM instance for specific assets
+func SearchAssets(rm RM, query nexus.SearchQueryBuilder) ([]RepositoryItemAsset, error) {
+	return SearchAssetsContext(context.Background(), rm, query)
+}
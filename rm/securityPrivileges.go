@@ -0,0 +1,200 @@
+package nexusrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const (
+	restSecurityPrivileges       = "service/rest/v1/security/privileges"
+	restSecurityPrivilege        = "service/rest/v1/security/privileges/%s"
+	restSecurityPrivilegesByType = "service/rest/v1/security/privileges/%s/%s"
+)
+
+// PrivilegeType is the type of a privilege, which decides the attributes it has
+type PrivilegeType string
+
+// Types of privileges
+const (
+	// PrivilegeApplication privileges grant actions on a domain of RM, such as users or blobstores
+	PrivilegeApplication PrivilegeType = "application"
+	// PrivilegeWildcard privileges grant the permissions which match a pattern, such as nexus:*
+	PrivilegeWildcard PrivilegeType = "wildcard"
+	// PrivilegeRepositoryView privileges grant actions on the content of repositories
+	PrivilegeRepositoryView PrivilegeType = "repository-view"
+	// PrivilegeRepositoryAdmin privileges grant actions on the configuration of repositories
+	PrivilegeRepositoryAdmin PrivilegeType = "repository-admin"
+	// PrivilegeRepositoryContentSelector privileges grant actions on the content of repositories matched by a content selector
+	PrivilegeRepositoryContentSelector PrivilegeType = "repository-content-selector"
+	// PrivilegeScript privileges grant actions on a script
+	PrivilegeScript PrivilegeType = "script"
+)
+
+// Actions of privileges. Not every type of privilege accepts every action
+const (
+	ActionAll          = "ALL"
+	ActionRead         = "READ"
+	ActionBrowse       = "BROWSE"
+	ActionAdd          = "ADD"
+	ActionEdit         = "EDIT"
+	ActionDelete       = "DELETE"
+	ActionCreate       = "CREATE"
+	ActionUpdate       = "UPDATE"
+	ActionRun          = "RUN"
+	ActionAssociate    = "ASSOCIATE"
+	ActionDisassociate = "DISASSOCIATE"
+)
+
+// Privilege grants actions on part of RM. Only the attributes of its type are used
+type Privilege struct {
+	Type        PrivilegeType `json:"type"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	// ReadOnly privileges are built into RM and cannot be changed
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// Actions apply to every type but wildcard
+	Actions []string `json:"actions,omitempty"`
+
+	// Format and Repository apply to the repository types, and * matches every format or repository
+	Format     string `json:"format,omitempty"`
+	Repository string `json:"repository,omitempty"`
+
+	// ContentSelector is the name of the content selector of repository-content-selector privileges
+	ContentSelector string `json:"contentSelector,omitempty"`
+	// ScriptName is the script of script privileges
+	ScriptName string `json:"scriptName,omitempty"`
+	// Pattern is the permission pattern of wildcard privileges
+	Pattern string `json:"pattern,omitempty"`
+	// Domain is the domain of application privileges
+	Domain string `json:"domain,omitempty"`
+}
+
+// privilegeRequest leaves out of a privilege what RM does not accept when it is created or updated
+type privilegeRequest struct {
+	*Privilege
+	Type     string `json:"type,omitempty"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+}
+
+// GetPrivilegesContext returns every privilege of RM
+func GetPrivilegesContext(ctx context.Context, rm RM) ([]Privilege, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get privileges: %w", err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, restSecurityPrivileges)
+	if err != nil {
+		return nil, doError(err)
+	}
+
+	privileges := make([]Privilege, 0)
+	if err := json.Unmarshal(body, &privileges); err != nil {
+		return nil, doError(err)
+	}
+
+	return privileges, nil
+}
+
+// GetPrivileges returns every privilege of RM
+func GetPrivileges(rm RM) ([]Privilege, error) {
+	return GetPrivilegesContext(context.Background(), rm)
+}
+
+// GetPrivilegeByNameContext returns the named privilege
+func GetPrivilegeByNameContext(ctx context.Context, rm RM, name string) (Privilege, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get privilege '%s': %w", name, err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf(restSecurityPrivilege, url.PathEscape(name)))
+	if err != nil {
+		return Privilege{}, doError(err)
+	}
+
+	var privilege Privilege
+	if err := json.Unmarshal(body, &privilege); err != nil {
+		return Privilege{}, doError(err)
+	}
+
+	return privilege, nil
+}
+
+// GetPrivilegeByName returns the named privilege
+func GetPrivilegeByName(rm RM, name string) (Privilege, error) {
+	return GetPrivilegeByNameContext(context.Background(), rm, name)
+}
+
+// CreatePrivilegeContext creates a privilege of the type it indicates
+func CreatePrivilegeContext(ctx context.Context, rm RM, privilege Privilege) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not create privilege '%s': %w", privilege.Name, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	buf, err := json.Marshal(privilegeRequest{Privilege: &privilege})
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PostContext(ctx, rm, fmt.Sprintf(restSecurityPrivilege, privilege.Type), bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// CreatePrivilege creates a privilege of the type it indicates
+func CreatePrivilege(rm RM, privilege Privilege) error {
+	return CreatePrivilegeContext(context.Background(), rm, privilege)
+}
+
+// UpdatePrivilegeContext replaces the description and attributes of the named privilege. Its type cannot change
+func UpdatePrivilegeContext(ctx context.Context, rm RM, privilege Privilege) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not update privilege '%s': %w", privilege.Name, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	buf, err := json.Marshal(privilegeRequest{Privilege: &privilege})
+	if err != nil {
+		return doError(err)
+	}
+
+	endpoint := fmt.Sprintf(restSecurityPrivilegesByType, privilege.Type, url.PathEscape(privilege.Name))
+	if _, _, err := nexus.PutContext(ctx, rm, endpoint, bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// UpdatePrivilege replaces the description and attributes of the named privilege. Its type cannot change
+func UpdatePrivilege(rm RM, privilege Privilege) error {
+	return UpdatePrivilegeContext(context.Background(), rm, privilege)
+}
+
+// DeletePrivilegeContext deletes the named privilege
+func DeletePrivilegeContext(ctx context.Context, rm RM, name string) error {
+	if _, err := nexus.DelContext(ctx, rm, fmt.Sprintf(restSecurityPrivilege, url.PathEscape(name))); err != nil {
+		return fmt.Errorf("could not delete privilege '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+// DeletePrivilege deletes the named privilege
+func DeletePrivilege(rm RM, name string) error {
+	return DeletePrivilegeContext(context.Background(), rm, name)
+}
@@ -0,0 +1,88 @@
+package nexusrm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	"github.com/sonatype-nexus-community/gonexus/rm/nexusrmtest"
+)
+
+func TestPrivileges(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	privileges := []Privilege{
+		{Type: PrivilegeApplication, Name: "app", Domain: "users", Actions: []string{ActionRead}},
+		{Type: PrivilegeWildcard, Name: "wild", Pattern: "nexus:repository-view:*"},
+		{Type: PrivilegeRepositoryView, Name: "view", Format: "maven2", Repository: "*", Actions: []string{ActionBrowse, ActionRead}},
+		{Type: PrivilegeRepositoryAdmin, Name: "admin", Format: "npm", Repository: "npm-hosted", Actions: []string{ActionAll}},
+		{Type: PrivilegeRepositoryContentSelector, Name: "csel", Format: "*", Repository: "*", ContentSelector: "acme", Actions: []string{ActionRead}},
+		{Type: PrivilegeScript, Name: "script", ScriptName: "cleanup", Actions: []string{ActionRun}},
+	}
+	for _, p := range privileges {
+		if err := CreatePrivilege(rm, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := CreatePrivilege(rm, privileges[0]); err == nil {
+		t.Error("Expected an error creating an existing privilege")
+	}
+	if err := CreatePrivilege(rm, Privilege{Type: PrivilegeScript, Name: "noscript", Actions: []string{ActionRun}}); err == nil {
+		t.Error("Expected an error creating a script privilege without a script")
+	}
+
+	got, err := GetPrivileges(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, privileges) {
+		t.Errorf("Unexpected privileges\ngot:  %+v\nwant: %+v", got, privileges)
+	}
+
+	view := privileges[2]
+	view.Description = "Browse all maven repositories"
+	view.Actions = []string{ActionBrowse}
+	if err := UpdatePrivilege(rm, view); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := GetPrivilegeByName(rm, "view"); !reflect.DeepEqual(p, view) {
+		t.Errorf("Unexpected privilege\ngot:  %+v\nwant: %+v", p, view)
+	}
+
+	view.Type = PrivilegeRepositoryAdmin
+	if err := UpdatePrivilege(rm, view); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found changing the type of a privilege but got %v", err)
+	}
+
+	if err := DeletePrivilege(rm, "view"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetPrivilegeByName(rm, "view"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected the privilege to be deleted but got %v", err)
+	}
+}
+
+func TestReadOnlyPrivilege(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	server.AddPrivilege(nexusrmtest.Privilege{Type: "wildcard", Name: "nx-all", Pattern: "nexus:*", ReadOnly: true})
+
+	privilege, err := GetPrivilegeByName(rm, "nx-all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !privilege.ReadOnly {
+		t.Errorf("Expected a read-only privilege but got %+v", privilege)
+	}
+
+	if err := UpdatePrivilege(rm, privilege); err == nil {
+		t.Error("Expected an error updating a read-only privilege")
+	}
+	if err := DeletePrivilege(rm, "nx-all"); err == nil {
+		t.Error("Expected an error deleting a read-only privilege")
+	}
+}
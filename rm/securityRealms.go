@@ -0,0 +1,159 @@
+package nexusrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const (
+	restSecurityRealmsAvailable = "service/rest/v1/security/realms/available"
+	restSecurityRealmsActive    = "service/rest/v1/security/realms/active"
+	restSecurityAnonymous       = "service/rest/v1/security/anonymous"
+)
+
+// Realm is a security realm which can authenticate or authorize users
+type Realm struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AnonymousAccess is whether, and as which user, RM serves the requests made without credentials
+type AnonymousAccess struct {
+	Enabled   bool   `json:"enabled"`
+	UserID    string `json:"userId"`
+	RealmName string `json:"realmName"`
+}
+
+// GetAvailableRealmsContext returns the realms which can be activated
+func GetAvailableRealmsContext(ctx context.Context, rm RM) ([]Realm, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get available realms: %w", err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, restSecurityRealmsAvailable)
+	if err != nil {
+		return nil, doError(err)
+	}
+
+	realms := make([]Realm, 0)
+	if err := json.Unmarshal(body, &realms); err != nil {
+		return nil, doError(err)
+	}
+
+	return realms, nil
+}
+
+// GetAvailableRealms returns the realms which can be activated
+func GetAvailableRealms(rm RM) ([]Realm, error) {
+	return GetAvailableRealmsContext(context.Background(), rm)
+}
+
+// GetActiveRealmsContext returns the IDs of the active realms, in the order they are tried
+func GetActiveRealmsContext(ctx context.Context, rm RM) ([]string, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get active realms: %w", err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, restSecurityRealmsActive)
+	if err != nil {
+		return nil, doError(err)
+	}
+
+	realms := make([]string, 0)
+	if err := json.Unmarshal(body, &realms); err != nil {
+		return nil, doError(err)
+	}
+
+	return realms, nil
+}
+
+// GetActiveRealms returns the IDs of the active realms, in the order they are tried
+func GetActiveRealms(rm RM) ([]string, error) {
+	return GetActiveRealmsContext(context.Background(), rm)
+}
+
+// SetActiveRealmsContext activates the realms with the given IDs, in the order they are to be tried, and deactivates the others
+func SetActiveRealmsContext(ctx context.Context, rm RM, ids []string) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not set active realms: %w", err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	if ids == nil {
+		ids = []string{}
+	}
+
+	buf, err := json.Marshal(ids)
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PutContext(ctx, rm, restSecurityRealmsActive, bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// SetActiveRealms activates the realms with the given IDs, in the order they are to be tried, and deactivates the others
+func SetActiveRealms(rm RM, ids []string) error {
+	return SetActiveRealmsContext(context.Background(), rm, ids)
+}
+
+// GetAnonymousAccessContext returns the anonymous access settings
+func GetAnonymousAccessContext(ctx context.Context, rm RM) (AnonymousAccess, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get anonymous access: %w", err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, restSecurityAnonymous)
+	if err != nil {
+		return AnonymousAccess{}, doError(err)
+	}
+
+	var access AnonymousAccess
+	if err := json.Unmarshal(body, &access); err != nil {
+		return AnonymousAccess{}, doError(err)
+	}
+
+	return access, nil
+}
+
+// GetAnonymousAccess returns the anonymous access settings
+func GetAnonymousAccess(rm RM) (AnonymousAccess, error) {
+	return GetAnonymousAccessContext(context.Background(), rm)
+}
+
+// SetAnonymousAccessContext changes the anonymous access settings
+func SetAnonymousAccessContext(ctx context.Context, rm RM, access AnonymousAccess) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not set anonymous access: %w", err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	buf, err := json.Marshal(access)
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PutContext(ctx, rm, restSecurityAnonymous, bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// SetAnonymousAccess changes the anonymous access settings
+func SetAnonymousAccess(rm RM, access AnonymousAccess) error {
+	return SetAnonymousAccessContext(context.Background(), rm, access)
+}
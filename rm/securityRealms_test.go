@@ -0,0 +1,60 @@
+package nexusrm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRealms(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	available, err := GetAvailableRealms(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(available) == 0 {
+		t.Fatal("Expected available realms")
+	}
+
+	active, err := GetActiveRealms(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"NexusAuthenticatingRealm", "NexusAuthorizingRealm"}; !reflect.DeepEqual(active, want) {
+		t.Errorf("Unexpected active realms\ngot:  %v\nwant: %v", active, want)
+	}
+
+	ordered := []string{"DockerToken", "NexusAuthenticatingRealm", "NexusAuthorizingRealm"}
+	if err := SetActiveRealms(rm, ordered); err != nil {
+		t.Fatal(err)
+	}
+	if active, _ = GetActiveRealms(rm); !reflect.DeepEqual(active, ordered) {
+		t.Errorf("Unexpected active realms\ngot:  %v\nwant: %v", active, ordered)
+	}
+
+	if err := SetActiveRealms(rm, []string{"nope"}); err == nil {
+		t.Error("Expected an error activating an unknown realm")
+	}
+}
+
+func TestAnonymousAccess(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	access, err := GetAnonymousAccess(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if access.Enabled || access.UserID != "anonymous" {
+		t.Errorf("Unexpected anonymous access %+v", access)
+	}
+
+	access.Enabled = true
+	if err := SetAnonymousAccess(rm, access); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetAnonymousAccess(rm); !reflect.DeepEqual(got, access) {
+		t.Errorf("Unexpected anonymous access\ngot:  %+v\nwant: %+v", got, access)
+	}
+}
@@ -0,0 +1,206 @@
+package nexusrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const (
+	restSecurityRoles         = "service/rest/v1/security/roles"
+	restSecurityRole          = "service/rest/v1/security/roles/%s"
+	restSecurityRolesBySource = "service/rest/v1/security/roles?source=%s"
+)
+
+// Role groups privileges, and other roles, so that they can be given to users together
+type Role struct {
+	ID          string `json:"id"`
+	Source      string `json:"source,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Privileges are the names of the privileges of the role
+	Privileges []string `json:"privileges"`
+	// Roles are the IDs of the roles whose privileges the role includes
+	Roles []string `json:"roles"`
+}
+
+// GetRolesContext returns the roles of the indicated source, or all roles if none is given
+func GetRolesContext(ctx context.Context, rm RM, source string) ([]Role, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get roles: %w", err)
+	}
+
+	endpoint := restSecurityRoles
+	if source != "" {
+		endpoint = fmt.Sprintf(restSecurityRolesBySource, url.QueryEscape(source))
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, endpoint)
+	if err != nil {
+		return nil, doError(err)
+	}
+
+	roles := make([]Role, 0)
+	if err := json.Unmarshal(body, &roles); err != nil {
+		return nil, doError(err)
+	}
+
+	return roles, nil
+}
+
+// GetRoles returns the roles of the indicated source, or all roles if none is given
+func GetRoles(rm RM, source string) ([]Role, error) {
+	return GetRolesContext(context.Background(), rm, source)
+}
+
+// GetRoleByIDContext returns the role with the given ID
+func GetRoleByIDContext(ctx context.Context, rm RM, id string) (Role, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get role '%s': %w", id, err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf(restSecurityRole, url.PathEscape(id)))
+	if err != nil {
+		return Role{}, doError(err)
+	}
+
+	var role Role
+	if err := json.Unmarshal(body, &role); err != nil {
+		return Role{}, doError(err)
+	}
+
+	return role, nil
+}
+
+// GetRoleByID returns the role with the given ID
+func GetRoleByID(rm RM, id string) (Role, error) {
+	return GetRoleByIDContext(context.Background(), rm, id)
+}
+
+// CreateRoleContext creates a role of DefaultUserSource
+func CreateRoleContext(ctx context.Context, rm RM, role Role) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not create role '%s': %w", role.ID, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	role.Source = ""
+	buf, err := json.Marshal(role)
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PostContext(ctx, rm, restSecurityRoles, bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// CreateRole creates a role of DefaultUserSource
+func CreateRole(rm RM, role Role) error {
+	return CreateRoleContext(context.Background(), rm, role)
+}
+
+// UpdateRoleContext replaces the name, description, privileges and roles of the role
+func UpdateRoleContext(ctx context.Context, rm RM, role Role) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not update role '%s': %w", role.ID, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	role.Source = ""
+	buf, err := json.Marshal(role)
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PutContext(ctx, rm, fmt.Sprintf(restSecurityRole, url.PathEscape(role.ID)), bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// UpdateRole replaces the name, description, privileges and roles of the role
+func UpdateRole(rm RM, role Role) error {
+	return UpdateRoleContext(context.Background(), rm, role)
+}
+
+// DeleteRoleContext deletes the role with the given ID
+func DeleteRoleContext(ctx context.Context, rm RM, id string) error {
+	if _, err := nexus.DelContext(ctx, rm, fmt.Sprintf(restSecurityRole, url.PathEscape(id))); err != nil {
+		return fmt.Errorf("could not delete role '%s': %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteRole deletes the role with the given ID
+func DeleteRole(rm RM, id string) error {
+	return DeleteRoleContext(context.Background(), rm, id)
+}
+
+// GetRolePrivilegesContext returns the names of the privileges given by the role, including those of its nested roles, sorted
+func GetRolePrivilegesContext(ctx context.Context, rm RM, id string) ([]string, error) {
+	roles, err := GetRolesContext(ctx, rm, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not get privileges of role '%s': %w", id, err)
+	}
+
+	byID := make(map[string]Role, len(roles))
+	for _, r := range roles {
+		byID[r.ID] = r
+	}
+	if _, ok := byID[id]; !ok {
+		role, err := GetRoleByIDContext(ctx, rm, id)
+		if err != nil {
+			return nil, fmt.Errorf("could not get privileges of role '%s': %w", id, err)
+		}
+		byID[id] = role
+	}
+
+	// Roles are only visited once, which also stops at cycles
+	visited := make(map[string]bool)
+	privileges := make(map[string]bool)
+	var visit func(id string)
+	visit = func(id string) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+
+		role := byID[id]
+		for _, p := range role.Privileges {
+			privileges[p] = true
+		}
+		for _, nested := range role.Roles {
+			visit(nested)
+		}
+	}
+	visit(id)
+
+	names := make([]string, 0, len(privileges))
+	for p := range privileges {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// GetRolePrivileges returns the names of the privileges given by the role, including those of its nested roles, sorted
+func GetRolePrivileges(rm RM, id string) ([]string, error) {
+	return GetRolePrivilegesContext(context.Background(), rm, id)
+}
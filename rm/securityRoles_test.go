@@ -0,0 +1,81 @@
+package nexusrm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	"github.com/sonatype-nexus-community/gonexus/rm/nexusrmtest"
+)
+
+func TestRoles(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	server.AddPrivilege(nexusrmtest.Privilege{Type: "wildcard", Name: "nx-all", Pattern: "nexus:*", ReadOnly: true})
+
+	role := Role{ID: "admins", Name: "Admins", Privileges: []string{"nx-all"}, Roles: []string{}}
+	if err := CreateRole(rm, role); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateRole(rm, role); err == nil {
+		t.Error("Expected an error creating an existing role")
+	}
+	if err := CreateRole(rm, Role{ID: "bad", Name: "bad", Privileges: []string{"nope"}}); err == nil {
+		t.Error("Expected an error creating a role with a missing privilege")
+	}
+
+	role.Description = "Administrators"
+	if err := UpdateRole(rm, role); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetRoleByID(rm, "admins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := role
+	want.Source = DefaultUserSource
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected role\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	roles, err := GetRoles(rm, DefaultUserSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 {
+		t.Errorf("Expected 1 role but got %+v", roles)
+	}
+
+	if err := DeleteRole(rm, "admins"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetRoleByID(rm, "admins"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected the role to be deleted but got %v", err)
+	}
+}
+
+func TestGetRolePrivileges(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	server.AddRole(nexusrmtest.Role{ID: "base", Privileges: []string{"read", "browse"}, Roles: []string{"deploy"}})
+	server.AddRole(nexusrmtest.Role{ID: "deploy", Privileges: []string{"add", "read"}, Roles: []string{"base"}})
+	server.AddRole(nexusrmtest.Role{ID: "admin", Privileges: []string{"all"}, Roles: []string{"base"}})
+
+	privileges, err := GetRolePrivileges(rm, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"add", "all", "browse", "read"}
+	if !reflect.DeepEqual(privileges, want) {
+		t.Errorf("Unexpected privileges\ngot:  %v\nwant: %v", privileges, want)
+	}
+
+	if _, err := GetRolePrivileges(rm, "nope"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found getting the privileges of a missing role but got %v", err)
+	}
+}
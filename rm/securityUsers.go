@@ -0,0 +1,253 @@
+package nexusrm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
+
+const (
+	restSecurityUsers          = "service/rest/v1/security/users"
+	restSecurityUser           = "service/rest/v1/security/users/%s"
+	restSecurityUserPassword   = "service/rest/v1/security/users/%s/change-password"
+	restSecurityUserSources    = "service/rest/v1/security/user-sources"
+	restSecurityUsersBySource  = "service/rest/v1/security/users?source=%s"
+	restSecurityUsersByIDQuery = "service/rest/v1/security/users?userId=%s&source=%s"
+)
+
+// DefaultUserSource is the source of the users and roles managed by RM itself, rather than by LDAP, Crowd...
+const DefaultUserSource = "default"
+
+// UserStatus is the status of a user account
+type UserStatus string
+
+// Statuses of user accounts
+const (
+	UserActive         UserStatus = "active"
+	UserLocked         UserStatus = "locked"
+	UserDisabled       UserStatus = "disabled"
+	UserChangePassword UserStatus = "changepassword"
+)
+
+// User encapsulates the information about a Repository Manager user
+type User struct {
+	ID           string     `json:"userId"`
+	FirstName    string     `json:"firstName"`
+	LastName     string     `json:"lastName"`
+	EmailAddress string     `json:"emailAddress"`
+	Source       string     `json:"source,omitempty"`
+	Status       UserStatus `json:"status"`
+	// ReadOnly users come from an external source and only their roles can be changed
+	ReadOnly bool     `json:"readOnly,omitempty"`
+	Roles    []string `json:"roles"`
+	// ExternalRoles are the roles given to the user by its source
+	ExternalRoles []string `json:"externalRoles,omitempty"`
+
+	// Password is only sent when the user is created, see ChangeUserPassword
+	Password string `json:"password,omitempty"`
+}
+
+// UserSource is a source of users, such as the users of RM itself or an LDAP server
+type UserSource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// GetUsersContext returns the users of the indicated source, or of DefaultUserSource if none is given
+func GetUsersContext(ctx context.Context, rm RM, source string) ([]User, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get users: %w", err)
+	}
+
+	if source == "" {
+		source = DefaultUserSource
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf(restSecurityUsersBySource, url.QueryEscape(source)))
+	if err != nil {
+		return nil, doError(err)
+	}
+
+	users := make([]User, 0)
+	if err := json.Unmarshal(body, &users); err != nil {
+		return nil, doError(err)
+	}
+
+	return users, nil
+}
+
+// GetUsers returns the users of the indicated source, or of DefaultUserSource if none is given
+func GetUsers(rm RM, source string) ([]User, error) {
+	return GetUsersContext(context.Background(), rm, source)
+}
+
+// GetUserByIDContext returns the user of the indicated source with the given ID
+func GetUserByIDContext(ctx context.Context, rm RM, id, source string) (User, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get user '%s': %w", id, err)
+	}
+
+	if source == "" {
+		source = DefaultUserSource
+	}
+
+	// RM matches the users whose ID starts with the one given
+	body, _, err := nexus.GetContext(ctx, rm, fmt.Sprintf(restSecurityUsersByIDQuery, url.QueryEscape(id), url.QueryEscape(source)))
+	if err != nil {
+		return User{}, doError(err)
+	}
+
+	var users []User
+	if err := json.Unmarshal(body, &users); err != nil {
+		return User{}, doError(err)
+	}
+
+	for _, user := range users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+
+	return User{}, doError(nexus.ErrNotFound)
+}
+
+// GetUserByID returns the user of the indicated source with the given ID
+func GetUserByID(rm RM, id, source string) (User, error) {
+	return GetUserByIDContext(context.Background(), rm, id, source)
+}
+
+// CreateUserContext creates a user of DefaultUserSource with the password of the user
+func CreateUserContext(ctx context.Context, rm RM, user User) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not create user '%s': %w", user.ID, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	if user.Status == "" {
+		user.Status = UserActive
+	}
+	user.Source = ""
+
+	buf, err := json.Marshal(user)
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PostContext(ctx, rm, restSecurityUsers, bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// CreateUser creates a user of DefaultUserSource with the password of the user
+func CreateUser(rm RM, user User) error {
+	return CreateUserContext(context.Background(), rm, user)
+}
+
+// UpdateUserContext replaces the details and roles of the user. The password is not changed, see ChangeUserPassword
+func UpdateUserContext(ctx context.Context, rm RM, user User) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not update user '%s': %w", user.ID, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	if user.Source == "" {
+		user.Source = DefaultUserSource
+	}
+	user.Password = ""
+
+	buf, err := json.Marshal(user)
+	if err != nil {
+		return doError(err)
+	}
+
+	if _, _, err := nexus.PutContext(ctx, rm, fmt.Sprintf(restSecurityUser, url.PathEscape(user.ID)), bytes.NewBuffer(buf)); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// UpdateUser replaces the details and roles of the user. The password is not changed, see ChangeUserPassword
+func UpdateUser(rm RM, user User) error {
+	return UpdateUserContext(context.Background(), rm, user)
+}
+
+// ChangeUserPasswordContext changes the password of the user with the given ID
+func ChangeUserPasswordContext(ctx context.Context, rm RM, id, password string) error {
+	doError := func(err error) error {
+		return fmt.Errorf("could not change password of user '%s': %w", id, err)
+	}
+
+	if err := Capabilities(rm).Require(ctx, FeatureSecurityManagement); err != nil {
+		return doError(err)
+	}
+
+	req, err := nexus.NewRequestWithContext(ctx, rm, http.MethodPut, fmt.Sprintf(restSecurityUserPassword, url.PathEscape(id)), strings.NewReader(password))
+	if err != nil {
+		return doError(err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	if _, _, err := rm.Do(req); err != nil {
+		return doError(err)
+	}
+
+	return nil
+}
+
+// ChangeUserPassword changes the password of the user with the given ID
+func ChangeUserPassword(rm RM, id, password string) error {
+	return ChangeUserPasswordContext(context.Background(), rm, id, password)
+}
+
+// DeleteUserContext deletes the user with the given ID
+func DeleteUserContext(ctx context.Context, rm RM, id string) error {
+	if _, err := nexus.DelContext(ctx, rm, fmt.Sprintf(restSecurityUser, url.PathEscape(id))); err != nil {
+		return fmt.Errorf("could not delete user '%s': %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteUser deletes the user with the given ID
+func DeleteUser(rm RM, id string) error {
+	return DeleteUserContext(context.Background(), rm, id)
+}
+
+// GetUserSourcesContext returns the sources of users configured in RM
+func GetUserSourcesContext(ctx context.Context, rm RM) ([]UserSource, error) {
+	doError := func(err error) error {
+		return fmt.Errorf("could not get user sources: %w", err)
+	}
+
+	body, _, err := nexus.GetContext(ctx, rm, restSecurityUserSources)
+	if err != nil {
+		return nil, doError(err)
+	}
+
+	sources := make([]UserSource, 0)
+	if err := json.Unmarshal(body, &sources); err != nil {
+		return nil, doError(err)
+	}
+
+	return sources, nil
+}
+
+// GetUserSources returns the sources of users configured in RM
+func GetUserSources(rm RM) ([]UserSource, error) {
+	return GetUserSourcesContext(context.Background(), rm)
+}
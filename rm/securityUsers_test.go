@@ -0,0 +1,102 @@
+package nexusrm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+	"github.com/sonatype-nexus-community/gonexus/rm/nexusrmtest"
+)
+
+func TestUsers(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	server.AddRole(nexusrmtest.Role{ID: "nx-admin", Name: "nx-admin"})
+	server.AddUser(nexusrmtest.User{ID: "ldapuser", Source: "LDAP", ReadOnly: true})
+
+	user := User{ID: "jdoe", FirstName: "John", LastName: "Doe", EmailAddress: "jdoe@example.com", Password: "secret", Roles: []string{"nx-admin"}}
+	if err := CreateUser(rm, user); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateUser(rm, user); err == nil {
+		t.Error("Expected an error creating an existing user")
+	}
+	if err := CreateUser(rm, User{ID: "jdoe2", Password: "secret", Roles: []string{"nope"}}); err == nil {
+		t.Error("Expected an error creating a user with a missing role")
+	}
+
+	user.EmailAddress = "john.doe@example.com"
+	user.Status = UserLocked
+	if err := UpdateUser(rm, user); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetUserByID(rm, "jdoe", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := user
+	want.Source, want.Password, want.ExternalRoles = DefaultUserSource, "", []string{}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected user\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	if err := ChangeUserPassword(rm, "jdoe", "changed"); err != nil {
+		t.Fatal(err)
+	}
+	if users := server.Users(); users[1].Password != "changed" {
+		t.Errorf("Expected the password to be changed but got %q", users[1].Password)
+	}
+
+	users, err := GetUsers(rm, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].ID != "jdoe" {
+		t.Errorf("Expected only the default users but got %+v", users)
+	}
+
+	users, err = GetUsers(rm, "LDAP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || !users[0].ReadOnly {
+		t.Errorf("Expected the read-only LDAP user but got %+v", users)
+	}
+
+	if err := DeleteUser(rm, "jdoe"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetUserByID(rm, "jdoe", ""); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected the user to be deleted but got %v", err)
+	}
+	if err := DeleteUser(rm, "jdoe"); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found deleting a missing user but got %v", err)
+	}
+}
+
+func TestGetUserByIDExactMatch(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	server.AddUser(nexusrmtest.User{ID: "admin2"})
+
+	if _, err := GetUserByID(rm, "admin", ""); !errors.Is(err, nexus.ErrNotFound) {
+		t.Errorf("Expected not found when only the prefix matches but got %v", err)
+	}
+}
+
+func TestGetUserSources(t *testing.T) {
+	rm, server := newFakeRM(t)
+	defer server.Close()
+
+	sources, err := GetUserSources(rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 1 || sources[0].ID != DefaultUserSource {
+		t.Errorf("Unexpected user sources %+v", sources)
+	}
+}
@@ -1,6 +1,11 @@
 package nexusrm
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
+)
 
 // service/rest/v1/staging/move/{repository}
 const (
@@ -38,19 +43,37 @@ type componentsDeleted struct {
 	Version    string `json:"version"`
 }
 
-// StagingMove promotes components which match a set of criteria
-func StagingMove(rm RM, query QueryBuilder) error {
+// StagingMoveContext promotes components which match a set of criteria
+func StagingMoveContext(ctx context.Context, rm RM, query QueryBuilder) error {
+	if err := Capabilities(rm).Require(ctx, FeatureStaging); err != nil {
+		return err
+	}
+
 	endpoint := fmt.Sprintf("%s?%s", restStaging, query.Build())
 
 	// TODO: handle response
-	_, _, err := rm.Post(endpoint, nil)
+	_, _, err := nexus.PostContext(ctx, rm, endpoint, nil)
 	return err
 }
 
-// StagingDelete removes components which have been staged
-func StagingDelete(rm RM, query QueryBuilder) error {
-	endpoint := fmt.Sprintf("%s?%s", restStaging, query.Build())
+// StagingMove promotes components which match a set of criteria
+func StagingMove(rm RM, query QueryBuilder) error {
+	return StagingMoveContext(context.Background(), rm, query)
+}
+
+// StagingDeleteContext removes components which have been staged
+func StagingDeleteContext(ctx context.Context, rm RM, query QueryBuilder) error {
+	if err := Capabilities(rm).Require(ctx, FeatureStaging); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s?%s", restStagingDelete, query.Build())
 
-	_, err := rm.Del(endpoint)
+	_, err := nexus.DelContext(ctx, rm, endpoint)
 	return err
 }
+
+// StagingDelete removes components which have been staged
+func StagingDelete(rm RM, query QueryBuilder) error {
+	return StagingDeleteContext(context.Background(), rm, query)
+}
@@ -1,7 +1,10 @@
 package nexusrm
 
 import (
+	"context"
 	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const (
@@ -9,14 +12,24 @@ const (
 	restStatusWritable = "service/rest/v1/status/writable"
 )
 
+// StatusReadableContext returns true if the RM instance can serve read requests
+func StatusReadableContext(ctx context.Context, rm RM) (_ bool) {
+	_, resp, err := nexus.GetContext(ctx, rm, restStatusReadable)
+	return err == nil && resp.StatusCode == http.StatusOK
+}
+
 // StatusReadable returns true if the RM instance can serve read requests
 func StatusReadable(rm RM) (_ bool) {
-	_, resp, err := rm.Get(restStatusReadable)
+	return StatusReadableContext(context.Background(), rm)
+}
+
+// StatusWritableContext returns true if the RM instance can serve read requests
+func StatusWritableContext(ctx context.Context, rm RM) (_ bool) {
+	_, resp, err := nexus.GetContext(ctx, rm, restStatusWritable)
 	return err == nil && resp.StatusCode == http.StatusOK
 }
 
 // StatusWritable returns true if the RM instance can serve read requests
 func StatusWritable(rm RM) (_ bool) {
-	_, resp, err := rm.Get(restStatusWritable)
-	return err == nil && resp.StatusCode == http.StatusOK
+	return StatusWritableContext(context.Background(), rm)
 }
@@ -2,10 +2,14 @@ package nexusrm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"mime"
 	"net/http"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restSupportZip = "service/rest/v1/support/supportzip"
@@ -41,25 +45,65 @@ func NewSupportZipOptions() (o SupportZipOptions) {
 	return
 }
 
+// GetSupportZipContext generates a support zip with the given options
+func GetSupportZipContext(ctx context.Context, rm RM, options SupportZipOptions) ([]byte, string, error) {
+	request, err := json.Marshal(options)
+	if err != nil {
+		return nil, "", fmt.Errorf("error retrieving support zip: %w", err)
+	}
+
+	body, resp, err := nexus.PostContext(ctx, rm, restSupportZip, bytes.NewBuffer(request))
+	if err != nil {
+		return nil, "", fmt.Errorf("error retrieving support zip: %w", err)
+	}
+
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition"))
+	if err != nil {
+		return nil, "", fmt.Errorf("error determining name of support zip: %w", err)
+	}
+
+	return body, params["filename"], nil
+}
+
 // GetSupportZip generates a support zip with the given options
 func GetSupportZip(rm RM, options SupportZipOptions) ([]byte, string, error) {
+	return GetSupportZipContext(context.Background(), rm, options)
+}
+
+// StreamSupportZipContext generates a support zip with the given options and streams it to the writer.
+// Returns the name of the support zip
+func StreamSupportZipContext(ctx context.Context, rm RM, options SupportZipOptions, w io.Writer, progress DownloadProgress) (string, error) {
 	request, err := json.Marshal(options)
 	if err != nil {
-		return nil, "", fmt.Errorf("error retrieving support zip: %v", err)
+		return "", fmt.Errorf("error retrieving support zip: %w", err)
 	}
 
-	body, resp, err := rm.Post(restSupportZip, bytes.NewBuffer(request))
+	req, err := nexus.NewRequestWithContext(ctx, rm, http.MethodPost, restSupportZip, bytes.NewBuffer(request))
 	if err != nil {
-		return nil, "", fmt.Errorf("error retrieving support zip: %v", err)
+		return "", fmt.Errorf("error retrieving support zip: %w", err)
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, "", fmt.Errorf("error retrieving support zip: %s", resp.Status)
+
+	body, resp, err := nexus.Stream(rm, req)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving support zip: %w", err)
 	}
+	defer body.Close()
 
-	_, params, err := mime.ParseMediaType(resp.Header["Content-Disposition"][0])
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition"))
 	if err != nil {
-		return nil, "", fmt.Errorf("error determining name of support zip: %v", err)
+		return "", fmt.Errorf("error determining name of support zip: %w", err)
 	}
 
-	return body, params["filename"], nil
+	pw := &progressWriter{w: w, total: resp.ContentLength, progress: progress}
+	if _, err := io.Copy(pw, body); err != nil {
+		return "", fmt.Errorf("error retrieving support zip: %w", err)
+	}
+
+	return params["filename"], nil
+}
+
+// StreamSupportZip generates a support zip with the given options and streams it to the writer.
+// Returns the name of the support zip
+func StreamSupportZip(rm RM, options SupportZipOptions, w io.Writer, progress DownloadProgress) (string, error) {
+	return StreamSupportZipContext(context.Background(), rm, options, w, progress)
 }
@@ -0,0 +1,68 @@
+package nexusrm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const dummySupportZip = "PK...dummy support zip"
+
+func supportTestRM(t *testing.T) (RM, func()) {
+	rm, mock := newTestRM(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path[1:] != restSupportZip {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var options SupportZipOptions
+		if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Disposition", `attachment; filename="support-20200101-000000-1.zip"`)
+		w.Header().Set("Content-Length", fmt.Sprint(len(dummySupportZip)))
+		fmt.Fprint(w, dummySupportZip)
+	})
+
+	return rm, mock.Close
+}
+
+func TestGetSupportZip(t *testing.T) {
+	rm, done := supportTestRM(t)
+	defer done()
+
+	zip, name, err := GetSupportZip(rm, NewSupportZipOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "support-20200101-000000-1.zip" || string(zip) != dummySupportZip {
+		t.Errorf("Did not receive expected support zip: %s %q", name, zip)
+	}
+}
+
+func TestStreamSupportZip(t *testing.T) {
+	rm, done := supportTestRM(t)
+	defer done()
+
+	var written, total int64
+	var buf bytes.Buffer
+	name, err := StreamSupportZip(rm, NewSupportZipOptions(), &buf, func(w, t int64) {
+		written, total = w, t
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "support-20200101-000000-1.zip" || buf.String() != dummySupportZip {
+		t.Errorf("Did not receive expected support zip: %s %q", name, buf.String())
+	}
+
+	if written != int64(len(dummySupportZip)) || total != int64(len(dummySupportZip)) {
+		t.Errorf("Unexpected progress: %d/%d", written, total)
+	}
+}
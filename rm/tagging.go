@@ -2,8 +2,11 @@ package nexusrm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+
+	nexus "github.com/sonatype-nexus-community/gonexus"
 )
 
 const restTagging = "service/rest/v1/tags"
@@ -35,100 +38,124 @@ type componentsAssociated struct {
 	Version string `json:"version"`
 }
 
-// TagsList returns a list of tags in the given RM instance
-func TagsList(rm RM) ([]Tag, error) {
-	continuation := ""
-	tags := make([]Tag, 0)
-
-	get := func() error {
-		url := restTagging
-
-		if continuation != "" {
-			url += "&continuationToken=" + continuation
-		}
+// TagsListContext returns a list of tags in the given RM instance
+func TagsListContext(ctx context.Context, rm RM) ([]Tag, error) {
+	if err := Capabilities(rm).Require(ctx, FeatureTagging); err != nil {
+		return nil, err
+	}
 
-		body, _, err := rm.Get(url)
-		if err != nil {
-			return fmt.Errorf("could not get list of tags: %v", err)
-		}
+	tags := make([]Tag, 0)
 
-		var resp tagsResponse
-		err = json.Unmarshal(body, &resp)
-		if err != nil {
-			return fmt.Errorf("could not read tag list response: %v", err)
+	p := TagsPaginator(rm)
+	for p.Next(ctx) {
+		var page []Tag
+		if err := p.Decode(&page); err != nil {
+			return nil, fmt.Errorf("could not read tag list response: %w", err)
 		}
-
-		continuation = resp.ContinuationToken
-
-		return nil
+		tags = append(tags, page...)
 	}
 
-	for {
-		if err := get(); err != nil {
-			return nil, err
-		}
-
-		if continuation == "" {
-			break
-		}
+	if err := p.Err(); err != nil {
+		return nil, fmt.Errorf("could not get list of tags: %w", err)
 	}
 
 	return tags, nil
 }
 
-// AddTag adds a tag to the given instance
-func AddTag(rm RM, tagName string, attributes map[string]string) (Tag, error) {
+// TagsList returns a list of tags in the given RM instance
+func TagsList(rm RM) ([]Tag, error) {
+	return TagsListContext(context.Background(), rm)
+}
+
+// AddTagContext adds a tag to the given instance
+func AddTagContext(ctx context.Context, rm RM, tagName string, attributes map[string]string) (Tag, error) {
+	if err := Capabilities(rm).Require(ctx, FeatureTagging); err != nil {
+		return Tag{}, err
+	}
+
 	tag := Tag{Name: tagName}
 	//TODO: attributes
 
 	buf, err := json.Marshal(tag)
 	if err != nil {
-		return Tag{}, fmt.Errorf("could not marshal tag: %v", err)
+		return Tag{}, fmt.Errorf("could not marshal tag: %w", err)
 	}
 
-	body, _, err := rm.Post(restTagging, bytes.NewBuffer(buf))
+	body, _, err := nexus.PostContext(ctx, rm, restTagging, bytes.NewBuffer(buf))
 	if err != nil {
-		return Tag{}, fmt.Errorf("could not create tag %s: %v", tagName, err)
+		return Tag{}, fmt.Errorf("could not create tag %s: %w", tagName, err)
 	}
 
 	var createdTag Tag
 	if err = json.Unmarshal(body, &createdTag); err != nil {
-		return Tag{}, fmt.Errorf("could not read response: %v", err)
+		return Tag{}, fmt.Errorf("could not read response: %w", err)
 	}
 
 	return createdTag, nil
 }
 
-// GetTag retrieve the named tag
-func GetTag(rm RM, tagName string) (Tag, error) {
+// AddTag adds a tag to the given instance
+func AddTag(rm RM, tagName string, attributes map[string]string) (Tag, error) {
+	return AddTagContext(context.Background(), rm, tagName, attributes)
+}
+
+// GetTagContext retrieve the named tag
+func GetTagContext(ctx context.Context, rm RM, tagName string) (Tag, error) {
+	if err := Capabilities(rm).Require(ctx, FeatureTagging); err != nil {
+		return Tag{}, err
+	}
+
 	endpoint := fmt.Sprintf("%s/%s", restTagging, tagName)
 
-	body, _, err := rm.Get(endpoint)
+	body, _, err := nexus.GetContext(ctx, rm, endpoint)
 	if err != nil {
-		return Tag{}, fmt.Errorf("could not find tag %s: %v", tagName, err)
+		return Tag{}, fmt.Errorf("could not find tag %s: %w", tagName, err)
 	}
 
 	var tag Tag
 	if err = json.Unmarshal(body, &tag); err != nil {
-		return Tag{}, fmt.Errorf("could not read response: %v", err)
+		return Tag{}, fmt.Errorf("could not read response: %w", err)
 	}
 
 	return tag, nil
 }
 
-// AssociateTag associates a tag to any component which matches the search criteria
-func AssociateTag(rm RM, query QueryBuilder) error {
+// GetTag retrieve the named tag
+func GetTag(rm RM, tagName string) (Tag, error) {
+	return GetTagContext(context.Background(), rm, tagName)
+}
+
+// AssociateTagContext associates a tag to any component which matches the search criteria
+func AssociateTagContext(ctx context.Context, rm RM, query QueryBuilder) error {
+	if err := Capabilities(rm).Require(ctx, FeatureTagging); err != nil {
+		return err
+	}
+
 	endpoint := fmt.Sprintf("%s?%s", restTagging, query.Build())
 
 	// TODO: handle response
-	_, _, err := rm.Post(endpoint, nil)
+	_, _, err := nexus.PostContext(ctx, rm, endpoint, nil)
 	return err
 }
 
-// DisassociateTag associates a tag to any component which matches the search criteria
-func DisassociateTag(rm RM, query QueryBuilder) error {
+// AssociateTag associates a tag to any component which matches the search criteria
+func AssociateTag(rm RM, query QueryBuilder) error {
+	return AssociateTagContext(context.Background(), rm, query)
+}
+
+// DisassociateTagContext associates a tag to any component which matches the search criteria
+func DisassociateTagContext(ctx context.Context, rm RM, query QueryBuilder) error {
+	if err := Capabilities(rm).Require(ctx, FeatureTagging); err != nil {
+		return err
+	}
+
 	endpoint := fmt.Sprintf("%s?%s", restTagging, query.Build())
 
-	_, err := rm.Del(endpoint)
+	_, err := nexus.DelContext(ctx, rm, endpoint)
 	return err
 }
+
+// DisassociateTag associates a tag to any component which matches the search criteria
+func DisassociateTag(rm RM, query QueryBuilder) error {
+	return DisassociateTagContext(context.Background(), rm, query)
+}
@@ -104,7 +104,7 @@ func TestTagsList(t *testing.T) {
 		t.Error(err)
 	}
 
-	if len(tags) == len(dummyTags) {
+	if len(tags) != len(dummyTags) {
 		t.Errorf("received %d tags instead of the expected %d\n", len(tags), len(dummyTags))
 	}
 
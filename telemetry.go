@@ -0,0 +1,286 @@
+package nexus
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Names of the metrics recorded by DefaultClient
+const (
+	// MetricRequests counts the attempts of requests
+	MetricRequests = "nexus.client.requests"
+	// MetricErrors counts the attempts which failed, either with an error status or without a response
+	MetricErrors = "nexus.client.errors"
+	// MetricRetries counts the attempts which retried a failed one
+	MetricRetries = "nexus.client.retries"
+	// MetricDuration is a histogram of the duration of the attempts, in seconds
+	MetricDuration = "nexus.client.duration"
+)
+
+// Keys of the attributes of the spans and metrics recorded by DefaultClient
+const (
+	AttrMethod   = "http.method"
+	AttrEndpoint = "http.route"
+	AttrStatus   = "http.status_code"
+	AttrAttempt  = "nexus.attempt"
+)
+
+// Attribute describes a span or a measurement
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span is an operation being traced
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Telemetry receives the traces and metrics of the requests sent by a client. It can be implemented on top of
+// OpenTelemetry, Prometheus or any other library, so that the client does not depend on any of them
+type Telemetry interface {
+	// StartSpan starts a span. The returned context carries the span and is used for the request
+	StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+
+	// AddCounter adds delta to the named counter
+	AddCounter(name string, delta int64, attrs ...Attribute)
+
+	// RecordHistogram records a value in the named histogram
+	RecordHistogram(name string, value float64, attrs ...Attribute)
+}
+
+// instrument reports an attempt of a request to the Telemetry of the client
+func instrument(cfg settings, request *http.Request, attempt int, attemptFunc attemptFunc) ([]byte, *http.Response, error) {
+	if cfg.telemetry == nil {
+		return attemptFunc(cfg, request)
+	}
+
+	endpoint := endpointTemplate(relativePath(cfg.host, request.URL), cfg.endpoints)
+	ctx, span := cfg.telemetry.StartSpan(request.Context(), request.Method+" "+endpoint,
+		Attribute{AttrMethod, request.Method},
+		Attribute{AttrEndpoint, endpoint},
+		Attribute{AttrAttempt, attempt},
+	)
+
+	start := time.Now()
+	body, resp, err := attemptFunc(cfg, request.WithContext(ctx))
+	elapsed := time.Since(start)
+
+	var status int
+	if resp != nil {
+		status = resp.StatusCode
+	}
+
+	span.SetAttributes(Attribute{AttrStatus, status})
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+
+	attrs := []Attribute{{AttrMethod, request.Method}, {AttrEndpoint, endpoint}, {AttrStatus, status}}
+	cfg.telemetry.AddCounter(MetricRequests, 1, attrs...)
+	if err != nil {
+		cfg.telemetry.AddCounter(MetricErrors, 1, attrs...)
+	}
+	if attempt > 1 {
+		cfg.telemetry.AddCounter(MetricRetries, 1, attrs...)
+	}
+	cfg.telemetry.RecordHistogram(MetricDuration, elapsed.Seconds(), attrs...)
+
+	return body, resp, err
+}
+
+// relativePath returns the path of the URL relative to the host, without its query
+func relativePath(host string, u *url.URL) string {
+	path := strings.TrimPrefix(u.Path, "/")
+	if host, err := url.Parse(host); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimPrefix(strings.TrimSuffix(host.Path, "/")+"/", "/"))
+	}
+	return path
+}
+
+// endpointTemplate replaces the segments of the path which do not appear in any of the templates by {id}.
+// Without templates, the segments containing digits are replaced, except versions such as v2
+func endpointTemplate(path string, templates []string) string {
+	known := make(map[string]bool)
+	for _, t := range templates {
+		if i := strings.IndexByte(t, '?'); i >= 0 {
+			t = t[:i]
+		}
+		for _, segment := range strings.Split(t, "/") {
+			known[segment] = true
+		}
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if (len(templates) > 0 && !known[segment]) || (len(templates) == 0 && isParameter(segment)) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func isParameter(segment string) bool {
+	if len(segment) > 1 && segment[0] == 'v' && strings.Trim(segment[1:], "0123456789") == "" {
+		return false
+	}
+	return strings.ContainsAny(segment, "0123456789")
+}
+
+// RecordedSpan is a span captured by a Recorder
+type RecordedSpan struct {
+	Name       string
+	Attributes map[string]interface{}
+	Err        error
+	Start, End time.Time
+	Ended      bool
+}
+
+// Measurement is a counter increment or histogram value captured by a Recorder
+type Measurement struct {
+	Name       string
+	Value      float64
+	Attributes map[string]interface{}
+}
+
+// Recorder is a Telemetry which keeps everything it receives in memory, e.g. for tests
+type Recorder struct {
+	mu           sync.Mutex
+	spans        []*RecordedSpan
+	counters     []Measurement
+	measurements []Measurement
+}
+
+// NewRecorder creates an empty Recorder
+func NewRecorder() *Recorder {
+	return new(Recorder)
+}
+
+type recorderSpan struct {
+	recorder *Recorder
+	span     *RecordedSpan
+}
+
+func (s recorderSpan) SetAttributes(attrs ...Attribute) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	for _, a := range attrs {
+		s.span.Attributes[a.Key] = a.Value
+	}
+}
+
+func (s recorderSpan) RecordError(err error) {
+	s.recorder.mu.Lock()
+	s.span.Err = err
+	s.recorder.mu.Unlock()
+}
+
+func (s recorderSpan) End() {
+	s.recorder.mu.Lock()
+	s.span.End, s.span.Ended = time.Now(), true
+	s.recorder.mu.Unlock()
+}
+
+func attributeMap(attrs []Attribute) map[string]interface{} {
+	m := make(map[string]interface{}, len(attrs))
+	for _, a := range attrs {
+		m[a.Key] = a.Value
+	}
+	return m
+}
+
+// StartSpan records a new span
+func (r *Recorder) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &RecordedSpan{Name: name, Attributes: attributeMap(attrs), Start: time.Now()}
+
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+
+	return ctx, recorderSpan{r, span}
+}
+
+// AddCounter records the increment of a counter
+func (r *Recorder) AddCounter(name string, delta int64, attrs ...Attribute) {
+	r.mu.Lock()
+	r.counters = append(r.counters, Measurement{name, float64(delta), attributeMap(attrs)})
+	r.mu.Unlock()
+}
+
+// RecordHistogram records a value of a histogram
+func (r *Recorder) RecordHistogram(name string, value float64, attrs ...Attribute) {
+	r.mu.Lock()
+	r.measurements = append(r.measurements, Measurement{name, value, attributeMap(attrs)})
+	r.mu.Unlock()
+}
+
+// Spans returns a copy of the spans recorded so far, in the order they were started
+func (r *Recorder) Spans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans := make([]RecordedSpan, len(r.spans))
+	for i, s := range r.spans {
+		spans[i] = *s
+		spans[i].Attributes = make(map[string]interface{}, len(s.Attributes))
+		for k, v := range s.Attributes {
+			spans[i].Attributes[k] = v
+		}
+	}
+	return spans
+}
+
+// Counter returns the total of the named counter over the increments which have all the given attributes
+func (r *Recorder) Counter(name string, attrs ...Attribute) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var total int64
+	for _, m := range r.counters {
+		if m.Name == name && m.matches(attrs) {
+			total += int64(m.Value)
+		}
+	}
+	return total
+}
+
+// Histogram returns the values of the named histogram which have all the given attributes
+func (r *Recorder) Histogram(name string, attrs ...Attribute) []float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var values []float64
+	for _, m := range r.measurements {
+		if m.Name == name && m.matches(attrs) {
+			values = append(values, m.Value)
+		}
+	}
+	return values
+}
+
+// Reset discards everything recorded so far
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.spans, r.counters, r.measurements = nil, nil, nil
+	r.mu.Unlock()
+}
+
+func (m Measurement) matches(attrs []Attribute) bool {
+	for _, a := range attrs {
+		if v, ok := m.Attributes[a.Key]; !ok || v != a.Value {
+			return false
+		}
+	}
+	return true
+}
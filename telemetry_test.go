@@ -0,0 +1,101 @@
+package nexus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	templates := []string{"api/v2/applications?publicId=%s", "api/v2/reports/applications/%s/history", "api/v2/users/%s"}
+
+	tests := []struct {
+		path, templates, want string
+	}{
+		{"api/v2/applications", "yes", "api/v2/applications"},
+		{"api/v2/reports/applications/4bb67dcfc86344e3a483832f8c496419/history", "yes", "api/v2/reports/applications/{id}/history"},
+		{"api/v2/users/jdoe", "yes", "api/v2/users/{id}"},
+		{"api/v2/users/jdoe", "", "api/v2/users/jdoe"},
+		{"service/rest/v1/repositories/maven-123", "", "service/rest/v1/repositories/{id}"},
+	}
+
+	for _, test := range tests {
+		var tmpl []string
+		if test.templates != "" {
+			tmpl = templates
+		}
+		if got := endpointTemplate(test.path, tmpl); got != test.want {
+			t.Errorf("Expected %q for %q but got %q", test.want, test.path, got)
+		}
+	}
+}
+
+func TestEndpointStripsHostPath(t *testing.T) {
+	u, _ := url.Parse("http://localhost:8070/iq/api/v2/users/jdoe?realm=internal")
+	if got := endpointTemplate(relativePath("http://localhost:8070/iq/", u), []string{"api/v2/users/%s"}); got != "api/v2/users/{id}" {
+		t.Errorf("Unexpected endpoint %q", got)
+	}
+}
+
+func TestTelemetry(t *testing.T) {
+	var attempts int
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer mock.Close()
+
+	recorder := NewRecorder()
+	client := NewClient(ServerInfo{Host: mock.URL},
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}),
+		WithTelemetry(recorder),
+		WithEndpoints("api/v2/users/%s"),
+	)
+
+	if _, _, err := client.Get("api/v2/users/jdoe"); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected a span per attempt but got %d", len(spans))
+	}
+
+	for i, want := range []int{http.StatusServiceUnavailable, http.StatusOK} {
+		s := spans[i]
+		if s.Name != "GET api/v2/users/{id}" || !s.Ended {
+			t.Errorf("Unexpected span %+v", s)
+		}
+		if s.Attributes[AttrAttempt] != i+1 || s.Attributes[AttrStatus] != want || s.Attributes[AttrEndpoint] != "api/v2/users/{id}" {
+			t.Errorf("Unexpected attributes of attempt %d: %v", i+1, s.Attributes)
+		}
+	}
+	if spans[0].Err == nil || spans[1].Err != nil {
+		t.Errorf("Expected only the first attempt to record an error but got %v and %v", spans[0].Err, spans[1].Err)
+	}
+
+	route := Attribute{AttrEndpoint, "api/v2/users/{id}"}
+	if n := recorder.Counter(MetricRequests, route); n != 2 {
+		t.Errorf("Expected 2 requests but got %d", n)
+	}
+	if n := recorder.Counter(MetricErrors, Attribute{AttrStatus, http.StatusServiceUnavailable}); n != 1 {
+		t.Errorf("Expected 1 error but got %d", n)
+	}
+	if n := recorder.Counter(MetricRetries, Attribute{AttrStatus, http.StatusOK}); n != 1 {
+		t.Errorf("Expected 1 retry but got %d", n)
+	}
+	if values := recorder.Histogram(MetricDuration, Attribute{AttrMethod, http.MethodGet}); len(values) != 2 {
+		t.Errorf("Expected the duration of both attempts but got %v", values)
+	}
+
+	recorder.Reset()
+	if len(recorder.Spans()) != 0 || recorder.Counter(MetricRequests) != 0 {
+		t.Error("Expected the recorder to be empty after a reset")
+	}
+}
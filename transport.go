@@ -0,0 +1,152 @@
+package nexus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+
+	// Allows the concurrent helpers to keep their connections alive between requests
+	defaultMaxIdleConnsPerHost = 32
+)
+
+// client returns the http.Client used to communicate with the server.
+// Unless one was provided, it is built once and reused so that connections are kept alive
+func (s *DefaultClient) client() *http.Client {
+	client, _ := s.clients()
+	return client
+}
+
+// clients returns the http.Client used to communicate with the server and the one used to stream responses
+func (s *DefaultClient) clients() (client, stream *http.Client) {
+	s.mu.RLock()
+	client, stream = s.built, s.builtStream
+	if s.builtCertFile != s.CertFile {
+		client = nil
+	}
+	s.mu.RUnlock()
+	if client != nil {
+		return client, stream
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.built == nil || s.builtCertFile != s.CertFile {
+		s.build()
+	}
+
+	return s.built, s.builtStream
+}
+
+// build replaces the clients with new ones built from the settings, which must be locked. The previous clients
+// and their transports are left untouched, so that the requests in flight which use them are not affected
+func (s *DefaultClient) build() {
+	if s.httpClient != nil {
+		s.built = s.httpClient
+	} else {
+		timeout := s.timeout
+		if timeout <= 0 {
+			timeout = defaultTimeout
+		}
+		s.built = &http.Client{
+			Timeout:   timeout,
+			Transport: s.buildTransport(),
+		}
+	}
+	s.builtStream = streamClient(s.built)
+	s.builtCertFile = s.CertFile
+}
+
+// streamClient copies the client without its overall timeout, which would cut off the reading of a large body,
+// so that streams are only limited by the context of their request. The timeout instead limits the wait for
+// the response headers when the transport allows it
+func streamClient(client *http.Client) *http.Client {
+	stream := *client
+	stream.Timeout = 0
+
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if t, ok := transport.(*http.Transport); ok && client.Timeout > 0 && t.ResponseHeaderTimeout == 0 {
+		t = t.Clone()
+		t.ResponseHeaderTimeout = client.Timeout
+		stream.Transport = t
+	}
+
+	return &stream
+}
+
+// buildTransport builds the transport of the client, which must be locked
+func (s *DefaultClient) buildTransport() http.RoundTripper {
+	logger := orDefaultLogger(s.logger)
+	tlsAuth, hasTLSAuth := s.auth.(TLSAuthenticator)
+
+	if s.CertFile == "" && !hasTLSAuth {
+		if s.transport != nil {
+			return s.transport
+		}
+		// Cloned so that pooled connections are not shared with other users of http.DefaultTransport
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+		return transport
+	}
+
+	base := s.transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t, ok := base.(*http.Transport)
+	if !ok {
+		logger.Warn("cannot configure TLS of a custom transport")
+		return base
+	}
+
+	transport := t.Clone()
+	if s.transport == nil {
+		transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = new(tls.Config)
+	}
+
+	if s.CertFile != "" {
+		transport.TLSClientConfig.RootCAs = loadRootCAs(logger, s.CertFile)
+	}
+
+	if hasTLSAuth {
+		if err := tlsAuth.ConfigureTLS(transport.TLSClientConfig); err != nil {
+			logger.Warn("could not configure client certificate", "error", err)
+		}
+	}
+
+	return transport
+}
+
+func loadRootCAs(logger Logger, certFile string) *x509.CertPool {
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		logger.Warn("failed to get the system cert pool", "error", err)
+	}
+	if rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	certs, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		logger.Warn("failed to append cert file to RootCAs", "certFile", certFile, "error", err)
+	}
+
+	if ok := rootCAs.AppendCertsFromPEM(certs); !ok {
+		logger.Warn("no certs appended, using system certs only", "certFile", certFile)
+	}
+
+	return rootCAs
+}
@@ -0,0 +1,110 @@
+package nexus
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransportReusesConnections(t *testing.T) {
+	var conns int32
+	mock := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("not here"))
+		}
+	}))
+	mock.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	mock.Start()
+	defer mock.Close()
+
+	client := &DefaultClient{ServerInfo: ServerInfo{Host: mock.URL}}
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Get("ok"); err != nil {
+			t.Fatal(err)
+		}
+		client.Get("fail")
+	}
+
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Errorf("Expected a single connection to be used but %d were opened", n)
+	}
+}
+
+func TestTransportRebuiltOnCertFileChange(t *testing.T) {
+	client := new(DefaultClient)
+
+	first := client.client()
+	if client.client() != first {
+		t.Error("Expected the http client to be reused")
+	}
+
+	client.SetCertFile("testdata/does-not-exist.pem")
+	rebuilt := client.client()
+	if rebuilt == first || rebuilt.Transport == first.Transport {
+		t.Error("Expected the http client to be rebuilt after changing the cert file")
+	}
+
+	// The previous client may still be in use by requests in flight, so it is not changed
+	if tls := first.Transport.(*http.Transport).TLSClientConfig; tls != nil && tls.RootCAs != nil {
+		t.Error("Expected the transport of the previous client not to be changed")
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	client := new(DefaultClient)
+	if timeout := client.client().Timeout; timeout != defaultTimeout {
+		t.Errorf("Expected the default timeout but got %s", timeout)
+	}
+
+	client = NewClient(ServerInfo{}, WithTimeout(5*time.Second))
+	if timeout := client.client().Timeout; timeout != 5*time.Second {
+		t.Errorf("Expected the timeout to be applied but got %s", timeout)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var calls int
+	client := NewClient(ServerInfo{Host: "http://nexus.example"},
+		WithTransport(RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+		})),
+	)
+
+	if _, _, err := client.Get("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected the custom transport to be used")
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	var calls int
+	client := NewClient(ServerInfo{Host: "http://nexus.example"},
+		WithHTTPClient(&http.Client{
+			Transport: RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				calls++
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+			}),
+		}),
+	)
+
+	if _, _, err := client.Get("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected the custom http client to be used")
+	}
+}